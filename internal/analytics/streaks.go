@@ -0,0 +1,61 @@
+package analytics
+
+import "github.com/armitageee/faceit-cli/internal/entity"
+
+// CalculateStreaks calculates win/loss streaks from match results ordered
+// newest first. The current streak is negative for a losing streak.
+func CalculateStreaks(matches []entity.PlayerMatchSummary) (currentStreak int, streakType string, longestWinStreak, longestLossStreak int) {
+	if len(matches) == 0 {
+		return 0, "", 0, 0
+	}
+
+	// Calculate current streak
+	currentStreak = 0
+	streakType = ""
+
+	// Start from the most recent match
+	for _, match := range matches {
+		if match.Result == "Win" {
+			if streakType == "win" || streakType == "" {
+				currentStreak++
+				streakType = "win"
+			} else {
+				break
+			}
+		} else if match.Result == "Loss" {
+			if streakType == "loss" || streakType == "" {
+				currentStreak++
+				streakType = "loss"
+			} else {
+				break
+			}
+		}
+	}
+
+	// Make current streak negative for loss streaks
+	if streakType == "loss" {
+		currentStreak = -currentStreak
+	}
+
+	// Calculate longest streaks
+	var tempWinStreak, tempLossStreak int
+	longestWinStreak, longestLossStreak = 0, 0
+
+	for _, match := range matches {
+		if match.Result == "Win" {
+			tempWinStreak++
+			tempLossStreak = 0
+			if tempWinStreak > longestWinStreak {
+				longestWinStreak = tempWinStreak
+			}
+		} else if match.Result == "Loss" {
+			tempLossStreak++
+			tempWinStreak = 0
+			if tempLossStreak > longestLossStreak {
+				longestLossStreak = tempLossStreak
+			}
+		}
+	}
+
+	return currentStreak, streakType, longestWinStreak, longestLossStreak
+}
@@ -0,0 +1,157 @@
+package analytics
+
+import (
+	"sort"
+
+	"github.com/armitageee/faceit-cli/internal/entity"
+)
+
+// MatchSummary aggregates a player's performance over a list of matches.
+// Averages are taken over all matches, like the statistics screen does.
+type MatchSummary struct {
+	Matches       int     `json:"matches"`
+	Wins          int     `json:"wins"`
+	Losses        int     `json:"losses"`
+	WinRate       float64 `json:"win_rate"`
+	Kills         int     `json:"kills"`
+	Deaths        int     `json:"deaths"`
+	Assists       int     `json:"assists"`
+	AverageKD     float64 `json:"average_kd"`
+	KD            float64 `json:"kd"` // total kills / total deaths
+	AverageHS     float64 `json:"average_hs"`
+	AverageADR    float64 `json:"average_adr"` // over matches that report ADR
+	BestKD        float64 `json:"best_kd"`
+	WorstKD       float64 `json:"worst_kd"`
+	MostPlayedMap string  `json:"most_played_map"`
+}
+
+// Summarize aggregates matches given newest first
+func Summarize(matches []entity.PlayerMatchSummary) MatchSummary {
+	summary := MatchSummary{Matches: len(matches)}
+	if len(matches) == 0 {
+		return summary
+	}
+
+	var totalKD, totalHS, totalADR float64
+	adrMatches := 0
+	mapCounts := make(map[string]int)
+	for i, match := range matches {
+		summary.Kills += match.Kills
+		summary.Deaths += match.Deaths
+		summary.Assists += match.Assists
+		if match.Result == "Win" {
+			summary.Wins++
+		} else {
+			summary.Losses++
+		}
+
+		totalKD += match.KDRatio
+		if i == 0 || match.KDRatio > summary.BestKD {
+			summary.BestKD = match.KDRatio
+		}
+		if i == 0 || match.KDRatio < summary.WorstKD {
+			summary.WorstKD = match.KDRatio
+		}
+		totalHS += match.HeadshotsPercentage
+		if match.ADR > 0 {
+			totalADR += match.ADR
+			adrMatches++
+		}
+		if match.Map != "" {
+			mapCounts[match.Map]++
+		}
+	}
+
+	count := float64(len(matches))
+	summary.WinRate = float64(summary.Wins) / count * 100
+	summary.AverageKD = totalKD / count
+	summary.AverageHS = totalHS / count
+	if adrMatches > 0 {
+		summary.AverageADR = totalADR / float64(adrMatches)
+	}
+	if summary.Deaths > 0 {
+		summary.KD = float64(summary.Kills) / float64(summary.Deaths)
+	} else {
+		summary.KD = float64(summary.Kills)
+	}
+
+	// Ties go to the most recently played map
+	maxCount := 0
+	for _, match := range matches {
+		if c := mapCounts[match.Map]; c > maxCount {
+			maxCount = c
+			summary.MostPlayedMap = match.Map
+		}
+	}
+	return summary
+}
+
+// Metric names a MatchSummary value players can be ranked by
+type Metric string
+
+// Metrics used to rank compared players
+const (
+	MetricAverageKD  Metric = "average_kd"
+	MetricKD         Metric = "kd"
+	MetricWinRate    Metric = "win_rate"
+	MetricAverageHS  Metric = "average_hs"
+	MetricAverageADR Metric = "average_adr"
+	MetricKills      Metric = "kills"
+	MetricDeaths     Metric = "deaths"
+	MetricAssists    Metric = "assists"
+	MetricBestKD     Metric = "best_kd"
+	MetricWorstKD    Metric = "worst_kd"
+)
+
+// Metrics lists the metrics in display order
+var Metrics = []Metric{MetricAverageKD, MetricKD, MetricWinRate, MetricAverageHS, MetricAverageADR, MetricKills, MetricDeaths, MetricAssists, MetricBestKD, MetricWorstKD}
+
+// Value returns the metric's value in a summary
+func (m Metric) Value(s MatchSummary) float64 {
+	switch m {
+	case MetricAverageKD:
+		return s.AverageKD
+	case MetricKD:
+		return s.KD
+	case MetricWinRate:
+		return s.WinRate
+	case MetricAverageHS:
+		return s.AverageHS
+	case MetricAverageADR:
+		return s.AverageADR
+	case MetricKills:
+		return float64(s.Kills)
+	case MetricDeaths:
+		return float64(s.Deaths)
+	case MetricAssists:
+		return float64(s.Assists)
+	case MetricBestKD:
+		return s.BestKD
+	case MetricWorstKD:
+		return s.WorstKD
+	default:
+		return 0
+	}
+}
+
+// LowerIsBetter reports whether smaller values rank higher
+func (m Metric) LowerIsBetter() bool {
+	return m == MetricDeaths
+}
+
+// Rank returns the indices of summaries ordered from best to worst by the
+// metric. Equal values keep their input order.
+func Rank(summaries []MatchSummary, metric Metric) []int {
+	order := make([]int, len(summaries))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := metric.Value(summaries[order[i]]), metric.Value(summaries[order[j]])
+		if metric.LowerIsBetter() {
+			return a < b
+		}
+		return a > b
+	})
+	return order
+}
@@ -0,0 +1,50 @@
+package analytics
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/armitageee/faceit-cli/internal/entity"
+)
+
+func TestSummarize(t *testing.T) {
+	matches := []entity.PlayerMatchSummary{
+		{Map: "de_nuke", Result: "Win", Kills: 20, Deaths: 10, Assists: 5, KDRatio: 2.0, HeadshotsPercentage: 50, ADR: 100},
+		{Map: "de_mirage", Result: "Loss", Kills: 10, Deaths: 20, Assists: 3, KDRatio: 0.5, HeadshotsPercentage: 30},
+		{Map: "de_mirage", Result: "Win", Kills: 15, Deaths: 15, Assists: 2, KDRatio: 1.0, HeadshotsPercentage: 40, ADR: 80},
+	}
+
+	s := Summarize(matches)
+	if s.Matches != 3 || s.Wins != 2 || s.Losses != 1 {
+		t.Errorf("Expected 2-1 over 3 matches, got %d-%d over %d", s.Wins, s.Losses, s.Matches)
+	}
+	if s.Kills != 45 || s.Deaths != 45 || s.Assists != 10 || s.KD != 1 {
+		t.Errorf("Unexpected totals %+v", s)
+	}
+	if math.Abs(s.AverageKD-3.5/3) > 1e-9 || s.AverageHS != 40 || s.AverageADR != 90 {
+		t.Errorf("Unexpected averages %+v", s)
+	}
+	if s.BestKD != 2 || s.WorstKD != 0.5 || s.MostPlayedMap != "de_mirage" {
+		t.Errorf("Unexpected extremes %+v", s)
+	}
+
+	if empty := Summarize(nil); empty != (MatchSummary{}) {
+		t.Errorf("Expected an empty summary, got %+v", empty)
+	}
+}
+
+func TestRank(t *testing.T) {
+	summaries := []MatchSummary{
+		{KD: 1.0, Deaths: 30},
+		{KD: 1.5, Deaths: 10},
+		{KD: 1.0, Deaths: 20},
+	}
+
+	if got := Rank(summaries, MetricKD); !reflect.DeepEqual(got, []int{1, 0, 2}) {
+		t.Errorf("Rank(kd) = %v", got)
+	}
+	if got := Rank(summaries, MetricDeaths); !reflect.DeepEqual(got, []int{1, 2, 0}) {
+		t.Errorf("Rank(deaths) = %v", got)
+	}
+}
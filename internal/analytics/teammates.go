@@ -0,0 +1,168 @@
+package analytics
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/armitageee/faceit-cli/internal/entity"
+)
+
+// PlayerFrequency describes how often a player shared a lobby with the
+// analysed player and how those matches went for the analysed player
+type PlayerFrequency struct {
+	PlayerID   string  `json:"player_id"`
+	Nickname   string  `json:"nickname"`
+	Matches    int     `json:"matches"`
+	Wins       int     `json:"wins"`
+	Losses     int     `json:"losses"`
+	WinRate    float64 `json:"win_rate"`
+	LastPlayed int64   `json:"last_played"`
+}
+
+// Frequencies holds the most frequent teammates and opponents
+type Frequencies struct {
+	Matches   int               `json:"matches"`
+	Teammates []PlayerFrequency `json:"teammates"`
+	Opponents []PlayerFrequency `json:"opponents"`
+}
+
+// CalculateFrequencies counts how often each player appeared as a teammate
+// or an opponent. Lists are ordered by number of matches, then by win rate
+// and nickname. Matches are expected newest first.
+func CalculateFrequencies(matches []entity.PlayerMatchSummary) Frequencies {
+	teammates := make(map[string]*PlayerFrequency)
+	opponents := make(map[string]*PlayerFrequency)
+
+	for _, match := range matches {
+		won := match.Result == "Win"
+		for _, p := range match.Teammates {
+			countParticipant(teammates, p, won, match.FinishedAt)
+		}
+		for _, p := range match.Opponents {
+			countParticipant(opponents, p, won, match.FinishedAt)
+		}
+	}
+
+	return Frequencies{
+		Matches:   len(matches),
+		Teammates: sortFrequencies(teammates),
+		Opponents: sortFrequencies(opponents),
+	}
+}
+
+// countParticipant adds one match to a participant's tally
+func countParticipant(byID map[string]*PlayerFrequency, p entity.MatchParticipant, won bool, finishedAt int64) {
+	if p.PlayerID == "" {
+		return
+	}
+	freq, ok := byID[p.PlayerID]
+	if !ok {
+		// Matches arrive newest first, so the first nickname seen is
+		// the current one
+		freq = &PlayerFrequency{PlayerID: p.PlayerID, Nickname: p.Nickname, LastPlayed: finishedAt}
+		byID[p.PlayerID] = freq
+	}
+	freq.Matches++
+	if won {
+		freq.Wins++
+	} else {
+		freq.Losses++
+	}
+}
+
+// sortFrequencies flattens and orders a tally
+func sortFrequencies(byID map[string]*PlayerFrequency) []PlayerFrequency {
+	result := make([]PlayerFrequency, 0, len(byID))
+	for _, freq := range byID {
+		freq.WinRate = float64(freq.Wins) / float64(freq.Matches) * 100
+		result = append(result, *freq)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Matches != b.Matches {
+			return a.Matches > b.Matches
+		}
+		if a.WinRate != b.WinRate {
+			return a.WinRate > b.WinRate
+		}
+		return a.Nickname < b.Nickname
+	})
+	return result
+}
+
+// Top returns at most n players from the start of a frequency list
+func Top(list []PlayerFrequency, n int) []PlayerFrequency {
+	if n > 0 && len(list) > n {
+		return list[:n]
+	}
+	return list
+}
+
+// WriteFrequenciesJSON writes the frequencies as indented JSON
+func WriteFrequenciesJSON(w io.Writer, freq Frequencies) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(freq)
+}
+
+// WriteFrequenciesCSV writes one row per player with a role column set to
+// "teammate" or "opponent"
+func WriteFrequenciesCSV(w io.Writer, freq Frequencies) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"role", "player_id", "nickname", "matches", "wins", "losses", "win_rate", "last_played"}); err != nil {
+		return err
+	}
+	for _, section := range []struct {
+		role string
+		list []PlayerFrequency
+	}{
+		{"teammate", freq.Teammates},
+		{"opponent", freq.Opponents},
+	} {
+		for _, p := range section.list {
+			record := []string{
+				section.role,
+				p.PlayerID,
+				p.Nickname,
+				strconv.Itoa(p.Matches),
+				strconv.Itoa(p.Wins),
+				strconv.Itoa(p.Losses),
+				strconv.FormatFloat(p.WinRate, 'f', 1, 64),
+				time.Unix(p.LastPlayed, 0).UTC().Format(time.RFC3339),
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteFrequenciesTable writes the frequencies as aligned plain-text tables
+func WriteFrequenciesTable(w io.Writer, freq Frequencies) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Analysed matches: %d\n", freq.Matches)
+	for _, section := range []struct {
+		title string
+		list  []PlayerFrequency
+	}{
+		{"Teammates", freq.Teammates},
+		{"Opponents", freq.Opponents},
+	} {
+		fmt.Fprintf(tw, "\n%s\n", section.title)
+		fmt.Fprintln(tw, "NICKNAME\tMATCHES\tW-L\tWIN%\tLAST PLAYED")
+		for _, p := range section.list {
+			fmt.Fprintf(tw, "%s\t%d\t%d-%d\t%.1f\t%s\n",
+				p.Nickname, p.Matches, p.Wins, p.Losses, p.WinRate,
+				time.Unix(p.LastPlayed, 0).Format("2006-01-02"))
+		}
+	}
+	return tw.Flush()
+}
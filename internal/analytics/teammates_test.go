@@ -0,0 +1,102 @@
+package analytics
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/armitageee/faceit-cli/internal/entity"
+)
+
+func frequencyMatches() []entity.PlayerMatchSummary {
+	duo := entity.MatchParticipant{PlayerID: "p-duo", Nickname: "duo"}
+	rando := entity.MatchParticipant{PlayerID: "p-rando", Nickname: "rando"}
+	rival := entity.MatchParticipant{PlayerID: "p-rival", Nickname: "rival"}
+
+	return []entity.PlayerMatchSummary{
+		{MatchID: "m1", Result: "Win", FinishedAt: 3000, Teammates: []entity.MatchParticipant{duo}, Opponents: []entity.MatchParticipant{rival}},
+		{MatchID: "m2", Result: "Loss", FinishedAt: 2000, Teammates: []entity.MatchParticipant{{PlayerID: "p-duo", Nickname: "old-duo"}, rando}, Opponents: []entity.MatchParticipant{rival}},
+		{MatchID: "m3", Result: "Win", FinishedAt: 1000, Teammates: []entity.MatchParticipant{duo}},
+	}
+}
+
+func TestCalculateFrequencies(t *testing.T) {
+	freq := CalculateFrequencies(frequencyMatches())
+
+	if freq.Matches != 3 {
+		t.Errorf("Expected 3 analysed matches, got %d", freq.Matches)
+	}
+	if len(freq.Teammates) != 2 {
+		t.Fatalf("Expected 2 teammates, got %d", len(freq.Teammates))
+	}
+
+	duo := freq.Teammates[0]
+	if duo.Nickname != "duo" {
+		t.Errorf("Expected the most recent nickname 'duo' first, got %q", duo.Nickname)
+	}
+	if duo.Matches != 3 || duo.Wins != 2 || duo.Losses != 1 {
+		t.Errorf("Expected duo 2-1 over 3 matches, got %d-%d over %d", duo.Wins, duo.Losses, duo.Matches)
+	}
+	if duo.LastPlayed != 3000 {
+		t.Errorf("Expected last played 3000, got %d", duo.LastPlayed)
+	}
+	if duo.WinRate < 66.6 || duo.WinRate > 66.7 {
+		t.Errorf("Expected win rate 66.7, got %.2f", duo.WinRate)
+	}
+
+	if len(freq.Opponents) != 1 || freq.Opponents[0].Nickname != "rival" || freq.Opponents[0].WinRate != 50 {
+		t.Errorf("Expected rival at 50%% as the only opponent, got %+v", freq.Opponents)
+	}
+}
+
+func TestTop(t *testing.T) {
+	list := []PlayerFrequency{{Nickname: "a"}, {Nickname: "b"}, {Nickname: "c"}}
+	if got := Top(list, 2); len(got) != 2 {
+		t.Errorf("Expected 2 players, got %d", len(got))
+	}
+	if got := Top(list, 0); len(got) != 3 {
+		t.Errorf("Expected all players for n=0, got %d", len(got))
+	}
+}
+
+func TestWriteFrequencies(t *testing.T) {
+	freq := CalculateFrequencies(frequencyMatches())
+
+	var jsonOut bytes.Buffer
+	if err := WriteFrequenciesJSON(&jsonOut, freq); err != nil {
+		t.Fatalf("WriteFrequenciesJSON failed: %v", err)
+	}
+	var decoded Frequencies
+	if err := json.Unmarshal(jsonOut.Bytes(), &decoded); err != nil {
+		t.Fatalf("Invalid JSON output: %v", err)
+	}
+	if len(decoded.Teammates) != 2 || decoded.Teammates[0].PlayerID != "p-duo" {
+		t.Errorf("Unexpected JSON teammates: %+v", decoded.Teammates)
+	}
+
+	var csvOut bytes.Buffer
+	if err := WriteFrequenciesCSV(&csvOut, freq); err != nil {
+		t.Fatalf("WriteFrequenciesCSV failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(csvOut.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("Expected header and 3 rows, got %d lines", len(lines))
+	}
+	if !strings.HasPrefix(lines[1], "teammate,p-duo,duo,3,2,1,66.7,") {
+		t.Errorf("Unexpected first CSV row: %s", lines[1])
+	}
+	if !strings.HasPrefix(lines[3], "opponent,p-rival,rival,2,1,1,50.0,") {
+		t.Errorf("Unexpected last CSV row: %s", lines[3])
+	}
+
+	var tableOut bytes.Buffer
+	if err := WriteFrequenciesTable(&tableOut, freq); err != nil {
+		t.Fatalf("WriteFrequenciesTable failed: %v", err)
+	}
+	for _, want := range []string{"Teammates", "Opponents", "duo", "rival", "2-1"} {
+		if !strings.Contains(tableOut.String(), want) {
+			t.Errorf("Expected table output to contain %q", want)
+		}
+	}
+}
@@ -2,17 +2,25 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/armitageee/faceit-cli/internal/cache"
 	"github.com/armitageee/faceit-cli/internal/config"
 	"github.com/armitageee/faceit-cli/internal/logger"
+	"github.com/armitageee/faceit-cli/internal/notify"
 	"github.com/armitageee/faceit-cli/internal/repository"
+	"github.com/armitageee/faceit-cli/internal/store"
 	"github.com/armitageee/faceit-cli/internal/telemetry"
 	"github.com/armitageee/faceit-cli/internal/ui"
+	"github.com/armitageee/faceit-cli/internal/watch"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 )
@@ -21,30 +29,83 @@ import (
 type App struct {
 	config     *config.Config
 	repo       repository.FaceitRepository
+	store      *store.Store // nil when the history database is disabled
+	recordElo  watch.Recorder // nil when the history database is disabled
 	logger     *logger.Logger
 	telemetry  *telemetry.Telemetry
 }
 
 // NewApp creates a new application instance
 func NewApp(cfg *config.Config, appLogger *logger.Logger, telemetryInstance *telemetry.Telemetry) *App {
-	// Initialize repository with telemetry support
-	var repo repository.FaceitRepository = repository.NewFaceitRepository(cfg.FaceitAPIKey, telemetryInstance)
+	// Limit the API requests themselves, so cached responses are not
+	// delayed and calls making several requests count each of them
+	var httpClient *http.Client
+	if cfg.RateLimit > 0 {
+		httpClient = &http.Client{
+			Transport: repository.NewRateLimitedTransport(nil, float64(cfg.RateLimit), cfg.RateLimit).
+				WithMetrics(telemetryInstance.Metrics()),
+		}
+	}
+	var repo repository.FaceitRepository = repository.NewFaceitRepositoryWithClient(cfg.FaceitAPIKey, telemetryInstance, httpClient)
+
+	// Record below the cache so only fresh API responses are written. The
+	// watchers record the ELO changes they see, which the matches loaded
+	// later are returned with.
+	history := openHistory(cfg, appLogger)
+	var recordElo watch.Recorder
+	if history != nil {
+		recording := store.NewRecordingRepository(repo, history, func(err error) {
+			appLogger.Warn("Failed to record match history", map[string]interface{}{
+				"error": err.Error(),
+			})
+		})
+		repo = recording
+		recordElo = recording.RecordEloChange
+	}
 	
 	if cfg.CacheEnabled {
 		appLogger.Info("Cache enabled", map[string]interface{}{
 			"ttl_minutes": cfg.CacheTTL,
 		})
-		repo = cache.NewCachedFaceitRepository(repo, time.Duration(cfg.CacheTTL)*time.Minute)
+		repo = cache.NewCachedFaceitRepository(repo, time.Duration(cfg.CacheTTL)*time.Minute).
+			WithMetrics(telemetryInstance.Metrics())
 	}
 	
 	return &App{
 		config:    cfg,
 		repo:      repo,
+		store:     history,
+		recordElo: recordElo,
 		logger:    appLogger,
 		telemetry: telemetryInstance,
 	}
 }
 
+// openHistory opens the match history database. A database that cannot be
+// opened is logged and the application runs without it.
+func openHistory(cfg *config.Config, appLogger *logger.Logger) *store.Store {
+	if !cfg.HistoryEnabled || cfg.HistoryPath == "" {
+		return nil
+	}
+	history, err := store.Open(cfg.HistoryPath)
+	if err != nil {
+		appLogger.Warn("Match history disabled", map[string]interface{}{
+			"path":  cfg.HistoryPath,
+			"error": err.Error(),
+		})
+		return nil
+	}
+	return history
+}
+
+// Close releases the resources held by the application
+func (a *App) Close() error {
+	if a.store == nil {
+		return nil
+	}
+	return a.store.Close()
+}
+
 // Run starts the application
 func (a *App) Run(ctx context.Context) error {
 	if a.telemetry != nil {
@@ -57,6 +118,9 @@ func (a *App) Run(ctx context.Context) error {
 
 // runInternal contains the actual run logic
 func (a *App) runInternal(ctx context.Context) error {
+	stopMetrics := a.serveMetrics()
+	defer stopMetrics()
+
 	a.logger.Info("Initializing UI model")
 	
 	// Create a span for UI initialization if telemetry is enabled
@@ -68,8 +132,12 @@ func (a *App) runInternal(ctx context.Context) error {
 			attribute.Int("matches_per_page", a.config.MatchesPerPage),
 		)
 		
-		model := ui.InitialModel(a.repo, a.config, a.logger)
+		output := notify.NewOutput(os.Stdout)
+		model, err := a.newModel(output)
 		span.End()
+		if err != nil {
+			return err
+		}
 		
 		a.logger.Info("Starting TUI program")
 		
@@ -77,7 +145,7 @@ func (a *App) runInternal(ctx context.Context) error {
 		_, tuiSpan := a.telemetry.StartSpan(ctx, "app.tui_execution")
 		defer tuiSpan.End()
 		
-		p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
+		p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion(), tea.WithOutput(output))
 		
 		if _, err := p.Run(); err != nil {
 			tuiSpan.RecordError(err)
@@ -94,10 +162,14 @@ func (a *App) runInternal(ctx context.Context) error {
 	}
 	
 	// No telemetry - run without tracing
-	model := ui.InitialModel(a.repo, a.config, a.logger)
+	output := notify.NewOutput(os.Stdout)
+	model, err := a.newModel(output)
+	if err != nil {
+		return err
+	}
 	a.logger.Info("Starting TUI program")
 	
-	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion(), tea.WithOutput(output))
 	
 	if _, err := p.Run(); err != nil {
 		a.logger.Error("TUI program failed", map[string]interface{}{
@@ -109,3 +181,73 @@ func (a *App) runInternal(ctx context.Context) error {
 	a.logger.Info("TUI program completed successfully")
 	return nil
 }
+
+// newModel creates the UI model with the configured notifiers, key
+// bindings and theme. A broken notification setup is logged rather than
+// preventing the UI from starting; invalid key bindings and themes are an
+// error, as keys might otherwise silently do something other than
+// configured. Terminal notifications are written to output, which the
+// program must render on so the two never interleave.
+func (a *App) newModel(output *notify.Output) (ui.AppModel, error) {
+	keys, err := ui.NewKeyMap(a.config.KeyBindings)
+	if err != nil {
+		return ui.AppModel{}, fmt.Errorf("invalid keys in config.yml: %w", err)
+	}
+	theme, err := ui.LoadTheme(a.config.Theme, a.config.Themes, lipgloss.HasDarkBackground)
+	if err != nil {
+		return ui.AppModel{}, fmt.Errorf("invalid theme: %w", err)
+	}
+	ui.SetTheme(theme)
+	notifier, err := notify.FromConfig(a.config, output, dryRunLog{a.logger})
+	if err != nil {
+		a.logger.Warn("Notifications disabled", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+	return ui.InitialModel(a.repo, a.config, a.logger).
+		WithKeyMap(keys).
+		WithNotifier(notifier).
+		WithEloRecorder(a.recordElo).
+		WithMetrics(a.telemetry.Metrics()), nil
+}
+
+// dryRunLog logs the webhook payloads of a dry run, which would otherwise
+// be written over the TUI
+type dryRunLog struct {
+	logger *logger.Logger
+}
+
+// Write implements io.Writer
+func (d dryRunLog) Write(p []byte) (int, error) {
+	d.logger.Info("Webhook dry run", map[string]interface{}{
+		"payload": strings.TrimSpace(string(p)),
+	})
+	return len(p), nil
+}
+
+// serveMetrics exposes the Prometheus /metrics endpoint on the configured
+// address in the background when metrics are enabled. The returned
+// function stops the listener.
+func (a *App) serveMetrics() func() {
+	handler := a.telemetry.MetricsHandler()
+	if handler == nil || a.config.MetricsAddr == "" {
+		return func() {}
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", handler)
+	srv := &http.Server{Addr: a.config.MetricsAddr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			a.logger.Warn("Metrics endpoint unavailable", map[string]interface{}{
+				"addr":  a.config.MetricsAddr,
+				"error": err.Error(),
+			})
+		}
+	}()
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(ctx)
+	}
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/armitageee/faceit-cli/internal/analytics"
+)
+
+// defaultExportMatches is the number of matches analysed by the headless
+// exports unless --matches is given
+const defaultExportMatches = 50
+
+// ExportTeammates prints a player's most frequent teammates and opponents
+// without starting the TUI. Usage:
+//
+//	faceit-cli teammates <nickname> [--matches 50] [--top 10] [--format table|json|csv]
+func (a *App) ExportTeammates(ctx context.Context, args []string, w io.Writer) error {
+	flags := flag.NewFlagSet("teammates", flag.ContinueOnError)
+	matches := flags.Int("matches", defaultExportMatches, "number of recent matches to analyse")
+	top := flags.Int("top", 10, "number of teammates and opponents to list (0 for all)")
+	format := flags.String("format", "table", "output format: table, json or csv")
+
+	nickname, err := parseNicknameArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if *matches <= 0 {
+		return fmt.Errorf("--matches must be positive")
+	}
+
+	player, err := a.repo.GetPlayerByNickname(ctx, nickname)
+	if err != nil {
+		return fmt.Errorf("load player: %w", err)
+	}
+	history, err := a.repo.GetPlayerRecentMatches(ctx, player.ID, "cs2", *matches)
+	if err != nil {
+		return fmt.Errorf("load matches: %w", err)
+	}
+
+	freq := analytics.CalculateFrequencies(history)
+	freq.Teammates = analytics.Top(freq.Teammates, *top)
+	freq.Opponents = analytics.Top(freq.Opponents, *top)
+
+	switch strings.ToLower(*format) {
+	case "json":
+		return analytics.WriteFrequenciesJSON(w, freq)
+	case "csv":
+		return analytics.WriteFrequenciesCSV(w, freq)
+	case "table":
+		return analytics.WriteFrequenciesTable(w, freq)
+	default:
+		return fmt.Errorf("unknown format %q (use table, json or csv)", *format)
+	}
+}
+
+// parseNicknameArgs parses flags given before or after a single nickname
+// argument and returns the nickname
+func parseNicknameArgs(flags *flag.FlagSet, args []string) (string, error) {
+	nicknames, err := parseNicknameList(flags, args)
+	if err != nil {
+		return "", err
+	}
+	if len(nicknames) != 1 {
+		return "", fmt.Errorf("usage: faceit-cli %s <nickname> [flags]", flags.Name())
+	}
+	return nicknames[0], nil
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"flag"
+	"io"
+	"testing"
+)
+
+func TestParseNicknameArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+		format   string
+		wantErr  bool
+	}{
+		{"nickname only", []string{"s1mple"}, "s1mple", "table", false},
+		{"flags after nickname", []string{"s1mple", "--format", "json"}, "s1mple", "json", false},
+		{"flags before nickname", []string{"--format", "csv", "s1mple"}, "s1mple", "csv", false},
+		{"missing nickname", []string{"--format", "csv"}, "", "", true},
+		{"unknown flag", []string{"s1mple", "--bogus"}, "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := flag.NewFlagSet("teammates", flag.ContinueOnError)
+			flags.SetOutput(io.Discard)
+			format := flags.String("format", "table", "")
+
+			nickname, err := parseNicknameArgs(flags, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseNicknameArgs(%v) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if nickname != tt.expected || *format != tt.format {
+				t.Errorf("parseNicknameArgs(%v) = %q (format %q), want %q (format %q)", tt.args, nickname, *format, tt.expected, tt.format)
+			}
+		})
+	}
+}
+
+func TestParseNicknameList(t *testing.T) {
+	flags := flag.NewFlagSet("watch", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	plain := flags.Bool("plain", false, "")
+
+	nicknames, err := parseNicknameList(flags, []string{"alice", "--plain", "bob", "carol"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(nicknames) != 3 || nicknames[0] != "alice" || nicknames[2] != "carol" {
+		t.Errorf("Expected [alice bob carol], got %v", nicknames)
+	}
+	if !*plain {
+		t.Error("Expected --plain to be parsed between nicknames")
+	}
+}
@@ -0,0 +1,103 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/armitageee/faceit-cli/internal/exporter"
+)
+
+// Exporter serves per-player gauges (ELO, skill level, K/D, recent win
+// rate, streak) for Prometheus to scrape. Usage:
+//
+//	faceit-cli exporter --players nick1,nick2 [--addr :9465] [--interval 5m] [--matches 20]
+//
+// Without --players the configured watchlist is used. The gauges live on
+// their own registry; the application metrics keep their own endpoint.
+func (a *App) Exporter(ctx context.Context, args []string, w io.Writer) error {
+	flags := flag.NewFlagSet("exporter", flag.ContinueOnError)
+	players := flags.String("players", "", "comma-separated nicknames to export")
+	addr := flags.String("addr", ":9465", "address to serve /metrics on")
+	interval := flags.Duration("interval", exporter.DefaultInterval, "time between polls")
+	matches := flags.Int("matches", exporter.DefaultMatches, "recent matches used for the win rate and streak")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("usage: faceit-cli exporter --players nick1,nick2 [--addr :9465] [--interval 5m]")
+	}
+
+	var nicknames []string
+	for _, nickname := range strings.Split(*players, ",") {
+		if nickname = strings.TrimSpace(nickname); nickname != "" {
+			nicknames = append(nicknames, nickname)
+		}
+	}
+	if len(nicknames) == 0 {
+		nicknames = a.config.WatchPlayers
+	}
+	if len(nicknames) == 0 {
+		return fmt.Errorf("usage: faceit-cli exporter --players nick1,nick2 (or set watch_players in the config)")
+	}
+	if minInterval := exporter.MinIntervalFor(*matches); *interval > 0 && *interval < minInterval {
+		fmt.Fprintf(os.Stderr, "Interval raised to the minimum of %s for %d matches\n", minInterval, *matches)
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	stopMetrics := a.serveMetrics()
+	defer stopMetrics()
+
+	exp := exporter.New(a.repo, nicknames, *interval, *matches)
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", *addr, err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", exp.Handler())
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.Serve(listener)
+	}()
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Fprintf(w, "Exporting %d players on http://%s/metrics every %s (Ctrl+C to stop)\n",
+		len(nicknames), listener.Addr(), exp.Interval())
+	a.logger.Info("Player exporter started", map[string]interface{}{
+		"addr":    listener.Addr().String(),
+		"players": len(nicknames),
+	})
+
+	pollCtx, cancelPolls := context.WithCancel(ctx)
+	defer cancelPolls()
+	go func() {
+		if err := <-serveErr; err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Fprintf(os.Stderr, "Metrics server failed: %v\n", err)
+		}
+		cancelPolls()
+	}()
+
+	err = exp.Run(pollCtx, func(err error) {
+		fmt.Fprintf(os.Stderr, "%s  poll failed: %v\n", time.Now().Format("15:04:05"), err)
+	})
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
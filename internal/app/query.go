@@ -0,0 +1,113 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/armitageee/faceit-cli/internal/query"
+	"github.com/armitageee/faceit-cli/internal/store"
+)
+
+// listFlag collects comma-separated values from one or more uses of a flag
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(value string) error {
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*l = append(*l, item)
+		}
+	}
+	return nil
+}
+
+// Query filters and aggregates a player's stored matches. Usage:
+//
+//	faceit-cli query [--player nick] 'map = "de_nuke" and kd > 1.3' [--group-by map] [--agg avg(adr)] [--sort -count] [--limit 10] [--format table|json]
+//
+// The player defaults to the configured default player. Only matches in
+// the history database are queried; run faceit-cli sync first.
+func (a *App) Query(ctx context.Context, args []string, w io.Writer) error {
+	flags := flag.NewFlagSet("query", flag.ContinueOnError)
+	player := flags.String("player", a.config.DefaultPlayer, "player whose matches are queried")
+	var groupBy, aggs listFlag
+	flags.Var(&groupBy, "group-by", "comma-separated fields to group by")
+	flags.Var(&aggs, "agg", "comma-separated aggregates: count, avg(field), sum(field), min(field), max(field)")
+	sortBy := flags.String("sort", "", "column to sort by, prefixed with - for descending order")
+	limit := flags.Int("limit", 0, "maximum number of rows (0 for all)")
+	format := flags.String("format", "table", "output format: table or json")
+	listFields := flags.Bool("fields", false, "list the fields that can be queried")
+
+	// Every positional argument is part of the expression, so flags may
+	// come before or after it
+	terms, err := parseNicknameList(flags, args)
+	if err != nil {
+		return err
+	}
+	if *listFields {
+		return writeQueryFields(w)
+	}
+	if *player == "" {
+		return fmt.Errorf("usage: faceit-cli query --player <nickname> '<filter>' (or set default_player in the config)")
+	}
+	if a.store == nil {
+		return fmt.Errorf("match history is disabled (set history_enabled: true in the config)")
+	}
+
+	q := query.Query{GroupBy: groupBy, Sort: *sortBy, Limit: *limit}
+	q.Filter, err = query.Parse(strings.Join(terms, " "))
+	if err != nil {
+		return fmt.Errorf("invalid query: %w", err)
+	}
+	for _, text := range aggs {
+		agg, err := query.ParseAggregate(text)
+		if err != nil {
+			return err
+		}
+		q.Aggregates = append(q.Aggregates, agg)
+	}
+	switch strings.ToLower(*format) {
+	case "table", "json":
+	default:
+		return fmt.Errorf("unknown format %q (use table or json)", *format)
+	}
+
+	playerID, err := a.store.PlayerID(ctx, *player)
+	if errors.Is(err, store.ErrNotFound) {
+		return fmt.Errorf("no stored matches for %s; run faceit-cli sync %s first", *player, *player)
+	}
+	if err != nil {
+		return err
+	}
+	matches, err := a.store.Matches(ctx, playerID, "cs2", 0)
+	if err != nil {
+		return err
+	}
+
+	table, err := query.Run(q, matches)
+	if err != nil {
+		return err
+	}
+	if strings.ToLower(*format) == "json" {
+		return query.WriteJSON(w, table)
+	}
+	return query.WriteTable(w, table)
+}
+
+// writeQueryFields lists the queryable fields with a short description
+func writeQueryFields(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "FIELD\tDESCRIPTION")
+	for _, name := range query.FieldNames() {
+		fmt.Fprintf(tw, "%s\t%s\n", name, query.FieldHelp(name))
+	}
+	return tw.Flush()
+}
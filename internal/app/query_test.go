@@ -0,0 +1,33 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestQueryStoredMatches(t *testing.T) {
+	a := newSyncApp(t, newHistoryRepository(5))
+	if err := a.Sync(context.Background(), []string{"alice"}, &bytes.Buffer{}); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+
+	var out bytes.Buffer
+	err := a.Query(context.Background(), []string{"--player", "ALICE", "kd", ">=", "0", "--agg", "count", "--format", "json"}, &out)
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if !strings.Contains(out.String(), `"count": 5`) {
+		t.Errorf("Unexpected output:\n%s", out.String())
+	}
+
+	err = a.Query(context.Background(), []string{"--player", "bob", "kd > 1"}, &out)
+	if err == nil || !strings.Contains(err.Error(), "faceit-cli sync bob") {
+		t.Errorf("Expected a hint to sync bob, got %v", err)
+	}
+	err = a.Query(context.Background(), []string{"--player", "alice", "kd >"}, &out)
+	if err == nil || !strings.Contains(err.Error(), "invalid query") {
+		t.Errorf("Expected a syntax error, got %v", err)
+	}
+}
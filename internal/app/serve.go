@@ -0,0 +1,44 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/armitageee/faceit-cli/internal/server"
+)
+
+// Serve exposes the repository, including its cache and rate limit, as a
+// JSON API. When metrics are enabled they are served on /metrics of the
+// same address. Usage:
+//
+//	faceit-cli serve [--addr :8080]
+func (a *App) Serve(ctx context.Context, args []string, w io.Writer) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := flags.String("addr", ":8080", "address to listen on")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("usage: faceit-cli serve [--addr :8080]")
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	srv := server.New(a.repo, a.telemetry, a.logger, a.config.ComparisonMatches)
+	if handler := a.telemetry.MetricsHandler(); handler != nil {
+		srv.Mount("GET /metrics", handler)
+	}
+	return srv.ListenAndServe(ctx, *addr, func(bound net.Addr) {
+		fmt.Fprintf(w, "Serving the FACEIT API on http://%s (Ctrl+C to stop)\n", bound)
+		a.logger.Info("API server started", map[string]interface{}{
+			"addr": bound.String(),
+		})
+	})
+}
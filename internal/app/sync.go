@@ -0,0 +1,124 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/armitageee/faceit-cli/internal/cache"
+)
+
+// syncPageSize is the number of matches checked first by an incremental
+// sync; the full history is only fetched when all of them are new
+const syncPageSize = 100
+
+// defaultSyncLimit is the number of matches backfilled unless --limit is
+// given
+const defaultSyncLimit = 1000
+
+// Sync backfills a player's match history into the local database. Usage:
+//
+//	faceit-cli sync <nickname> [--limit 1000] [--full] [--stats]
+//
+// Once a player has been synced only the newest page is fetched unless it
+// contains no known match. --stats also stores the scoreboard of every
+// match, one API request each.
+func (a *App) Sync(ctx context.Context, args []string, w io.Writer) error {
+	flags := flag.NewFlagSet("sync", flag.ContinueOnError)
+	limit := flags.Int("limit", defaultSyncLimit, "maximum number of matches to backfill")
+	full := flags.Bool("full", false, "fetch the whole history even when the database is up to date")
+	withStats := flags.Bool("stats", false, "also store match scoreboards")
+
+	nickname, err := parseNicknameArgs(flags, args)
+	if err != nil {
+		return err
+	}
+	if *limit <= 0 {
+		return fmt.Errorf("--limit must be positive")
+	}
+	if a.store == nil {
+		return fmt.Errorf("match history is disabled (set history_enabled: true in the config)")
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	// Skip the cache: a sync must see matches finished since the last one
+	ctx = cache.WithMaxAge(ctx, time.Nanosecond)
+
+	player, err := a.repo.GetPlayerByNickname(ctx, nickname)
+	if err != nil {
+		return fmt.Errorf("load player: %w", err)
+	}
+	before, err := a.store.MatchCount(ctx, player.ID, "cs2")
+	if err != nil {
+		return err
+	}
+
+	// The recording repository stores whatever is fetched
+	fetch := *limit
+	if before > 0 && !*full && syncPageSize < fetch {
+		page, err := a.repo.GetPlayerRecentMatches(ctx, player.ID, "cs2", syncPageSize)
+		if err != nil {
+			return fmt.Errorf("load matches: %w", err)
+		}
+		count, err := a.store.MatchCount(ctx, player.ID, "cs2")
+		if err != nil {
+			return err
+		}
+		if count-before < len(page) || len(page) < syncPageSize {
+			fetch = 0
+		}
+	}
+	if fetch > 0 {
+		fmt.Fprintf(w, "Fetching up to %d matches of %s...\n", fetch, player.Nickname)
+		if _, err := a.repo.GetPlayerRecentMatches(ctx, player.ID, "cs2", fetch); err != nil {
+			return fmt.Errorf("load matches: %w", err)
+		}
+	}
+
+	after, err := a.store.MatchCount(ctx, player.ID, "cs2")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "%s: %d new matches, %d stored\n", player.Nickname, after-before, after)
+
+	if !*withStats {
+		return nil
+	}
+	return a.syncMatchStats(ctx, player.ID, w)
+}
+
+// syncMatchStats stores the scoreboards missing for a player's stored
+// matches. Failed matches are reported and retried on the next sync.
+func (a *App) syncMatchStats(ctx context.Context, playerID string, w io.Writer) error {
+	missing, err := a.store.MatchesWithoutStats(ctx, playerID, "cs2")
+	if err != nil {
+		return err
+	}
+	if len(missing) == 0 {
+		fmt.Fprintln(w, "All scoreboards already stored")
+		return nil
+	}
+
+	fmt.Fprintf(w, "Fetching %d scoreboards...\n", len(missing))
+	stored, failed := 0, 0
+	for _, matchID := range missing {
+		if _, err := a.repo.GetMatchStats(ctx, matchID); err != nil {
+			if errors.Is(err, context.Canceled) || ctx.Err() != nil {
+				break
+			}
+			fmt.Fprintf(os.Stderr, "Match %s: %v\n", matchID, err)
+			failed++
+			continue
+		}
+		stored++
+	}
+	fmt.Fprintf(w, "%d scoreboards stored, %d failed\n", stored, failed)
+	return nil
+}
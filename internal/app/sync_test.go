@@ -0,0 +1,144 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/armitageee/faceit-cli/internal/config"
+	"github.com/armitageee/faceit-cli/internal/entity"
+	"github.com/armitageee/faceit-cli/internal/store"
+)
+
+// historyRepository serves a fixed match history, newest first, and
+// records the limit of every history request
+type historyRepository struct {
+	matches []entity.PlayerMatchSummary
+	limits  []int
+}
+
+func newHistoryRepository(count int) *historyRepository {
+	repo := &historyRepository{}
+	repo.addMatches(count)
+	return repo
+}
+
+// addMatches prepends count newer matches
+func (h *historyRepository) addMatches(count int) {
+	start := len(h.matches)
+	var newer []entity.PlayerMatchSummary
+	for i := start + count - 1; i >= start; i-- {
+		newer = append(newer, entity.PlayerMatchSummary{MatchID: fmt.Sprintf("m%d", i), FinishedAt: int64(i + 1)})
+	}
+	h.matches = append(newer, h.matches...)
+}
+
+func (h *historyRepository) GetPlayerByNickname(ctx context.Context, nickname string) (*entity.PlayerProfile, error) {
+	return &entity.PlayerProfile{ID: "p1", Nickname: nickname}, nil
+}
+
+func (h *historyRepository) GetPlayerByID(ctx context.Context, playerID string) (*entity.PlayerProfile, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (h *historyRepository) GetPlayerStats(ctx context.Context, playerID, gameID string) (*entity.PlayerStats, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (h *historyRepository) GetPlayerRecentMatches(ctx context.Context, playerID string, gameID string, limit int) ([]entity.PlayerMatchSummary, error) {
+	h.limits = append(h.limits, limit)
+	if limit > len(h.matches) {
+		limit = len(h.matches)
+	}
+	return h.matches[:limit], nil
+}
+
+func (h *historyRepository) GetMatchStats(ctx context.Context, matchID string) (*entity.MatchStats, error) {
+	if matchID == "m0" {
+		return nil, errors.New("match not found")
+	}
+	return &entity.MatchStats{MatchID: matchID}, nil
+}
+
+func newSyncApp(t *testing.T, repo *historyRepository) *App {
+	t.Helper()
+	history, err := store.Open(filepath.Join(t.TempDir(), "history.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { history.Close() })
+	return &App{
+		config: &config.Config{},
+		repo:   store.NewRecordingRepository(repo, history, nil),
+		store:  history,
+	}
+}
+
+func TestSyncBackfillsThenFetchesOnlyNewMatches(t *testing.T) {
+	repo := newHistoryRepository(250)
+	a := newSyncApp(t, repo)
+	var out bytes.Buffer
+
+	if err := a.Sync(context.Background(), []string{"alice"}, &out); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if !strings.Contains(out.String(), "250 new matches, 250 stored") {
+		t.Errorf("Unexpected output:\n%s", out.String())
+	}
+
+	// A few new matches fit in the first page
+	repo.addMatches(3)
+	repo.limits = nil
+	out.Reset()
+	if err := a.Sync(context.Background(), []string{"alice"}, &out); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if len(repo.limits) != 1 || repo.limits[0] != syncPageSize {
+		t.Errorf("Expected a single page request, got limits %v", repo.limits)
+	}
+	if !strings.Contains(out.String(), "3 new matches, 253 stored") {
+		t.Errorf("Unexpected output:\n%s", out.String())
+	}
+
+	// More new matches than a page falls back to the full history
+	repo.addMatches(syncPageSize + 5)
+	repo.limits = nil
+	out.Reset()
+	if err := a.Sync(context.Background(), []string{"alice"}, &out); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if len(repo.limits) != 2 || repo.limits[1] != defaultSyncLimit {
+		t.Errorf("Expected a page and a full request, got limits %v", repo.limits)
+	}
+	if !strings.Contains(out.String(), "105 new matches, 358 stored") {
+		t.Errorf("Unexpected output:\n%s", out.String())
+	}
+}
+
+func TestSyncStoresScoreboards(t *testing.T) {
+	repo := newHistoryRepository(3)
+	a := newSyncApp(t, repo)
+	var out bytes.Buffer
+
+	if err := a.Sync(context.Background(), []string{"alice", "--stats"}, &out); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if !strings.Contains(out.String(), "2 scoreboards stored, 1 failed") {
+		t.Errorf("Unexpected output:\n%s", out.String())
+	}
+	missing, err := a.store.MatchesWithoutStats(context.Background(), "p1", "cs2")
+	if err != nil || len(missing) != 1 || missing[0] != "m0" {
+		t.Errorf("Expected only m0 to be missing, got %v (%v)", missing, err)
+	}
+}
+
+func TestSyncRequiresHistory(t *testing.T) {
+	a := &App{config: &config.Config{}, repo: newHistoryRepository(1)}
+	if err := a.Sync(context.Background(), []string{"alice"}, &bytes.Buffer{}); err == nil {
+		t.Error("Expected an error without a history database")
+	}
+}
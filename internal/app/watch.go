@@ -0,0 +1,139 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/armitageee/faceit-cli/internal/notify"
+	"github.com/armitageee/faceit-cli/internal/ui"
+	"github.com/armitageee/faceit-cli/internal/watch"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Watch polls a list of players for new matches and ELO changes. Usage:
+//
+//	faceit-cli watch [nick1 nick2 ...] [--interval 60s] [--plain] [--dry-run]
+//
+// Without nicknames the configured watchlist is used. By default the TUI
+// dashboard is opened; --plain prints one line per change instead. Finished
+// matches are also sent to the configured notifiers.
+func (a *App) Watch(ctx context.Context, args []string, w io.Writer) error {
+	flags := flag.NewFlagSet("watch", flag.ContinueOnError)
+	interval := flags.Duration("interval", time.Duration(a.config.WatchInterval)*time.Second, "time between polls")
+	plain := flags.Bool("plain", false, "print changes as plain lines instead of opening the dashboard")
+	dryRun := flags.Bool("dry-run", a.config.NotifyDryRun, "print webhook payloads instead of sending them (logged while the dashboard is open)")
+
+	nicknames, err := parseNicknameList(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(nicknames) == 0 {
+		nicknames = a.config.WatchPlayers
+	}
+	if len(nicknames) == 0 {
+		return fmt.Errorf("usage: faceit-cli watch <nickname>... (or set watch_players in the config)")
+	}
+	if *interval > 0 && *interval < watch.MinInterval {
+		fmt.Fprintf(os.Stderr, "Interval raised to the minimum of %s\n", watch.MinInterval)
+	}
+
+	a.config.NotifyDryRun = *dryRun
+	// The dashboard and its terminal notifications share a locked output;
+	// dry runs are logged rather than written over the dashboard
+	terminal, dryRunOutput := w, w
+	var output *notify.Output
+	if !*plain {
+		output = notify.NewOutput(os.Stdout)
+		terminal, dryRunOutput = output, dryRunLog{a.logger}
+	}
+	notifier, err := notify.FromConfig(a.config, terminal, dryRunOutput)
+	if err != nil {
+		return fmt.Errorf("configure notifications: %w", err)
+	}
+
+	stopMetrics := a.serveMetrics()
+	defer stopMetrics()
+
+	if !*plain {
+		// The dashboard reads the interval from the configuration
+		a.config.WatchInterval = int(interval.Seconds())
+		model := ui.InitialModel(a.repo, a.config, a.logger).
+			WithNotifier(notifier).
+			WithMetrics(a.telemetry.Metrics()).
+			WithWatchlist(nicknames)
+		p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion(), tea.WithOutput(output))
+		if _, err := p.Run(); err != nil {
+			return fmt.Errorf("failed to run watch dashboard: %w", err)
+		}
+		return nil
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	watcher := watch.New(a.repo, nicknames, *interval).WithRecorder(a.recordElo)
+	fmt.Fprintf(w, "Watching %d players every %s (Ctrl+C to stop)\n", len(nicknames), watcher.Interval())
+
+	baseline := true
+	err = watcher.Run(ctx, func(events []watch.Event) {
+		if baseline {
+			baseline = false
+			for _, status := range watcher.Statuses() {
+				if status.Err != nil {
+					fmt.Fprintf(w, "%s  %s: %v\n", time.Now().Format("15:04:05"), status.Nickname, status.Err)
+					continue
+				}
+				fmt.Fprintf(w, "%s  %s: ELO %d\n", time.Now().Format("15:04:05"), status.Nickname, status.Elo)
+			}
+		}
+		for _, event := range events {
+			fmt.Fprintf(w, "%s  %s\n", time.Now().Format("15:04:05"), event)
+		}
+		sendNotifications(ctx, notifier, events)
+	})
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
+
+// sendNotifications notifies about the finished matches among the events.
+// Delivery errors are reported on stderr without stopping the watch.
+func sendNotifications(ctx context.Context, notifier notify.Notifier, events []watch.Event) {
+	if notifier == nil {
+		return
+	}
+	for _, event := range events {
+		n, ok := notify.FromEvent(event)
+		if !ok {
+			continue
+		}
+		if err := notifier.Notify(ctx, n); err != nil {
+			fmt.Fprintf(os.Stderr, "Notification failed: %v\n", err)
+		}
+	}
+}
+
+// parseNicknameList parses flags mixed with any number of nicknames
+func parseNicknameList(flags *flag.FlagSet, args []string) ([]string, error) {
+	var nicknames []string
+	for len(args) > 0 {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		args = flags.Args()
+		if len(args) > 0 {
+			nicknames = append(nicknames, args[0])
+			args = args[1:]
+		}
+	}
+	return nicknames, nil
+}
@@ -3,15 +3,18 @@ package cache
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/armitageee/faceit-cli/internal/entity"
+	"github.com/armitageee/faceit-cli/internal/telemetry"
 )
 
 // CacheEntry represents a cached item with expiration
 type CacheEntry struct {
 	Data      interface{}
+	StoredAt  time.Time
 	ExpiresAt time.Time
 }
 
@@ -22,9 +25,10 @@ func (e *CacheEntry) IsExpired() bool {
 
 // Cache provides in-memory caching with TTL support
 type Cache struct {
-	mu    sync.RWMutex
-	items map[string]*CacheEntry
-	ttl   time.Duration
+	mu      sync.RWMutex
+	items   map[string]*CacheEntry
+	ttl     time.Duration
+	onEvict func(kind string, count int) // called after expired entries are removed
 }
 
 // NewCache creates a new cache instance with the specified TTL
@@ -45,9 +49,11 @@ func (c *Cache) Set(key string, value interface{}) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	
+	now := time.Now()
 	c.items[key] = &CacheEntry{
 		Data:      value,
-		ExpiresAt: time.Now().Add(c.ttl),
+		StoredAt:  now,
+		ExpiresAt: now.Add(c.ttl),
 	}
 }
 
@@ -64,6 +70,23 @@ func (c *Cache) Get(key string) (interface{}, bool) {
 	return entry.Data, true
 }
 
+// GetFresh retrieves a value from the cache only if it was stored less
+// than maxAge ago. A zero maxAge behaves like Get.
+func (c *Cache) GetFresh(key string, maxAge time.Duration) (interface{}, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, exists := c.items[key]
+	if !exists || entry.IsExpired() {
+		return nil, false
+	}
+	if maxAge > 0 && time.Since(entry.StoredAt) >= maxAge {
+		return nil, false
+	}
+
+	return entry.Data, true
+}
+
 // Delete removes a value from the cache
 func (c *Cache) Delete(key string) {
 	c.mu.Lock()
@@ -86,16 +109,37 @@ func (c *Cache) cleanup() {
 	defer ticker.Stop()
 	
 	for range ticker.C {
-		c.mu.Lock()
-		for key, entry := range c.items {
-			if entry.IsExpired() {
-				delete(c.items, key)
-			}
+		c.removeExpired()
+	}
+}
+
+// removeExpired deletes expired entries and reports them to the eviction
+// hook, grouped by kind of data
+func (c *Cache) removeExpired() {
+	c.mu.Lock()
+	evicted := make(map[string]int)
+	for key, entry := range c.items {
+		if entry.IsExpired() {
+			delete(c.items, key)
+			evicted[keyKind(key)]++
+		}
+	}
+	onEvict := c.onEvict
+	c.mu.Unlock()
+
+	if onEvict != nil {
+		for kind, count := range evicted {
+			onEvict(kind, count)
 		}
-		c.mu.Unlock()
 	}
 }
 
+// keyKind returns the kind of data stored under a key, e.g. "profile"
+func keyKind(key string) string {
+	kind, _, _ := strings.Cut(key, ":")
+	return kind
+}
+
 // GenerateKey creates a cache key for player matches
 func GeneratePlayerMatchesKey(playerID, gameID string, limit int) string {
 	return fmt.Sprintf("matches:%s:%s:%d", playerID, gameID, limit)
@@ -106,6 +150,13 @@ func GeneratePlayerProfileKey(nickname string) string {
 	return fmt.Sprintf("profile:%s", nickname)
 }
 
+// GeneratePlayerProfileIDKey creates a cache key for a player profile
+// looked up by ID. Nicknames cannot contain ":", so the keys of both
+// lookups do not collide.
+func GeneratePlayerProfileIDKey(playerID string) string {
+	return fmt.Sprintf("profile:id:%s", playerID)
+}
+
 // GenerateKey creates a cache key for player stats
 func GeneratePlayerStatsKey(playerID, gameID string) string {
 	return fmt.Sprintf("stats:%s:%s", playerID, gameID)
@@ -116,15 +167,34 @@ func GenerateMatchStatsKey(matchID string) string {
 	return fmt.Sprintf("match_stats:%s", matchID)
 }
 
+// maxAgeKey is the context key under which WithMaxAge stores its value
+type maxAgeKey struct{}
+
+// WithMaxAge returns a context that makes the cached repository treat
+// entries older than maxAge as missing. Pollers use it to get data that is
+// at most one interval old while still sharing the cache with the rest of
+// the application.
+func WithMaxAge(ctx context.Context, maxAge time.Duration) context.Context {
+	return context.WithValue(ctx, maxAgeKey{}, maxAge)
+}
+
+// maxAgeFromContext returns the maximum entry age requested by the caller
+func maxAgeFromContext(ctx context.Context) time.Duration {
+	maxAge, _ := ctx.Value(maxAgeKey{}).(time.Duration)
+	return maxAge
+}
+
 // CachedFaceitRepository wraps a FaceitRepository with caching
 type CachedFaceitRepository struct {
-	repo  FaceitRepository
-	cache *Cache
+	repo    FaceitRepository
+	cache   *Cache
+	metrics *telemetry.Metrics
 }
 
 // FaceitRepository interface for dependency injection
 type FaceitRepository interface {
 	GetPlayerByNickname(ctx context.Context, nickname string) (*entity.PlayerProfile, error)
+	GetPlayerByID(ctx context.Context, playerID string) (*entity.PlayerProfile, error)
 	GetPlayerStats(ctx context.Context, playerID, gameID string) (*entity.PlayerStats, error)
 	GetPlayerRecentMatches(ctx context.Context, playerID string, gameID string, limit int) ([]entity.PlayerMatchSummary, error)
 	GetMatchStats(ctx context.Context, matchID string) (*entity.MatchStats, error)
@@ -138,12 +208,36 @@ func NewCachedFaceitRepository(repo FaceitRepository, cacheTTL time.Duration) *C
 	}
 }
 
+// WithMetrics records cache hits, misses and evictions. A nil metrics
+// value disables recording.
+func (c *CachedFaceitRepository) WithMetrics(metrics *telemetry.Metrics) *CachedFaceitRepository {
+	c.metrics = metrics
+	c.cache.mu.Lock()
+	c.cache.onEvict = func(kind string, count int) {
+		metrics.RecordCacheEvictions(context.Background(), kind, count)
+	}
+	c.cache.mu.Unlock()
+	return c
+}
+
+// lookup returns a cached value fresh enough for the caller and records
+// the hit or miss
+func (c *CachedFaceitRepository) lookup(ctx context.Context, key string) (interface{}, bool) {
+	cached, found := c.cache.GetFresh(key, maxAgeFromContext(ctx))
+	if found {
+		c.metrics.RecordCacheHit(ctx, keyKind(key))
+	} else {
+		c.metrics.RecordCacheMiss(ctx, keyKind(key))
+	}
+	return cached, found
+}
+
 // GetPlayerByNickname implements FaceitRepository interface with caching
 func (c *CachedFaceitRepository) GetPlayerByNickname(ctx context.Context, nickname string) (*entity.PlayerProfile, error) {
 	key := GeneratePlayerProfileKey(nickname)
 	
 	// Try to get from cache
-	if cached, found := c.cache.Get(key); found {
+	if cached, found := c.lookup(ctx, key); found {
 		if profile, ok := cached.(*entity.PlayerProfile); ok {
 			return profile, nil
 		}
@@ -161,12 +255,35 @@ func (c *CachedFaceitRepository) GetPlayerByNickname(ctx context.Context, nickna
 	return profile, nil
 }
 
+// GetPlayerByID implements FaceitRepository interface with caching
+func (c *CachedFaceitRepository) GetPlayerByID(ctx context.Context, playerID string) (*entity.PlayerProfile, error) {
+	key := GeneratePlayerProfileIDKey(playerID)
+	
+	// Try to get from cache
+	if cached, found := c.lookup(ctx, key); found {
+		if profile, ok := cached.(*entity.PlayerProfile); ok {
+			return profile, nil
+		}
+	}
+	
+	// Get from repository
+	profile, err := c.repo.GetPlayerByID(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Cache the result
+	c.cache.Set(key, profile)
+	
+	return profile, nil
+}
+
 // GetPlayerStats implements FaceitRepository interface with caching
 func (c *CachedFaceitRepository) GetPlayerStats(ctx context.Context, playerID, gameID string) (*entity.PlayerStats, error) {
 	key := GeneratePlayerStatsKey(playerID, gameID)
 	
 	// Try to get from cache
-	if cached, found := c.cache.Get(key); found {
+	if cached, found := c.lookup(ctx, key); found {
 		if stats, ok := cached.(*entity.PlayerStats); ok {
 			return stats, nil
 		}
@@ -189,7 +306,7 @@ func (c *CachedFaceitRepository) GetPlayerRecentMatches(ctx context.Context, pla
 	key := GeneratePlayerMatchesKey(playerID, gameID, limit)
 	
 	// Try to get from cache
-	if cached, found := c.cache.Get(key); found {
+	if cached, found := c.lookup(ctx, key); found {
 		if matches, ok := cached.([]entity.PlayerMatchSummary); ok {
 			return matches, nil
 		}
@@ -228,7 +345,7 @@ func (c *CachedFaceitRepository) GetMatchStats(ctx context.Context, matchID stri
 	key := GenerateMatchStatsKey(matchID)
 	
 	// Try to get from cache
-	if cached, found := c.cache.Get(key); found {
+	if cached, found := c.lookup(ctx, key); found {
 		if stats, ok := cached.(*entity.MatchStats); ok {
 			return stats, nil
 		}
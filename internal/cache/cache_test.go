@@ -154,6 +154,15 @@ func (m *mockRepository) GetPlayerByNickname(ctx context.Context, nickname strin
 	return nil, fmt.Errorf("player not found")
 }
 
+func (m *mockRepository) GetPlayerByID(ctx context.Context, playerID string) (*entity.PlayerProfile, error) {
+	for _, profile := range m.profiles {
+		if profile.ID == playerID {
+			return profile, nil
+		}
+	}
+	return nil, fmt.Errorf("player not found")
+}
+
 func (m *mockRepository) GetPlayerStats(ctx context.Context, playerID, gameID string) (*entity.PlayerStats, error) {
 	key := playerID + ":" + gameID
 	if stats, exists := m.stats[key]; exists {
@@ -207,6 +216,14 @@ func TestCachedRepository(t *testing.T) {
 	if !found {
 		t.Error("Expected profile to be cached")
 	}
+
+	// Profiles looked up by ID are cached under their own key
+	if result, err := cachedRepo.GetPlayerByID(ctx, "test123"); err != nil || result.Nickname != "testplayer" {
+		t.Fatalf("Expected testplayer by ID, got %v (%v)", result, err)
+	}
+	if _, found := cachedRepo.cache.Get(GeneratePlayerProfileIDKey("test123")); !found {
+		t.Error("Expected the profile looked up by ID to be cached")
+	}
 }
 
 func TestCachedMatchStats(t *testing.T) {
@@ -253,3 +270,77 @@ func TestCachedMatchStats(t *testing.T) {
 		t.Error("Expected match stats to be cached")
 	}
 }
+
+func TestCacheGetFresh(t *testing.T) {
+	cache := NewCache(1 * time.Minute)
+	cache.Set("key1", "value1")
+
+	if _, found := cache.GetFresh("key1", 0); !found {
+		t.Error("Expected zero max age to behave like Get")
+	}
+	if _, found := cache.GetFresh("key1", time.Minute); !found {
+		t.Error("Expected a fresh entry to be returned")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if _, found := cache.GetFresh("key1", 10*time.Millisecond); found {
+		t.Error("Expected an entry older than max age to be missing")
+	}
+	if _, found := cache.Get("key1"); !found {
+		t.Error("Expected the entry to stay cached for other callers")
+	}
+}
+
+func TestCachedRepositoryWithMaxAge(t *testing.T) {
+	mockRepo := newMockRepository()
+	cachedRepo := NewCachedFaceitRepository(mockRepo, 1*time.Minute)
+	mockRepo.profiles["testplayer"] = &entity.PlayerProfile{ID: "test123", Nickname: "testplayer"}
+
+	ctx := context.Background()
+	if _, err := cachedRepo.GetPlayerByNickname(ctx, "testplayer"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The repository now reports a different profile
+	mockRepo.profiles["testplayer"] = &entity.PlayerProfile{ID: "test123", Nickname: "testplayer", Country: "DE"}
+
+	result, err := cachedRepo.GetPlayerByNickname(ctx, "testplayer")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Country != "" {
+		t.Error("Expected the cached profile without max age")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	result, err = cachedRepo.GetPlayerByNickname(WithMaxAge(ctx, 10*time.Millisecond), "testplayer")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Country != "DE" {
+		t.Error("Expected a refreshed profile once the entry is older than max age")
+	}
+}
+
+func TestCacheRemoveExpiredReportsEvictions(t *testing.T) {
+	cache := NewCache(10 * time.Millisecond)
+	evicted := make(map[string]int)
+	cache.onEvict = func(kind string, count int) {
+		evicted[kind] += count
+	}
+
+	cache.Set(GeneratePlayerProfileKey("alice"), "a")
+	cache.Set(GeneratePlayerProfileKey("bob"), "b")
+	cache.Set(GeneratePlayerMatchesKey("p1", "cs2", 20), "m")
+	time.Sleep(20 * time.Millisecond)
+	cache.Set(GeneratePlayerStatsKey("p1", "cs2"), "s")
+
+	cache.removeExpired()
+
+	if evicted["profile"] != 2 || evicted["matches"] != 1 || evicted["stats"] != 0 {
+		t.Errorf("Unexpected evictions %v", evicted)
+	}
+	if _, found := cache.Get(GeneratePlayerStatsKey("p1", "cs2")); !found {
+		t.Error("Expected the fresh entry to be kept")
+	}
+}
@@ -19,12 +19,32 @@ type Config struct {
 	LogToStdout       bool
 	MatchesPerPage    int
 	MaxMatchesToLoad  int
+	MatchColumns      []string // columns of the matches table, empty for all
+	CompactMatches    bool     // one line per match without spacing
+	KeyBindings       map[string][]string // action name to keys, only set from config.yml
+	Theme             string // built-in or custom theme name, "auto" to follow the terminal background
+	Themes            map[string]map[string]string // custom themes: color name to color, only set from config.yml
 	CacheEnabled      bool
 	CacheTTL          int // Cache TTL in minutes
+	RateLimit         int // Maximum FACEIT API requests per second, negative for no limit
 	ComparisonMatches int // Number of matches to use for comparison
+	// Match history database
+	HistoryEnabled    bool
+	HistoryPath       string // SQLite database file
+	// Watchlist configuration
+	WatchPlayers       []string
+	WatchInterval      int // Polling interval in seconds
+	// Notification configuration
+	Notifiers          []string // bell, osc9, command, webhook
+	NotifyCommand      string
+	NotifyWebhooks     []string // URLs, optionally prefixed with a format as in "discord=https://..."
+	NotifyDryRun       bool     // print webhook payloads instead of sending them
 	// Telemetry configuration
 	TelemetryEnabled   bool
 	OTLPEndpoint       string
+	MetricsEnabled     bool
+	MetricsAddr        string // Prometheus /metrics listen address
+	OTLPMetricsEndpoint string
 	ServiceName        string
 	ServiceVersion     string
 	Environment        string
@@ -85,6 +105,14 @@ func loadFromEnv() (*Config, error) {
 		}
 	}
 
+	// Parse matches table settings
+	matchColumns := splitList(os.Getenv("MATCH_COLUMNS"))
+	compactMatches := os.Getenv("COMPACT_MATCHES") == "true"
+	theme := os.Getenv("THEME")
+	if theme == "" {
+		theme = "auto"
+	}
+
 	// Parse production mode settings
 	productionMode := os.Getenv("PRODUCTION_MODE") == "true"
 	logToStdout := os.Getenv("LOG_TO_STDOUT") != "false" // Default to true unless explicitly disabled
@@ -98,6 +126,21 @@ func loadFromEnv() (*Config, error) {
 		}
 	}
 
+	// Parse rate limit settings
+	rateLimit := 10 // Default 10 calls per second
+	if rateLimitStr := os.Getenv("RATE_LIMIT"); rateLimitStr != "" {
+		if parsed, err := strconv.Atoi(rateLimitStr); err == nil && parsed != 0 {
+			rateLimit = parsed
+		}
+	}
+
+	// Parse history database settings
+	historyEnabled := os.Getenv("HISTORY_ENABLED") == "true"
+	historyPath := os.Getenv("HISTORY_PATH")
+	if historyPath == "" {
+		historyPath = DefaultHistoryPath()
+	}
+
 	// Parse comparison settings
 	comparisonMatches := 20 // Default 20 matches for comparison
 	if comparisonStr := os.Getenv("COMPARISON_MATCHES"); comparisonStr != "" {
@@ -106,6 +149,24 @@ func loadFromEnv() (*Config, error) {
 		}
 	}
 
+	// Parse watchlist settings
+	watchPlayers := splitList(os.Getenv("WATCH_PLAYERS"))
+	watchInterval := 60 // Default 60 seconds
+	if watchIntervalStr := os.Getenv("WATCH_INTERVAL"); watchIntervalStr != "" {
+		if parsed, err := strconv.Atoi(watchIntervalStr); err == nil && parsed > 0 {
+			watchInterval = parsed
+		}
+	}
+
+	// Parse notification settings
+	notifiers := splitList(os.Getenv("NOTIFY"))
+	notifyCommand := os.Getenv("NOTIFY_COMMAND")
+	if notifyCommand == "" {
+		notifyCommand = "notify-send"
+	}
+	notifyWebhooks := splitList(os.Getenv("NOTIFY_WEBHOOKS"))
+	notifyDryRun := os.Getenv("NOTIFY_DRY_RUN") == "true"
+
 	// Parse telemetry settings
 	telemetryEnabled := os.Getenv("TELEMETRY_ENABLED") == "true"
 	otlpEndpoint := os.Getenv("OTLP_ENDPOINT")
@@ -113,6 +174,12 @@ func loadFromEnv() (*Config, error) {
 		otlpEndpoint = "localhost:4317"
 	}
 	// Zipkin endpoint is handled by OTLP Collector
+	metricsEnabled := os.Getenv("METRICS_ENABLED") == "true"
+	metricsAddr := os.Getenv("METRICS_ADDR")
+	if metricsAddr == "" {
+		metricsAddr = ":9464"
+	}
+	otlpMetricsEndpoint := os.Getenv("OTLP_METRICS_ENDPOINT")
 	serviceName := os.Getenv("SERVICE_NAME")
 	if serviceName == "" {
 		serviceName = "faceit-cli"
@@ -137,11 +204,26 @@ func loadFromEnv() (*Config, error) {
 		LogToStdout:       logToStdout,
 		MatchesPerPage:    matchesPerPage,
 		MaxMatchesToLoad:  maxMatchesToLoad,
+		MatchColumns:      matchColumns,
+		CompactMatches:    compactMatches,
+		Theme:             theme,
 		CacheEnabled:      cacheEnabled,
 		CacheTTL:          cacheTTL,
+		RateLimit:         rateLimit,
 		ComparisonMatches: comparisonMatches,
+		HistoryEnabled:    historyEnabled,
+		HistoryPath:       historyPath,
+		WatchPlayers:      watchPlayers,
+		WatchInterval:     watchInterval,
+		Notifiers:         notifiers,
+		NotifyCommand:     notifyCommand,
+		NotifyWebhooks:    notifyWebhooks,
+		NotifyDryRun:      notifyDryRun,
 		TelemetryEnabled:  telemetryEnabled,
 		OTLPEndpoint:      otlpEndpoint,
+		MetricsEnabled:    metricsEnabled,
+		MetricsAddr:       metricsAddr,
+		OTLPMetricsEndpoint: otlpMetricsEndpoint,
 		ServiceName:       serviceName,
 		ServiceVersion:    serviceVersion,
 		Environment:       environment,
@@ -197,6 +279,37 @@ func convertYAMLToConfig(yamlConfig *YAMLConfig) (*Config, error) {
 		kafkaBrokers = strings.Split(yamlConfig.KafkaBrokers, ",")
 	}
 
+	// Parse watchlist with env override
+	watchPlayers := splitList(yamlConfig.WatchPlayers)
+	if envPlayers := os.Getenv("WATCH_PLAYERS"); envPlayers != "" {
+		watchPlayers = splitList(envPlayers)
+	}
+
+	// Parse matches table columns with env override
+	matchColumns := splitList(yamlConfig.MatchColumns)
+	if envColumns := os.Getenv("MATCH_COLUMNS"); envColumns != "" {
+		matchColumns = splitList(envColumns)
+	}
+
+	// Parse key bindings, which have no environment override
+	var keyBindings map[string][]string
+	if len(yamlConfig.Keys) > 0 {
+		keyBindings = make(map[string][]string, len(yamlConfig.Keys))
+		for action, keys := range yamlConfig.Keys {
+			keyBindings[action] = splitList(keys)
+		}
+	}
+
+	// Parse notifiers with env override
+	notifiers := splitList(yamlConfig.Notify)
+	if envNotifiers := os.Getenv("NOTIFY"); envNotifiers != "" {
+		notifiers = splitList(envNotifiers)
+	}
+	notifyWebhooks := splitList(yamlConfig.NotifyWebhooks)
+	if envWebhooks := os.Getenv("NOTIFY_WEBHOOKS"); envWebhooks != "" {
+		notifyWebhooks = splitList(envWebhooks)
+	}
+
 	return &Config{
 		FaceitAPIKey:      apiKey,
 		DefaultPlayer:     getStringValue("FACEIT_DEFAULT_PLAYER", yamlConfig.DefaultPlayer, ""),
@@ -208,13 +321,41 @@ func convertYAMLToConfig(yamlConfig *YAMLConfig) (*Config, error) {
 		LogToStdout:       getBoolValue("LOG_TO_STDOUT", yamlConfig.LogToStdout, true),
 		MatchesPerPage:    getIntValue("MATCHES_PER_PAGE", yamlConfig.MatchesPerPage, 10),
 		MaxMatchesToLoad:  getIntValue("MAX_MATCHES_TO_LOAD", yamlConfig.MaxMatchesToLoad, 100),
+		MatchColumns:      matchColumns,
+		CompactMatches:    getBoolValue("COMPACT_MATCHES", yamlConfig.CompactMatches, false),
+		KeyBindings:       keyBindings,
+		Theme:             getStringValue("THEME", yamlConfig.Theme, "auto"),
+		Themes:            yamlConfig.Themes,
 		CacheEnabled:      getBoolValue("CACHE_ENABLED", yamlConfig.CacheEnabled, false),
 		CacheTTL:          getIntValue("CACHE_TTL", yamlConfig.CacheTTL, 30),
+		RateLimit:         getIntValue("RATE_LIMIT", yamlConfig.RateLimit, 10),
 		ComparisonMatches: getIntValue("COMPARISON_MATCHES", yamlConfig.ComparisonMatches, 20),
+		HistoryEnabled:    getBoolValue("HISTORY_ENABLED", yamlConfig.HistoryEnabled, false),
+		HistoryPath:       getStringValue("HISTORY_PATH", yamlConfig.HistoryPath, DefaultHistoryPath()),
+		WatchPlayers:      watchPlayers,
+		WatchInterval:     getIntValue("WATCH_INTERVAL", yamlConfig.WatchInterval, 60),
+		Notifiers:         notifiers,
+		NotifyCommand:     getStringValue("NOTIFY_COMMAND", yamlConfig.NotifyCommand, "notify-send"),
+		NotifyWebhooks:    notifyWebhooks,
+		NotifyDryRun:      getBoolValue("NOTIFY_DRY_RUN", yamlConfig.NotifyDryRun, false),
 		TelemetryEnabled:  getBoolValue("TELEMETRY_ENABLED", yamlConfig.TelemetryEnabled, false),
 		OTLPEndpoint:      getStringValue("OTLP_ENDPOINT", yamlConfig.OTLPEndpoint, "localhost:4317"),
+		MetricsEnabled:    getBoolValue("METRICS_ENABLED", yamlConfig.MetricsEnabled, false),
+		MetricsAddr:       getStringValue("METRICS_ADDR", yamlConfig.MetricsAddr, ":9464"),
+		OTLPMetricsEndpoint: getStringValue("OTLP_METRICS_ENDPOINT", yamlConfig.OTLPMetricsEndpoint, ""),
 		ServiceName:       getStringValue("SERVICE_NAME", yamlConfig.ServiceName, "faceit-cli"),
 		ServiceVersion:    getStringValue("SERVICE_VERSION", yamlConfig.ServiceVersion, "1.0.0"),
 		Environment:       getStringValue("ENVIRONMENT", yamlConfig.Environment, "development"),
 	}, nil
 }
+
+// splitList splits a comma-separated list, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
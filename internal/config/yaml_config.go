@@ -20,12 +20,34 @@ type YAMLConfig struct {
 	LogToStdout      bool   `yaml:"log_to_stdout"`
 	MatchesPerPage   int    `yaml:"matches_per_page"`
 	MaxMatchesToLoad int    `yaml:"max_matches_to_load"`
+	MatchColumns     string `yaml:"match_columns"`
+	CompactMatches   bool   `yaml:"compact_matches"`
+	// Key bindings: action name to comma-separated keys
+	Keys             map[string]string `yaml:"keys,omitempty"`
+	// Theme name and custom themes: theme name to color name to color
+	Theme            string `yaml:"theme"`
+	Themes           map[string]map[string]string `yaml:"themes,omitempty"`
 	CacheEnabled     bool   `yaml:"cache_enabled"`
 	CacheTTL         int    `yaml:"cache_ttl"`
+	RateLimit        int    `yaml:"rate_limit"`
 	ComparisonMatches int   `yaml:"comparison_matches"`
+	// Match history database
+	HistoryEnabled   bool   `yaml:"history_enabled"`
+	HistoryPath      string `yaml:"history_path"`
+	// Watchlist configuration
+	WatchPlayers     string `yaml:"watch_players"`
+	WatchInterval    int    `yaml:"watch_interval"`
+	// Notification configuration
+	Notify           string `yaml:"notify"`
+	NotifyCommand    string `yaml:"notify_command"`
+	NotifyWebhooks   string `yaml:"notify_webhooks"`
+	NotifyDryRun     bool   `yaml:"notify_dry_run"`
 	// Telemetry configuration
 	TelemetryEnabled bool   `yaml:"telemetry_enabled"`
 	OTLPEndpoint     string `yaml:"otlp_endpoint"`
+	MetricsEnabled   bool   `yaml:"metrics_enabled"`
+	MetricsAddr      string `yaml:"metrics_addr"`
+	OTLPMetricsEndpoint string `yaml:"otlp_metrics_endpoint"`
 	ServiceName      string `yaml:"service_name"`
 	ServiceVersion   string `yaml:"service_version"`
 	Environment      string `yaml:"environment"`
@@ -46,6 +68,17 @@ func GetConfigPath() (string, error) {
 	return configFile, nil
 }
 
+// DefaultHistoryPath returns the default location of the match history
+// database, next to the config file. It is empty when the home directory
+// is unknown.
+func DefaultHistoryPath() string {
+	configPath, err := GetConfigPath()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(configPath), "history.db")
+}
+
 // LoadYAMLConfig loads configuration from YAML file
 func LoadYAMLConfig() (*YAMLConfig, error) {
 	configPath, err := GetConfigPath()
@@ -98,11 +131,26 @@ func CreateDefaultConfig() error {
 		LogToStdout:      false,
 		MatchesPerPage:   10,
 		MaxMatchesToLoad: 100,
+		MatchColumns:     "result,map,score,kda,kd,hs,adr,date,elo",
+		CompactMatches:   false,
+		Theme:            "auto",
 		CacheEnabled:     true,
 		CacheTTL:         30,
+		RateLimit:        10,
 		ComparisonMatches: 20,
+		HistoryEnabled:   true,
+		HistoryPath:      "",
+		WatchPlayers:     "",
+		WatchInterval:    60,
+		Notify:           "",
+		NotifyCommand:    "notify-send",
+		NotifyWebhooks:   "",
+		NotifyDryRun:     false,
 		TelemetryEnabled: false,
 		OTLPEndpoint:     "localhost:4317",
+		MetricsEnabled:   false,
+		MetricsAddr:      ":9464",
+		OTLPMetricsEndpoint: "",
 		ServiceName:      "faceit-cli",
 		ServiceVersion:   "1.0.0",
 		Environment:      "development",
@@ -11,6 +11,9 @@ type PlayerMatchSummary struct {
 	// this field to describe the map played (for example
 	// "de_inferno").
 	Map string
+	// StartedAt holds the UNIX timestamp at which the match began.
+	// A value of zero means the timestamp was unavailable.
+	StartedAt int64
 	// FinishedAt holds the UNIX timestamp at which the match
 	// concluded.  A value of zero means the timestamp was
 	// unavailable.
@@ -37,6 +40,29 @@ type PlayerMatchSummary struct {
 	// Result is "Win" when the player's team won the match and
 	// "Loss" otherwise.
 	Result string
+	// TeamID identifies the faction the player was on (for example
+	// "faction1").  Two summaries of the same match with equal TeamID
+	// values belong to teammates.  An empty value means the team could
+	// not be determined.
+	TeamID string
+	// EloChange is the change in the player's ELO caused by the match.
+	// The FACEIT Data API does not report it, so it is only known when
+	// the application observed the player's ELO before and after the
+	// match.  A value of zero means the change is unknown.
+	EloChange int
+	// Teammates lists the other players on the player's team.
+	Teammates []MatchParticipant
+	// Opponents lists the players on the opposing team.
+	Opponents []MatchParticipant
+}
+
+// MatchParticipant identifies a player who took part in a match.
+type MatchParticipant struct {
+	// PlayerID is the FACEIT player ID.
+	PlayerID string
+	// Nickname is the player's FACEIT nickname at the time of the
+	// match.
+	Nickname string
 }
 
 // MatchStats represents detailed statistics for a match with all players
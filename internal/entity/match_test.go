@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -85,7 +86,7 @@ func TestPlayerMatchSummaryStruct(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if tt.match != tt.expected {
+			if !reflect.DeepEqual(tt.match, tt.expected) {
 				t.Errorf("PlayerMatchSummary = %+v, want %+v", tt.match, tt.expected)
 			}
 		})
@@ -0,0 +1,207 @@
+// Package exporter publishes per-player FACEIT statistics as Prometheus
+// gauges so they can be scraped and graphed over time.
+package exporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/armitageee/faceit-cli/internal/analytics"
+	"github.com/armitageee/faceit-cli/internal/cache"
+	"github.com/armitageee/faceit-cli/internal/repository"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// DefaultInterval is the polling interval used when none is configured
+const DefaultInterval = 5 * time.Minute
+
+// MinInterval is the shortest allowed polling interval at DefaultMatches.
+// Polling more matches raises it; see MinIntervalFor.
+const MinInterval = 30 * time.Second
+
+// DefaultMatches is the number of recent matches the win rate and streak
+// are computed from
+const DefaultMatches = 20
+
+// gameID is the game whose statistics are exported
+const gameID = "cs2"
+
+// historyPageSize is the number of matches one FACEIT history request
+// returns at most
+const historyPageSize = 100
+
+// pollRequests returns the API requests one poll of a player costs: a
+// nickname search and profile fetch, the lifetime stats, one history
+// request per page and the stats of every match. That is 24 requests at
+// DefaultMatches.
+func pollRequests(matches int) int {
+	return 3 + (matches+historyPageSize-1)/historyPageSize + matches
+}
+
+// MinIntervalFor returns the shortest allowed polling interval when the
+// given number of recent matches is loaded: MinInterval scaled by the
+// requests a poll costs compared with DefaultMatches, and never less
+// than MinInterval
+func MinIntervalFor(matches int) time.Duration {
+	if matches <= 0 {
+		matches = DefaultMatches
+	}
+	scaled := MinInterval * time.Duration(pollRequests(matches)) / time.Duration(pollRequests(DefaultMatches))
+	return max(MinInterval, scaled)
+}
+
+// Exporter polls a list of players and keeps their statistics in gauges
+// labelled by player. It uses its own registry, separate from the
+// application metrics.
+type Exporter struct {
+	repo      repository.FaceitRepository
+	nicknames []string
+	interval  time.Duration
+	matches   int
+	now       func() time.Time
+
+	registry      *prometheus.Registry
+	up            *prometheus.GaugeVec
+	elo           *prometheus.GaugeVec
+	skillLevel    *prometheus.GaugeVec
+	lifetimeKD    *prometheus.GaugeVec
+	recentWinRate *prometheus.GaugeVec
+	recentKD      *prometheus.GaugeVec
+	currentStreak *prometheus.GaugeVec
+	lastMatch     *prometheus.GaugeVec
+	lastPoll      prometheus.Gauge
+}
+
+// New creates an exporter for the given nicknames. Intervals below
+// MinIntervalFor(matches) are raised to it. The repository should be the
+// cached one: each poll only asks for data that is at most one interval
+// old.
+func New(repo repository.FaceitRepository, nicknames []string, interval time.Duration, matches int) *Exporter {
+	if matches <= 0 {
+		matches = DefaultMatches
+	}
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	interval = max(interval, MinIntervalFor(matches))
+
+	playerGauge := func(name, help string) *prometheus.GaugeVec {
+		return prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "faceit",
+			Subsystem: "player",
+			Name:      name,
+			Help:      help,
+		}, []string{"player"})
+	}
+
+	e := &Exporter{
+		repo:          repo,
+		nicknames:     nicknames,
+		interval:      interval,
+		matches:       matches,
+		now:           time.Now,
+		registry:      prometheus.NewRegistry(),
+		up:            playerGauge("up", "Whether the last poll of the player succeeded (1) or failed (0)."),
+		elo:           playerGauge("elo", "Current FACEIT ELO."),
+		skillLevel:    playerGauge("skill_level", "Current FACEIT skill level (1-10)."),
+		lifetimeKD:    playerGauge("lifetime_kd_ratio", "Lifetime average K/D ratio."),
+		recentWinRate: playerGauge("recent_win_ratio", "Share of recent matches won (0-1)."),
+		recentKD:      playerGauge("recent_kd_ratio", "Overall K/D ratio over recent matches."),
+		currentStreak: playerGauge("current_streak", "Current streak over recent matches; negative for a losing streak."),
+		lastMatch:     playerGauge("last_match_timestamp_seconds", "UNIX time the most recent match finished."),
+		lastPoll: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "faceit",
+			Subsystem: "exporter",
+			Name:      "last_poll_timestamp_seconds",
+			Help:      "UNIX time of the last completed poll.",
+		}),
+	}
+	e.registry.MustRegister(e.up, e.elo, e.skillLevel, e.lifetimeKD, e.recentWinRate,
+		e.recentKD, e.currentStreak, e.lastMatch, e.lastPoll)
+	return e
+}
+
+// Interval returns the polling interval
+func (e *Exporter) Interval() time.Duration {
+	return e.interval
+}
+
+// Handler returns the /metrics handler serving the exporter's registry
+func (e *Exporter) Handler() http.Handler {
+	return promhttp.HandlerFor(e.registry, promhttp.HandlerOpts{})
+}
+
+// Poll refreshes every player once. A player whose poll fails keeps its
+// previous values and has its up gauge set to 0; the errors are joined.
+func (e *Exporter) Poll(ctx context.Context) error {
+	ctx = cache.WithMaxAge(ctx, e.interval)
+
+	var errs []error
+	for _, nickname := range e.nicknames {
+		if err := e.pollPlayer(ctx, nickname); err != nil {
+			e.up.WithLabelValues(nickname).Set(0)
+			errs = append(errs, fmt.Errorf("%s: %w", nickname, err))
+			continue
+		}
+		e.up.WithLabelValues(nickname).Set(1)
+	}
+	e.lastPoll.Set(float64(e.now().Unix()))
+	return errors.Join(errs...)
+}
+
+// pollPlayer loads one player's profile, lifetime stats and recent
+// matches and updates the gauges. Nothing is updated unless all three
+// requests succeed.
+func (e *Exporter) pollPlayer(ctx context.Context, nickname string) error {
+	profile, err := e.repo.GetPlayerByNickname(ctx, nickname)
+	if err != nil {
+		return fmt.Errorf("load profile: %w", err)
+	}
+	stats, err := e.repo.GetPlayerStats(ctx, profile.ID, gameID)
+	if err != nil {
+		return fmt.Errorf("load stats: %w", err)
+	}
+	matches, err := e.repo.GetPlayerRecentMatches(ctx, profile.ID, gameID, e.matches)
+	if err != nil {
+		return fmt.Errorf("load matches: %w", err)
+	}
+
+	game := profile.Games[gameID]
+	lifetimeKD, _, _ := analytics.ExtractLifetimeStats(stats)
+	summary := analytics.Summarize(matches)
+	streak, _, _, _ := analytics.CalculateStreaks(matches)
+
+	e.elo.WithLabelValues(nickname).Set(float64(game.Elo))
+	e.skillLevel.WithLabelValues(nickname).Set(float64(game.SkillLevel))
+	e.lifetimeKD.WithLabelValues(nickname).Set(lifetimeKD)
+	e.recentWinRate.WithLabelValues(nickname).Set(summary.WinRate / 100)
+	e.recentKD.WithLabelValues(nickname).Set(summary.KD)
+	e.currentStreak.WithLabelValues(nickname).Set(float64(streak))
+	if len(matches) > 0 && matches[0].FinishedAt > 0 {
+		e.lastMatch.WithLabelValues(nickname).Set(float64(matches[0].FinishedAt))
+	}
+	return nil
+}
+
+// Run polls immediately and then once per interval until the context is
+// cancelled. Poll errors are passed to report and do not stop the loop.
+func (e *Exporter) Run(ctx context.Context, report func(error)) error {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := e.Poll(ctx); err != nil && report != nil && ctx.Err() == nil {
+			report(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
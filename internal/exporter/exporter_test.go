@@ -0,0 +1,151 @@
+package exporter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/armitageee/faceit-cli/internal/entity"
+)
+
+// fakeRepository serves a fixed profile, stats and match history per
+// player; players listed in fail return errors
+type fakeRepository struct {
+	elo     map[string]int
+	matches map[string][]entity.PlayerMatchSummary
+	fail    map[string]bool
+}
+
+func (f *fakeRepository) GetPlayerByNickname(ctx context.Context, nickname string) (*entity.PlayerProfile, error) {
+	if f.fail[nickname] {
+		return nil, errors.New("player not found")
+	}
+	return &entity.PlayerProfile{
+		ID:       "id-" + nickname,
+		Nickname: nickname,
+		Games:    map[string]entity.GameDetail{"cs2": {Elo: f.elo[nickname], SkillLevel: 9}},
+	}, nil
+}
+
+func (f *fakeRepository) GetPlayerByID(ctx context.Context, playerID string) (*entity.PlayerProfile, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeRepository) GetPlayerStats(ctx context.Context, playerID, gameID string) (*entity.PlayerStats, error) {
+	return &entity.PlayerStats{
+		PlayerID: playerID,
+		GameID:   gameID,
+		Lifetime: map[string]interface{}{"Average K/D Ratio": "1.25"},
+	}, nil
+}
+
+func (f *fakeRepository) GetPlayerRecentMatches(ctx context.Context, playerID string, gameID string, limit int) ([]entity.PlayerMatchSummary, error) {
+	matches := f.matches[strings.TrimPrefix(playerID, "id-")]
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches, nil
+}
+
+func (f *fakeRepository) GetMatchStats(ctx context.Context, matchID string) (*entity.MatchStats, error) {
+	return nil, errors.New("not implemented")
+}
+
+func scrape(t *testing.T, e *Exporter) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	e.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := io.ReadAll(rec.Body)
+	if err != nil {
+		t.Fatalf("read metrics: %v", err)
+	}
+	return string(body)
+}
+
+func TestNewClampsInterval(t *testing.T) {
+	repo := &fakeRepository{}
+	if got := New(repo, nil, 0, 0).Interval(); got != DefaultInterval {
+		t.Errorf("Expected default interval %s, got %s", DefaultInterval, got)
+	}
+	if got := New(repo, nil, time.Second, 0).Interval(); got != MinInterval {
+		t.Errorf("Expected minimum interval %s, got %s", MinInterval, got)
+	}
+
+	// The minimum grows with the requests a poll of more matches costs
+	if got := pollRequests(DefaultMatches); got != 24 {
+		t.Errorf("Expected a poll to cost 24 requests at %d matches, got %d", DefaultMatches, got)
+	}
+	if got := New(repo, nil, time.Second, 100).Interval(); got != 130*time.Second {
+		t.Errorf("Expected a minimum of 2m10s for 100 matches, got %s", got)
+	}
+	if got := MinIntervalFor(5); got != MinInterval {
+		t.Errorf("Expected fewer matches to keep the minimum %s, got %s", MinInterval, got)
+	}
+}
+
+func TestPollExportsPlayerGauges(t *testing.T) {
+	repo := &fakeRepository{
+		elo: map[string]int{"alice": 2100},
+		matches: map[string][]entity.PlayerMatchSummary{
+			"alice": {
+				{MatchID: "m4", Result: "Loss", Kills: 10, Deaths: 20, FinishedAt: 1700000400},
+				{MatchID: "m3", Result: "Loss", Kills: 15, Deaths: 15},
+				{MatchID: "m2", Result: "Win", Kills: 25, Deaths: 10},
+				{MatchID: "m1", Result: "Win", Kills: 30, Deaths: 15},
+			},
+		},
+		fail: map[string]bool{"ghost": true},
+	}
+	e := New(repo, []string{"alice", "ghost"}, time.Minute, 10)
+
+	err := e.Poll(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "ghost") {
+		t.Errorf("Expected an error for ghost, got %v", err)
+	}
+
+	body := scrape(t, e)
+	for _, want := range []string{
+		`faceit_player_up{player="alice"} 1`,
+		`faceit_player_up{player="ghost"} 0`,
+		`faceit_player_elo{player="alice"} 2100`,
+		`faceit_player_skill_level{player="alice"} 9`,
+		`faceit_player_lifetime_kd_ratio{player="alice"} 1.25`,
+		`faceit_player_recent_win_ratio{player="alice"} 0.5`,
+		`faceit_player_recent_kd_ratio{player="alice"} 1.3333333333333333`,
+		`faceit_player_current_streak{player="alice"} -2`,
+		`faceit_player_last_match_timestamp_seconds{player="alice"} 1.7000004e+09`,
+		`faceit_exporter_last_poll_timestamp_seconds`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected metrics to contain %q\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, `faceit_player_elo{player="ghost"}`) {
+		t.Errorf("Failed player should not export an ELO:\n%s", body)
+	}
+}
+
+func TestPollKeepsValuesWhenPlayerFails(t *testing.T) {
+	repo := &fakeRepository{elo: map[string]int{"alice": 2100}, fail: map[string]bool{}}
+	e := New(repo, []string{"alice"}, time.Minute, 10)
+	if err := e.Poll(context.Background()); err != nil {
+		t.Fatalf("Poll: %v", err)
+	}
+
+	repo.fail["alice"] = true
+	if err := e.Poll(context.Background()); err == nil {
+		t.Fatal("Expected an error once the player fails")
+	}
+
+	body := scrape(t, e)
+	if !strings.Contains(body, `faceit_player_up{player="alice"} 0`) {
+		t.Errorf("Expected alice to be down:\n%s", body)
+	}
+	if !strings.Contains(body, `faceit_player_elo{player="alice"} 2100`) {
+		t.Errorf("Expected the last known ELO to be kept:\n%s", body)
+	}
+}
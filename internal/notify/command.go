@@ -0,0 +1,44 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Command runs an external program such as notify-send or terminal-notifier
+// with the notification title and body appended as its last two arguments
+type Command struct {
+	name string
+	args []string
+	run  func(ctx context.Context, name string, args ...string) error
+}
+
+// NewCommand creates a command notifier from a command line like
+// "notify-send -u critical"
+func NewCommand(commandLine string) (*Command, error) {
+	fields := strings.Fields(commandLine)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("notification command is empty")
+	}
+	return &Command{name: fields[0], args: fields[1:], run: runCommand}, nil
+}
+
+// Notify implements Notifier
+func (c *Command) Notify(ctx context.Context, n Notification) error {
+	args := append(append([]string(nil), c.args...), n.Title(), n.Body())
+	if err := c.run(ctx, c.name, args...); err != nil {
+		return fmt.Errorf("notification command %s: %w", c.name, err)
+	}
+	return nil
+}
+
+// runCommand executes the command, including its output in the error
+func runCommand(ctx context.Context, name string, args ...string) error {
+	output, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+	if err != nil && len(output) > 0 {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return err
+}
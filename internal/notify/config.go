@@ -0,0 +1,57 @@
+package notify
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/armitageee/faceit-cli/internal/config"
+)
+
+// FromConfig builds the notifiers selected in the configuration. Terminal
+// notifications are written to terminal and webhook dry runs to dryRun,
+// which a full-screen UI keeps off its screen. It returns nil when
+// notifications are disabled.
+func FromConfig(cfg *config.Config, terminal, dryRun io.Writer) (Notifier, error) {
+	var notifiers Multi
+	for _, name := range cfg.Notifiers {
+		switch strings.ToLower(name) {
+		case "bell":
+			notifiers = append(notifiers, NewTerminal(terminal, false))
+		case "osc9":
+			notifiers = append(notifiers, NewTerminal(terminal, true))
+		case "command":
+			command, err := NewCommand(cfg.NotifyCommand)
+			if err != nil {
+				return nil, err
+			}
+			notifiers = append(notifiers, command)
+		case "webhook":
+			if len(cfg.NotifyWebhooks) == 0 {
+				return nil, fmt.Errorf("the webhook notifier needs at least one URL in notify_webhooks")
+			}
+			opts := WebhookOptions{Retries: DefaultWebhookRetries, RetryDelay: DefaultWebhookRetryDelay}
+			if cfg.NotifyDryRun {
+				opts.DryRun = dryRun
+			}
+			for _, spec := range cfg.NotifyWebhooks {
+				webhook, err := ParseWebhook(spec, opts)
+				if err != nil {
+					return nil, err
+				}
+				notifiers = append(notifiers, webhook)
+			}
+		default:
+			return nil, fmt.Errorf("unknown notifier %q (use bell, osc9, command or webhook)", name)
+		}
+	}
+
+	switch len(notifiers) {
+	case 0:
+		return nil, nil
+	case 1:
+		return notifiers[0], nil
+	default:
+		return notifiers, nil
+	}
+}
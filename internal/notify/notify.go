@@ -0,0 +1,108 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/armitageee/faceit-cli/internal/entity"
+	"github.com/armitageee/faceit-cli/internal/watch"
+)
+
+// Notification describes a finished match of a watched player
+type Notification struct {
+	Player    string                    `json:"player"`
+	Match     entity.PlayerMatchSummary `json:"match"`
+	Elo       int                       `json:"elo"`
+	EloChange int                       `json:"elo_change"` // zero when unknown
+}
+
+// FromEvent converts a watcher event into a notification. Events without
+// a finished match (plain ELO corrections) do not notify.
+func FromEvent(event watch.Event) (Notification, bool) {
+	if event.Match == nil {
+		return Notification{}, false
+	}
+	return Notification{
+		Player:    event.Nickname,
+		Match:     *event.Match,
+		Elo:       event.Elo,
+		EloChange: event.EloChange,
+	}, true
+}
+
+// Title returns a short headline such as "s1mple won on de_mirage"
+func (n Notification) Title() string {
+	verb := "lost"
+	if n.Match.Result == "Win" {
+		verb = "won"
+	}
+	if n.Match.Map == "" {
+		return fmt.Sprintf("%s %s a match", n.Player, verb)
+	}
+	return fmt.Sprintf("%s %s on %s", n.Player, verb, n.Match.Map)
+}
+
+// Body returns the score, K/D and ELO delta on one line
+func (n Notification) Body() string {
+	var parts []string
+	if n.Match.Score != "" {
+		parts = append(parts, n.Match.Score)
+	}
+	parts = append(parts, fmt.Sprintf("K/D %.2f (%d/%d/%d)", n.Match.KDRatio, n.Match.Kills, n.Match.Deaths, n.Match.Assists))
+	parts = append(parts, "ELO "+n.EloText())
+	return strings.Join(parts, " • ")
+}
+
+// EloText formats the ELO and its change, e.g. "2025 (+25)"
+func (n Notification) EloText() string {
+	if n.EloChange == 0 {
+		return fmt.Sprintf("%d (change unknown)", n.Elo)
+	}
+	return fmt.Sprintf("%d (%+d)", n.Elo, n.EloChange)
+}
+
+// Notifier delivers notifications to the user
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// Multi sends every notification to all of its notifiers
+type Multi []Notifier
+
+// Notify implements Notifier. All notifiers are tried; their errors are
+// joined.
+func (m Multi) Notify(ctx context.Context, n Notification) error {
+	var errs []error
+	for _, notifier := range m {
+		if err := notifier.Notify(ctx, n); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Recorder is a fake notifier that keeps every notification it receives.
+// It is safe for concurrent use.
+type Recorder struct {
+	mu            sync.Mutex
+	notifications []Notification
+	Err           error // returned from every Notify call when set
+}
+
+// Notify implements Notifier
+func (r *Recorder) Notify(ctx context.Context, n Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.notifications = append(r.notifications, n)
+	return r.Err
+}
+
+// Notifications returns the notifications received so far
+func (r *Recorder) Notifications() []Notification {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Notification(nil), r.notifications...)
+}
@@ -0,0 +1,215 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/armitageee/faceit-cli/internal/config"
+	"github.com/armitageee/faceit-cli/internal/entity"
+	"github.com/armitageee/faceit-cli/internal/watch"
+)
+
+func testNotification() Notification {
+	return Notification{
+		Player: "alice",
+		Match: entity.PlayerMatchSummary{
+			MatchID: "m1",
+			Map:     "de_nuke",
+			Result:  "Win",
+			Score:   "13-9",
+			Kills:   21,
+			Deaths:  15,
+			Assists: 4,
+			KDRatio: 1.4,
+		},
+		Elo:       2025,
+		EloChange: 25,
+	}
+}
+
+func TestFromEvent(t *testing.T) {
+	if _, ok := FromEvent(watch.Event{Nickname: "alice", Elo: 2000, EloChange: -10}); ok {
+		t.Error("Expected an ELO-only event not to notify")
+	}
+
+	match := testNotification().Match
+	n, ok := FromEvent(watch.Event{Nickname: "alice", Match: &match, Elo: 2025, EloChange: 25})
+	if !ok {
+		t.Fatal("Expected a finished match to notify")
+	}
+	if !reflect.DeepEqual(n, testNotification()) {
+		t.Errorf("FromEvent() = %+v, want %+v", n, testNotification())
+	}
+}
+
+func TestNotificationText(t *testing.T) {
+	n := testNotification()
+	if got, want := n.Title(), "alice won on de_nuke"; got != want {
+		t.Errorf("Title() = %q, want %q", got, want)
+	}
+	if got, want := n.Body(), "13-9 • K/D 1.40 (21/15/4) • ELO 2025 (+25)"; got != want {
+		t.Errorf("Body() = %q, want %q", got, want)
+	}
+
+	n.Match.Result = "Loss"
+	n.EloChange = 0
+	if got, want := n.Title(), "alice lost on de_nuke"; got != want {
+		t.Errorf("Title() = %q, want %q", got, want)
+	}
+	if !strings.Contains(n.Body(), "ELO 2025 (change unknown)") {
+		t.Errorf("Expected an unknown ELO change in %q", n.Body())
+	}
+}
+
+func TestMulti(t *testing.T) {
+	failing := &Recorder{Err: errors.New("boom")}
+	ok := &Recorder{}
+
+	err := Multi{failing, ok}.Notify(context.Background(), testNotification())
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("Expected the failure to be reported, got %v", err)
+	}
+	if len(failing.Notifications()) != 1 || len(ok.Notifications()) != 1 {
+		t.Error("Expected every notifier to be tried")
+	}
+}
+
+func TestTerminal(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewTerminal(&buf, false).Notify(context.Background(), testNotification()); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "\a" {
+		t.Errorf("Expected a bell, got %q", buf.String())
+	}
+
+	buf.Reset()
+	n := testNotification()
+	n.Player = "ali\x07ce"
+	if err := NewTerminal(&buf, true).Notify(context.Background(), n); err != nil {
+		t.Fatal(err)
+	}
+	want := "\x1b]9;alice won on de_nuke: " + n.Body() + "\a"
+	if buf.String() != want {
+		t.Errorf("OSC 9 sequence = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestOutput(t *testing.T) {
+	file, err := os.CreateTemp(t.TempDir(), "output")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	output := NewOutput(file)
+	if output.Fd() != file.Fd() {
+		t.Error("Expected the output to expose the file descriptor of its terminal")
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = NewTerminal(output, true).Notify(context.Background(), testNotification())
+		}()
+	}
+	wg.Wait()
+
+	written, err := os.ReadFile(file.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(string(written), "\x1b]9;"); got != 10 {
+		t.Errorf("Wrote %d notifications, want 10", got)
+	}
+}
+
+func TestCommand(t *testing.T) {
+	if _, err := NewCommand("  "); err == nil {
+		t.Error("Expected an empty command to be rejected")
+	}
+
+	command, err := NewCommand("notify-send -u critical")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotName string
+	var gotArgs []string
+	command.run = func(ctx context.Context, name string, args ...string) error {
+		gotName, gotArgs = name, args
+		return nil
+	}
+
+	n := testNotification()
+	if err := command.Notify(context.Background(), n); err != nil {
+		t.Fatal(err)
+	}
+	wantArgs := []string{"-u", "critical", n.Title(), n.Body()}
+	if gotName != "notify-send" || !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Errorf("Ran %s %v, want notify-send %v", gotName, gotArgs, wantArgs)
+	}
+
+	command.run = func(ctx context.Context, name string, args ...string) error {
+		return errors.New("not found")
+	}
+	if err := command.Notify(context.Background(), n); err == nil {
+		t.Error("Expected the command failure to be returned")
+	}
+}
+
+func TestFromConfig(t *testing.T) {
+	notifier, err := FromConfig(&config.Config{}, nil, nil)
+	if err != nil || notifier != nil {
+		t.Errorf("Expected notifications to be disabled, got %v, %v", notifier, err)
+	}
+
+	notifier, err = FromConfig(&config.Config{Notifiers: []string{"Bell"}}, &bytes.Buffer{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := notifier.(*Terminal); !ok {
+		t.Errorf("Expected a terminal notifier, got %T", notifier)
+	}
+
+	notifier, err = FromConfig(&config.Config{
+		Notifiers:      []string{"osc9", "command", "webhook"},
+		NotifyCommand:  "notify-send",
+		NotifyWebhooks: []string{"http://localhost/hook", "slack=http://localhost/slack"},
+	}, &bytes.Buffer{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if multi, ok := notifier.(Multi); !ok || len(multi) != 4 {
+		t.Errorf("Expected four notifiers, got %#v", notifier)
+	}
+
+	var terminal, dryRun bytes.Buffer
+	notifier, err = FromConfig(&config.Config{
+		Notifiers:      []string{"webhook"},
+		NotifyWebhooks: []string{"http://localhost/hook"},
+		NotifyDryRun:   true,
+	}, &terminal, &dryRun)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := notifier.Notify(context.Background(), testNotification()); err != nil || !strings.Contains(dryRun.String(), "POST http://localhost/…") {
+		t.Errorf("Expected the payload to be printed, got %q (%v)", dryRun.String(), err)
+	}
+	if terminal.Len() != 0 {
+		t.Errorf("Expected nothing on the terminal, got %q", terminal.String())
+	}
+
+	if _, err := FromConfig(&config.Config{Notifiers: []string{"webhook"}}, nil, nil); err == nil {
+		t.Error("Expected a webhook without URL to be rejected")
+	}
+	if _, err := FromConfig(&config.Config{Notifiers: []string{"pigeon"}}, nil, nil); err == nil {
+		t.Error("Expected an unknown notifier to be rejected")
+	}
+}
@@ -0,0 +1,146 @@
+package notify
+
+import (
+	"fmt"
+	"time"
+)
+
+// Embed colours used for Discord notifications
+const (
+	discordColorWin  = 0x2ecc71
+	discordColorLoss = 0xe74c3c
+)
+
+// jsonPayload is the document posted to raw JSON webhooks
+type jsonPayload struct {
+	Title string `json:"title"`
+	Text  string `json:"text"`
+	URL   string `json:"url,omitempty"`
+	Notification
+}
+
+// discordMessage is a Discord webhook message with a single embed
+type discordMessage struct {
+	Username string         `json:"username"`
+	Embeds   []discordEmbed `json:"embeds"`
+}
+
+type discordEmbed struct {
+	Title       string         `json:"title"`
+	Description string         `json:"description,omitempty"`
+	URL         string         `json:"url,omitempty"`
+	Color       int            `json:"color"`
+	Fields      []discordField `json:"fields"`
+	Footer      *discordFooter `json:"footer,omitempty"`
+	Timestamp   string         `json:"timestamp,omitempty"`
+}
+
+type discordField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+type discordFooter struct {
+	Text string `json:"text"`
+}
+
+// slackMessage is a Slack incoming webhook message using Block Kit
+type slackMessage struct {
+	Text   string       `json:"text"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type     string      `json:"type"`
+	Text     *slackText  `json:"text,omitempty"`
+	Fields   []slackText `json:"fields,omitempty"`
+	Elements []slackText `json:"elements,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// summaryField is one labelled value of a match summary
+type summaryField struct {
+	name  string
+	value string
+}
+
+// summaryFields lists the values shown in formatted match summaries
+func summaryFields(n Notification) []summaryField {
+	m := n.Match
+	fields := []summaryField{
+		{"Result", m.Result},
+		{"Score", valueOrDash(m.Score)},
+		{"K/D", fmt.Sprintf("%.2f", m.KDRatio)},
+		{"K / D / A", fmt.Sprintf("%d / %d / %d", m.Kills, m.Deaths, m.Assists)},
+	}
+	if m.ADR > 0 {
+		fields = append(fields, summaryField{"ADR", fmt.Sprintf("%.1f", m.ADR)})
+	}
+	if m.HeadshotsPercentage > 0 {
+		fields = append(fields, summaryField{"HS", fmt.Sprintf("%.0f%%", m.HeadshotsPercentage)})
+	}
+	return append(fields, summaryField{"ELO", n.EloText()})
+}
+
+// discordPayload renders a notification as a Discord embed
+func discordPayload(n Notification) discordMessage {
+	embed := discordEmbed{
+		Title:  n.Title(),
+		URL:    matchURL(n.Match.MatchID),
+		Color:  discordColorLoss,
+		Footer: &discordFooter{Text: "faceit-cli"},
+	}
+	if n.Match.Result == "Win" {
+		embed.Color = discordColorWin
+	}
+	if n.Match.FinishedAt > 0 {
+		embed.Timestamp = time.Unix(n.Match.FinishedAt, 0).UTC().Format(time.RFC3339)
+	}
+	for _, field := range summaryFields(n) {
+		embed.Fields = append(embed.Fields, discordField{Name: field.name, Value: field.value, Inline: true})
+	}
+	return discordMessage{Username: "faceit-cli", Embeds: []discordEmbed{embed}}
+}
+
+// slackPayload renders a notification as Slack blocks. The plain text is
+// used by Slack for the notification itself.
+func slackPayload(n Notification) slackMessage {
+	title := n.Title()
+	if url := matchURL(n.Match.MatchID); url != "" {
+		title = fmt.Sprintf("<%s|%s>", url, title)
+	}
+
+	var fields []slackText
+	for _, field := range summaryFields(n) {
+		fields = append(fields, slackText{Type: "mrkdwn", Text: fmt.Sprintf("*%s*\n%s", field.name, field.value)})
+	}
+
+	return slackMessage{
+		Text: n.Title() + ": " + n.Body(),
+		Blocks: []slackBlock{
+			{Type: "section", Text: &slackText{Type: "mrkdwn", Text: "*" + title + "*"}},
+			{Type: "section", Fields: fields},
+			{Type: "context", Elements: []slackText{{Type: "mrkdwn", Text: "Sent by faceit-cli"}}},
+		},
+	}
+}
+
+// matchURL links to the match room on the FACEIT website
+func matchURL(matchID string) string {
+	if matchID == "" {
+		return ""
+	}
+	return "https://www.faceit.com/en/cs2/room/" + matchID
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,82 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Terminal rings the terminal bell or, with OSC9 set, raises a desktop
+// notification through the OSC 9 escape sequence understood by iTerm2,
+// kitty, WezTerm and Windows Terminal
+type Terminal struct {
+	w    io.Writer
+	OSC9 bool
+}
+
+// NewTerminal creates a terminal notifier writing to w
+func NewTerminal(w io.Writer, osc9 bool) *Terminal {
+	return &Terminal{w: w, OSC9: osc9}
+}
+
+// Notify implements Notifier
+func (t *Terminal) Notify(ctx context.Context, n Notification) error {
+	sequence := "\a"
+	if t.OSC9 {
+		sequence = fmt.Sprintf("\x1b]9;%s: %s\a", sanitizeEscape(n.Title()), sanitizeEscape(n.Body()))
+	}
+	if _, err := io.WriteString(t.w, sequence); err != nil {
+		return fmt.Errorf("terminal notification: %w", err)
+	}
+	return nil
+}
+
+// Output is a terminal shared by a full-screen UI and terminal notifiers.
+// Every write holds a lock, so a notification is never written in the
+// middle of a frame. It exposes the file descriptor so the UI still
+// detects the terminal and its size.
+type Output struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewOutput wraps the terminal file f
+func NewOutput(f *os.File) *Output {
+	return &Output{file: f}
+}
+
+// Write implements io.Writer
+func (o *Output) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.file.Write(p)
+}
+
+// Read implements io.Reader
+func (o *Output) Read(p []byte) (int, error) {
+	return o.file.Read(p)
+}
+
+// Close implements io.Closer
+func (o *Output) Close() error {
+	return o.file.Close()
+}
+
+// Fd returns the file descriptor of the terminal
+func (o *Output) Fd() uintptr {
+	return o.file.Fd()
+}
+
+// sanitizeEscape removes control characters that would terminate the
+// escape sequence early
+func sanitizeEscape(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, text)
+}
@@ -0,0 +1,214 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// webhookTimeout bounds a single webhook delivery attempt
+const webhookTimeout = 10 * time.Second
+
+// maxRetryAfter caps the wait requested by a rate-limited webhook
+const maxRetryAfter = 30 * time.Second
+
+// Defaults used for webhooks created from the configuration
+const (
+	DefaultWebhookRetries    = 3
+	DefaultWebhookRetryDelay = time.Second
+)
+
+// WebhookFormat selects the JSON layout posted to a webhook
+type WebhookFormat string
+
+// Supported webhook formats
+const (
+	FormatJSON    WebhookFormat = "json"    // the raw notification
+	FormatDiscord WebhookFormat = "discord" // a Discord embed
+	FormatSlack   WebhookFormat = "slack"   // Slack Block Kit blocks
+)
+
+// WebhookOptions configures a webhook notifier
+type WebhookOptions struct {
+	Format     WebhookFormat
+	Retries    int           // additional attempts after a failed delivery
+	RetryDelay time.Duration // delay before the first retry, doubled for every further one
+	DryRun     io.Writer     // when set, payloads are written here instead of being sent
+}
+
+// Webhook posts notifications as JSON to a URL
+type Webhook struct {
+	url    string
+	opts   WebhookOptions
+	client *http.Client
+}
+
+// NewWebhook creates a webhook notifier for rawURL
+func NewWebhook(rawURL string, opts WebhookOptions) (*Webhook, error) {
+	if rawURL == "" {
+		return nil, fmt.Errorf("notification webhook URL is empty")
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid notification webhook URL %q", rawURL)
+	}
+	if opts.Format == "" {
+		opts.Format = FormatJSON
+	}
+	switch opts.Format {
+	case FormatJSON, FormatDiscord, FormatSlack:
+	default:
+		return nil, fmt.Errorf("unknown webhook format %q (use json, discord or slack)", opts.Format)
+	}
+	return &Webhook{url: rawURL, opts: opts, client: &http.Client{Timeout: webhookTimeout}}, nil
+}
+
+// ParseWebhook parses a webhook specification of the form "format=url".
+// Without a format prefix Discord and Slack webhook URLs are recognised by
+// their host and anything else receives raw JSON.
+func ParseWebhook(spec string, opts WebhookOptions) (*Webhook, error) {
+	spec = strings.TrimSpace(spec)
+	if format, rawURL, found := strings.Cut(spec, "="); found && !strings.Contains(format, "/") {
+		opts.Format = WebhookFormat(strings.ToLower(format))
+		return NewWebhook(rawURL, opts)
+	}
+	opts.Format = detectWebhookFormat(spec)
+	return NewWebhook(spec, opts)
+}
+
+// detectWebhookFormat guesses the format from a webhook URL's host
+func detectWebhookFormat(rawURL string) WebhookFormat {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return FormatJSON
+	}
+	host := strings.ToLower(parsed.Hostname())
+	switch {
+	case host == "hooks.slack.com":
+		return FormatSlack
+	case (host == "discord.com" || host == "discordapp.com" || strings.HasSuffix(host, ".discord.com")) &&
+		strings.HasPrefix(parsed.Path, "/api/webhooks/"):
+		return FormatDiscord
+	default:
+		return FormatJSON
+	}
+}
+
+// Format returns the webhook's payload format
+func (h *Webhook) Format() WebhookFormat {
+	return h.opts.Format
+}
+
+// Notify implements Notifier. Network errors, rate limiting and server
+// errors are retried with exponential backoff.
+func (h *Webhook) Notify(ctx context.Context, n Notification) error {
+	body, err := encodePayload(h.opts.Format, n)
+	if err != nil {
+		return fmt.Errorf("encode webhook payload: %w", err)
+	}
+
+	if h.opts.DryRun != nil {
+		if _, err := fmt.Fprintf(h.opts.DryRun, "POST %s (%s)\n%s\n", redactURL(h.url), h.opts.Format, body); err != nil {
+			return fmt.Errorf("write webhook dry run: %w", err)
+		}
+		return nil
+	}
+
+	delay := h.opts.RetryDelay
+	for attempt := 0; ; attempt++ {
+		wait, err := h.send(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if wait < 0 || attempt >= h.opts.Retries {
+			return fmt.Errorf("webhook %s: %w", redactURL(h.url), err)
+		}
+		if wait == 0 {
+			wait = delay
+			delay *= 2
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("webhook %s: %w", redactURL(h.url), ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// send performs one delivery attempt. On failure it returns how long to
+// wait before retrying: zero for the default backoff, a positive duration
+// requested by the server or a negative one when retrying is pointless.
+func (h *Webhook) send(ctx context.Context, body []byte) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return -1, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return -1, ctx.Err()
+		}
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return 0, nil
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return retryAfter(resp.Header.Get("Retry-After")), fmt.Errorf("rate limited: %s", resp.Status)
+	case resp.StatusCode >= 500:
+		return 0, fmt.Errorf("server error: %s", resp.Status)
+	default:
+		return -1, fmt.Errorf("rejected: %s", resp.Status)
+	}
+}
+
+// retryAfter parses a Retry-After header given in seconds. Zero means the
+// default backoff applies.
+func retryAfter(header string) time.Duration {
+	seconds, err := strconv.ParseFloat(header, 64)
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	wait := time.Duration(seconds * float64(time.Second))
+	if wait > maxRetryAfter {
+		wait = maxRetryAfter
+	}
+	return wait
+}
+
+// redactURL hides the path of a webhook URL, which usually contains its
+// secret token
+func redactURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return "webhook"
+	}
+	return parsed.Scheme + "://" + parsed.Host + "/…"
+}
+
+// encodePayload renders a notification in the given webhook format
+func encodePayload(format WebhookFormat, n Notification) ([]byte, error) {
+	switch format {
+	case FormatDiscord:
+		return json.Marshal(discordPayload(n))
+	case FormatSlack:
+		return json.Marshal(slackPayload(n))
+	default:
+		return json.Marshal(jsonPayload{Title: n.Title(), Text: n.Body(), URL: matchURL(n.Match.MatchID), Notification: n})
+	}
+}
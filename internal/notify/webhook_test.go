@@ -0,0 +1,179 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// webhookServer records the JSON bodies it receives and answers with the
+// queued status codes, then 204
+func webhookServer(t *testing.T, statuses ...int) (*httptest.Server, *[]map[string]interface{}) {
+	t.Helper()
+	var bodies []map[string]interface{}
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.Header.Get("Content-Type"))
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Invalid JSON body: %v", err)
+		}
+		bodies = append(bodies, body)
+
+		call := int(atomic.AddInt32(&calls, 1)) - 1
+		if call < len(statuses) {
+			w.WriteHeader(statuses[call])
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+	return server, &bodies
+}
+
+func TestWebhookFormats(t *testing.T) {
+	server, bodies := webhookServer(t)
+	n := testNotification()
+	n.Match.FinishedAt = 1700000000
+
+	for _, format := range []WebhookFormat{FormatJSON, FormatDiscord, FormatSlack} {
+		webhook, err := NewWebhook(server.URL, WebhookOptions{Format: format})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := webhook.Notify(context.Background(), n); err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+	}
+	if len(*bodies) != 3 {
+		t.Fatalf("Expected three deliveries, got %d", len(*bodies))
+	}
+
+	raw := (*bodies)[0]
+	if raw["player"] != "alice" || raw["title"] != "alice won on de_nuke" || raw["elo_change"] != float64(25) || raw["url"] != "https://www.faceit.com/en/cs2/room/m1" {
+		t.Errorf("Unexpected raw payload %v", raw)
+	}
+
+	embed := (*bodies)[1]["embeds"].([]interface{})[0].(map[string]interface{})
+	if embed["title"] != "alice won on de_nuke" || embed["color"] != float64(discordColorWin) || embed["timestamp"] != "2023-11-14T22:13:20Z" {
+		t.Errorf("Unexpected Discord embed %v", embed)
+	}
+	fields := embed["fields"].([]interface{})
+	last := fields[len(fields)-1].(map[string]interface{})
+	if last["name"] != "ELO" || last["value"] != "2025 (+25)" {
+		t.Errorf("Expected the ELO change as the last field, got %v", last)
+	}
+
+	slack := (*bodies)[2]
+	if !strings.HasPrefix(slack["text"].(string), "alice won on de_nuke: 13-9") {
+		t.Errorf("Unexpected Slack fallback text %q", slack["text"])
+	}
+	blocks := slack["blocks"].([]interface{})
+	if len(blocks) != 3 || !strings.Contains(blocks[0].(map[string]interface{})["text"].(map[string]interface{})["text"].(string), "|alice won on de_nuke>") {
+		t.Errorf("Unexpected Slack blocks %v", blocks)
+	}
+}
+
+func TestWebhookRetries(t *testing.T) {
+	server, bodies := webhookServer(t, http.StatusInternalServerError, http.StatusTooManyRequests)
+	webhook, err := NewWebhook(server.URL, WebhookOptions{Retries: 2, RetryDelay: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := webhook.Notify(context.Background(), testNotification()); err != nil {
+		t.Fatalf("Expected the third attempt to succeed, got %v", err)
+	}
+	if len(*bodies) != 3 {
+		t.Errorf("Expected three attempts, got %d", len(*bodies))
+	}
+
+	server, bodies = webhookServer(t, http.StatusBadGateway, http.StatusBadGateway)
+	webhook, _ = NewWebhook(server.URL, WebhookOptions{Retries: 1, RetryDelay: time.Millisecond})
+	if err := webhook.Notify(context.Background(), testNotification()); err == nil {
+		t.Error("Expected an error once the retries are exhausted")
+	}
+	if len(*bodies) != 2 {
+		t.Errorf("Expected two attempts, got %d", len(*bodies))
+	}
+
+	// Client errors are not retried
+	server, bodies = webhookServer(t, http.StatusNotFound)
+	webhook, _ = NewWebhook(server.URL+"/secret-token", WebhookOptions{Retries: 3, RetryDelay: time.Millisecond})
+	if err := webhook.Notify(context.Background(), testNotification()); err == nil || strings.Contains(err.Error(), "secret-token") {
+		t.Errorf("Expected a rejection without the full URL, got %v", err)
+	}
+	if len(*bodies) != 1 {
+		t.Errorf("Expected a single attempt, got %d", len(*bodies))
+	}
+}
+
+func TestWebhookDryRun(t *testing.T) {
+	server, bodies := webhookServer(t)
+	var out bytes.Buffer
+	webhook, err := NewWebhook(server.URL+"/secret-token", WebhookOptions{Format: FormatDiscord, DryRun: &out})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := webhook.Notify(context.Background(), testNotification()); err != nil {
+		t.Fatal(err)
+	}
+	if len(*bodies) != 0 {
+		t.Error("Expected nothing to be sent in dry-run mode")
+	}
+	if !strings.Contains(out.String(), "(discord)") || !strings.Contains(out.String(), `"embeds"`) || strings.Contains(out.String(), "secret-token") {
+		t.Errorf("Unexpected dry-run output %q", out.String())
+	}
+}
+
+func TestParseWebhook(t *testing.T) {
+	tests := []struct {
+		spec   string
+		format WebhookFormat
+		err    bool
+	}{
+		{"https://discord.com/api/webhooks/1/abc", FormatDiscord, false},
+		{"https://hooks.slack.com/services/T/B/X", FormatSlack, false},
+		{"https://example.com/hook?token=a=b", FormatJSON, false},
+		{"slack=https://example.com/hook", FormatSlack, false},
+		{"Discord=https://example.com/hook", FormatDiscord, false},
+		{"teams=https://example.com/hook", "", true},
+		{"not a url", "", true},
+		{"", "", true},
+	}
+	for _, test := range tests {
+		webhook, err := ParseWebhook(test.spec, WebhookOptions{})
+		if test.err {
+			if err == nil {
+				t.Errorf("ParseWebhook(%q) expected an error", test.spec)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseWebhook(%q) error: %v", test.spec, err)
+			continue
+		}
+		if webhook.Format() != test.format {
+			t.Errorf("ParseWebhook(%q) format = %s, want %s", test.spec, webhook.Format(), test.format)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	if got := retryAfter("2"); got != 2*time.Second {
+		t.Errorf("retryAfter(2) = %s", got)
+	}
+	if got := retryAfter("3600"); got != maxRetryAfter {
+		t.Errorf("Expected long waits to be capped, got %s", got)
+	}
+	if got := retryAfter("Wed, 21 Oct 2015 07:28:00 GMT"); got != 0 {
+		t.Errorf("Expected dates to fall back to the default backoff, got %s", got)
+	}
+}
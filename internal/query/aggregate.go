@@ -0,0 +1,276 @@
+package query
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/armitageee/faceit-cli/internal/entity"
+)
+
+// Aggregate is a function over the matches of a group, such as avg(adr)
+type Aggregate struct {
+	Func  string // count, avg, sum, min or max
+	field field  // unset for count
+}
+
+// ParseAggregate parses count, count() or func(field) where func is avg,
+// sum, min or max and field is a number field
+func ParseAggregate(text string) (Aggregate, error) {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if text == "count" || text == "count()" {
+		return Aggregate{Func: "count"}, nil
+	}
+	open := strings.IndexByte(text, '(')
+	if open <= 0 || !strings.HasSuffix(text, ")") {
+		return Aggregate{}, fmt.Errorf("invalid aggregate %q (use count, avg(field), sum(field), min(field) or max(field))", text)
+	}
+	name, arg := text[:open], strings.TrimSpace(text[open+1:len(text)-1])
+	switch name {
+	case "avg", "sum", "min", "max":
+	default:
+		return Aggregate{}, fmt.Errorf("unknown aggregate function %q (use count, avg, sum, min or max)", name)
+	}
+	f, err := lookupField(arg)
+	if err != nil {
+		return Aggregate{}, err
+	}
+	if f.kind != kindNumber {
+		return Aggregate{}, fmt.Errorf("%s(%s): %s is not a number field", name, arg, f.name)
+	}
+	return Aggregate{Func: name, field: f}, nil
+}
+
+// String returns the aggregate as written, e.g. "avg(adr)"
+func (a Aggregate) String() string {
+	if a.Func == "count" {
+		return "count"
+	}
+	return a.Func + "(" + a.field.name + ")"
+}
+
+// apply computes the aggregate over matches. Averages, minimums and
+// maximums of an empty group are NaN.
+func (a Aggregate) apply(matches []*entity.PlayerMatchSummary) float64 {
+	if a.Func == "count" {
+		return float64(len(matches))
+	}
+	if len(matches) == 0 && a.Func != "sum" {
+		return math.NaN()
+	}
+	var result float64
+	for i, m := range matches {
+		value := a.field.number(m)
+		switch {
+		case a.Func == "min" && (i == 0 || value < result):
+			result = value
+		case a.Func == "max" && (i == 0 || value > result):
+			result = value
+		case a.Func == "sum" || a.Func == "avg":
+			result += value
+		}
+	}
+	if a.Func == "avg" {
+		result /= float64(len(matches))
+	}
+	return result
+}
+
+// Query filters matches and optionally groups and aggregates them
+type Query struct {
+	Filter     Filter
+	GroupBy    []string
+	Aggregates []Aggregate
+	// Sort names a result column; a leading "-" sorts in descending
+	// order. Without it groups are ordered by key and plain matches
+	// keep their order.
+	Sort  string
+	Limit int // maximum number of rows, zero for all
+}
+
+// Table is the result of a query. Cells hold strings or float64 values.
+type Table struct {
+	Columns []string
+	Rows    [][]interface{}
+}
+
+// listColumns are the fields shown when matches are listed without
+// aggregates
+var listColumns = []string{"finished", "map", "result", "score", "kills", "deaths", "assists", "kd", "adr", "hs", "match"}
+
+// Run evaluates the query over matches. Without group-by fields or
+// aggregates it lists the matching matches; with aggregates but no
+// group-by fields it returns a single row over all of them; group-by
+// fields without aggregates count the matches per group.
+func Run(q Query, matches []entity.PlayerMatchSummary) (*Table, error) {
+	filter := q.Filter
+	if filter == nil {
+		filter = matchAll{}
+	}
+	var selected []*entity.PlayerMatchSummary
+	for i := range matches {
+		if filter.Match(&matches[i]) {
+			selected = append(selected, &matches[i])
+		}
+	}
+
+	var table *Table
+	var err error
+	if len(q.GroupBy) == 0 && len(q.Aggregates) == 0 {
+		table, err = list(selected)
+	} else {
+		table, err = group(selected, q.GroupBy, q.Aggregates)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if q.Sort != "" {
+		if err := table.sortBy(q.Sort); err != nil {
+			return nil, err
+		}
+	}
+	if q.Limit > 0 && len(table.Rows) > q.Limit {
+		table.Rows = table.Rows[:q.Limit]
+	}
+	return table, nil
+}
+
+// list returns one row per match
+func list(matches []*entity.PlayerMatchSummary) (*Table, error) {
+	table := &Table{Columns: listColumns}
+	for _, m := range matches {
+		row := make([]interface{}, len(listColumns))
+		for i, name := range listColumns {
+			row[i] = fields[name].value(m)
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table, nil
+}
+
+// group returns one row per distinct combination of the group-by fields,
+// holding the keys followed by the aggregates
+func group(matches []*entity.PlayerMatchSummary, groupBy []string, aggregates []Aggregate) (*Table, error) {
+	keys := make([]field, len(groupBy))
+	for i, name := range groupBy {
+		f, err := lookupField(name)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = f
+	}
+	if len(aggregates) == 0 {
+		aggregates = []Aggregate{{Func: "count"}}
+	}
+
+	table := &Table{}
+	for _, key := range keys {
+		table.Columns = append(table.Columns, key.name)
+	}
+	for _, agg := range aggregates {
+		table.Columns = append(table.Columns, agg.String())
+	}
+
+	type bucket struct {
+		key     []interface{}
+		matches []*entity.PlayerMatchSummary
+	}
+	var buckets []*bucket
+	index := make(map[string]*bucket)
+	if len(keys) == 0 {
+		// A single row over all matches, even when none matched
+		buckets = append(buckets, &bucket{matches: matches})
+	}
+	for _, m := range matches {
+		if len(keys) == 0 {
+			break
+		}
+		key := make([]interface{}, len(keys))
+		for i, f := range keys {
+			key[i] = f.value(m)
+		}
+		id := bucketID(key)
+		b, ok := index[id]
+		if !ok {
+			b = &bucket{key: key}
+			index[id] = b
+			buckets = append(buckets, b)
+		}
+		b.matches = append(b.matches, m)
+	}
+
+	sort.SliceStable(buckets, func(i, j int) bool {
+		return compareRows(buckets[i].key, buckets[j].key) < 0
+	})
+	for _, b := range buckets {
+		row := append([]interface{}{}, b.key...)
+		for _, agg := range aggregates {
+			row = append(row, agg.apply(b.matches))
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table, nil
+}
+
+// sortBy orders the rows by a column, descending with a leading "-"
+func (t *Table) sortBy(spec string) error {
+	descending := strings.HasPrefix(spec, "-")
+	name := strings.ToLower(strings.TrimPrefix(spec, "-"))
+	column := -1
+	for i, c := range t.Columns {
+		if c == name {
+			column = i
+		}
+	}
+	if column < 0 {
+		return fmt.Errorf("cannot sort by %q (columns: %s)", name, strings.Join(t.Columns, ", "))
+	}
+	sort.SliceStable(t.Rows, func(i, j int) bool {
+		cmp := compareCells(t.Rows[i][column], t.Rows[j][column])
+		if descending {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+	return nil
+}
+
+// compareRows compares two keys cell by cell
+func compareRows(a, b []interface{}) int {
+	for i := range a {
+		if cmp := compareCells(a[i], b[i]); cmp != 0 {
+			return cmp
+		}
+	}
+	return 0
+}
+
+// compareCells orders numbers numerically (NaN first) and strings
+// lexically
+func compareCells(a, b interface{}) int {
+	x, aNumber := a.(float64)
+	y, bNumber := b.(float64)
+	if aNumber && bNumber {
+		switch {
+		case math.IsNaN(x) && math.IsNaN(y):
+			return 0
+		case math.IsNaN(x) || x < y:
+			return -1
+		case math.IsNaN(y) || x > y:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+// bucketID identifies a group key. Cells are separated by a byte that
+// values do not contain, so ("ab", "c") and ("a", "bc") stay apart.
+func bucketID(key []interface{}) string {
+	cells := make([]string, len(key))
+	for i, cell := range key {
+		cells[i] = fmt.Sprint(cell)
+	}
+	return strings.Join(cells, "\x00")
+}
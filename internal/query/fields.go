@@ -0,0 +1,124 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/armitageee/faceit-cli/internal/entity"
+)
+
+// kind is the type of a field's values
+type kind int
+
+const (
+	kindString kind = iota
+	kindNumber
+	kindTime
+)
+
+// field describes a property of a match that queries can filter, group
+// and aggregate on. Number fields set number, string fields set text and
+// time fields set both (unix seconds and a display date).
+type field struct {
+	name   string
+	kind   kind
+	help   string
+	number func(m *entity.PlayerMatchSummary) float64
+	text   func(m *entity.PlayerMatchSummary) string
+}
+
+// value returns the field's value as shown in results: a float64 for
+// number fields and a string otherwise
+func (f field) value(m *entity.PlayerMatchSummary) interface{} {
+	if f.kind == kindNumber {
+		return f.number(m)
+	}
+	return f.text(m)
+}
+
+// formatDate formats a unix timestamp as a local date, or "" when unknown
+func formatDate(unix int64, layout string) string {
+	if unix == 0 {
+		return ""
+	}
+	return time.Unix(unix, 0).Format(layout)
+}
+
+func boolNumber(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// fields lists every queryable field by name
+var fields = map[string]field{}
+
+func init() {
+	for _, f := range []field{
+		{name: "match", kind: kindString, help: "match ID",
+			text: func(m *entity.PlayerMatchSummary) string { return m.MatchID }},
+		{name: "map", kind: kindString, help: "map name, e.g. de_nuke",
+			text: func(m *entity.PlayerMatchSummary) string { return m.Map }},
+		{name: "result", kind: kindString, help: "Win or Loss",
+			text: func(m *entity.PlayerMatchSummary) string { return m.Result }},
+		{name: "score", kind: kindString, help: "final score, e.g. 13-9",
+			text: func(m *entity.PlayerMatchSummary) string { return m.Score }},
+		{name: "win", kind: kindNumber, help: "1 for a win, 0 otherwise; avg(win) is the win rate",
+			number: func(m *entity.PlayerMatchSummary) float64 { return boolNumber(m.Result == "Win") }},
+		{name: "kills", kind: kindNumber, help: "kills",
+			number: func(m *entity.PlayerMatchSummary) float64 { return float64(m.Kills) }},
+		{name: "deaths", kind: kindNumber, help: "deaths",
+			number: func(m *entity.PlayerMatchSummary) float64 { return float64(m.Deaths) }},
+		{name: "assists", kind: kindNumber, help: "assists",
+			number: func(m *entity.PlayerMatchSummary) float64 { return float64(m.Assists) }},
+		{name: "kd", kind: kindNumber, help: "kill/death ratio",
+			number: func(m *entity.PlayerMatchSummary) float64 { return m.KDRatio }},
+		{name: "hs", kind: kindNumber, help: "headshot percentage",
+			number: func(m *entity.PlayerMatchSummary) float64 { return m.HeadshotsPercentage }},
+		{name: "adr", kind: kindNumber, help: "average damage per round",
+			number: func(m *entity.PlayerMatchSummary) float64 { return m.ADR }},
+		{name: "elo_change", kind: kindNumber, help: "ELO change, 0 when unknown",
+			number: func(m *entity.PlayerMatchSummary) float64 { return float64(m.EloChange) }},
+		{name: "finished", kind: kindTime, help: "finish time; compare with dates such as 2026-01-01",
+			number: func(m *entity.PlayerMatchSummary) float64 { return float64(m.FinishedAt) },
+			text:   func(m *entity.PlayerMatchSummary) string { return formatDate(m.FinishedAt, "2006-01-02 15:04") }},
+		{name: "started", kind: kindTime, help: "start time",
+			number: func(m *entity.PlayerMatchSummary) float64 { return float64(m.StartedAt) },
+			text:   func(m *entity.PlayerMatchSummary) string { return formatDate(m.StartedAt, "2006-01-02 15:04") }},
+		{name: "day", kind: kindString, help: "finish date, e.g. 2026-01-31",
+			text: func(m *entity.PlayerMatchSummary) string { return formatDate(m.FinishedAt, "2006-01-02") }},
+		{name: "month", kind: kindString, help: "finish month, e.g. 2026-01",
+			text: func(m *entity.PlayerMatchSummary) string { return formatDate(m.FinishedAt, "2006-01") }},
+		{name: "weekday", kind: kindString, help: "finish weekday, e.g. Mon",
+			text: func(m *entity.PlayerMatchSummary) string { return formatDate(m.FinishedAt, "Mon") }},
+	} {
+		fields[f.name] = f
+	}
+}
+
+// lookupField returns the named field, ignoring case
+func lookupField(name string) (field, error) {
+	f, ok := fields[strings.ToLower(name)]
+	if !ok {
+		return field{}, fmt.Errorf("unknown field %q (known fields: %s)", name, strings.Join(FieldNames(), ", "))
+	}
+	return f, nil
+}
+
+// FieldNames returns the names of all queryable fields, sorted
+func FieldNames() []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FieldHelp returns a one-line description of the named field
+func FieldHelp(name string) string {
+	return fields[name].help
+}
@@ -0,0 +1,94 @@
+package query
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// WriteTable writes the result as aligned columns followed by a row count
+func WriteTable(w io.Writer, t *Table) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.ToUpper(strings.Join(t.Columns, "\t")))
+	for _, row := range t.Rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = formatCell(cell)
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	fmt.Fprintf(tw, "\n%d rows\n", len(t.Rows))
+	return tw.Flush()
+}
+
+// formatCell formats whole numbers without decimals, other numbers with
+// two and missing values as "-"
+func formatCell(cell interface{}) string {
+	switch v := cell.(type) {
+	case float64:
+		switch {
+		case math.IsNaN(v):
+			return "-"
+		case v == math.Trunc(v):
+			return fmt.Sprintf("%.0f", v)
+		}
+		return fmt.Sprintf("%.2f", v)
+	case string:
+		if v == "" {
+			return "-"
+		}
+		return v
+	}
+	return fmt.Sprint(cell)
+}
+
+// WriteJSON writes the result as an array of objects keyed by column.
+// Missing values are null.
+func WriteJSON(w io.Writer, t *Table) error {
+	rows := make([]map[string]interface{}, 0, len(t.Rows))
+	for _, row := range t.Rows {
+		object := make(map[string]interface{}, len(row))
+		for i, cell := range row {
+			if v, ok := cell.(float64); ok && math.IsNaN(v) {
+				cell = nil
+			}
+			object[t.Columns[i]] = cell
+		}
+		rows = append(rows, object)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(rows)
+}
+
+// WriteCSV writes the result as CSV with a header row. Missing values are
+// empty cells.
+func WriteCSV(w io.Writer, t *Table) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(t.Columns); err != nil {
+		return err
+	}
+	for _, row := range t.Rows {
+		record := make([]string, len(row))
+		for i, cell := range row {
+			switch v := cell.(type) {
+			case float64:
+				if !math.IsNaN(v) {
+					record[i] = strconv.FormatFloat(v, 'f', -1, 64)
+				}
+			default:
+				record[i] = fmt.Sprint(v)
+			}
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
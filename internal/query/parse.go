@@ -0,0 +1,355 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/armitageee/faceit-cli/internal/entity"
+)
+
+// Filter selects matches. Build one with Parse.
+type Filter interface {
+	Match(m *entity.PlayerMatchSummary) bool
+}
+
+// tokenKind classifies lexer tokens
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString  // quoted text
+	tokenLiteral // number or date
+	tokenOp      // comparison operator
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int // byte offset in the input, for error messages
+}
+
+// lex splits a filter expression into tokens
+func lex(input string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(input); {
+		c := input[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{tokenLParen, "(", i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{tokenRParen, ")", i})
+			i++
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(input[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("position %d: unterminated string", i+1)
+			}
+			tokens = append(tokens, token{tokenString, input[i+1 : i+1+end], i})
+			i += end + 2
+		case strings.ContainsRune("=!<>~&|", rune(c)):
+			start := i
+			for i < len(input) && strings.ContainsRune("=!<>~&|", rune(input[i])) {
+				i++
+			}
+			op := input[start:i]
+			switch op {
+			case "&&":
+				tokens = append(tokens, token{tokenIdent, "and", start})
+			case "||":
+				tokens = append(tokens, token{tokenIdent, "or", start})
+			case "!":
+				tokens = append(tokens, token{tokenIdent, "not", start})
+			case "==":
+				tokens = append(tokens, token{tokenOp, "=", start})
+			case "=", "!=", "<", "<=", ">", ">=", "~":
+				tokens = append(tokens, token{tokenOp, op, start})
+			default:
+				return nil, fmt.Errorf("position %d: unknown operator %q", start+1, op)
+			}
+		case c == '-' || c == '.' || unicode.IsDigit(rune(c)):
+			start := i
+			i++
+			for i < len(input) && strings.ContainsRune("0123456789.-:TZ+", rune(input[i])) {
+				i++
+			}
+			tokens = append(tokens, token{tokenLiteral, input[start:i], start})
+		case c == '_' || unicode.IsLetter(rune(c)):
+			start := i
+			for i < len(input) && (input[i] == '_' || unicode.IsLetter(rune(input[i])) || unicode.IsDigit(rune(input[i]))) {
+				i++
+			}
+			tokens = append(tokens, token{tokenIdent, input[start:i], start})
+		default:
+			return nil, fmt.Errorf("position %d: unexpected character %q", i+1, c)
+		}
+	}
+	return append(tokens, token{tokenEOF, "", len(input)}), nil
+}
+
+// Parse compiles a filter expression such as
+//
+//	map = "de_nuke" and kd > 1.3 and finished > 2026-01-01
+//
+// Comparisons are joined with and, or and not (or &&, || and !) and can be
+// grouped with parentheses. Operators are =, !=, <, <=, >, >= and ~
+// (contains). Text comparisons ignore case and unquoted words are text,
+// so result = win works. An empty expression matches every match.
+func Parse(input string) (Filter, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	if p.peek().kind == tokenEOF {
+		return matchAll{}, nil
+	}
+	filter, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if next := p.peek(); next.kind != tokenEOF {
+		return nil, fmt.Errorf("position %d: unexpected %q", next.pos+1, next.text)
+	}
+	return filter, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// keyword reports whether the next token is the given keyword and
+// consumes it if so
+func (p *parser) keyword(word string) bool {
+	t := p.peek()
+	if t.kind == tokenIdent && strings.EqualFold(t.text, word) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) parseOr() (Filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orFilter{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Filter, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = andFilter{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (Filter, error) {
+	if p.keyword("not") {
+		inner, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notFilter{inner}, nil
+	}
+	if p.peek().kind == tokenLParen {
+		p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t.kind != tokenRParen {
+			return nil, fmt.Errorf("position %d: expected ) but found %s", t.pos+1, describe(t))
+		}
+		return inner, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (Filter, error) {
+	name := p.next()
+	if name.kind != tokenIdent {
+		return nil, fmt.Errorf("position %d: expected a field name but found %s", name.pos+1, describe(name))
+	}
+	f, err := lookupField(name.text)
+	if err != nil {
+		return nil, fmt.Errorf("position %d: %w", name.pos+1, err)
+	}
+	op := p.next()
+	if op.kind != tokenOp {
+		return nil, fmt.Errorf("position %d: expected an operator after %s but found %s", op.pos+1, f.name, describe(op))
+	}
+	value := p.next()
+	if value.kind != tokenString && value.kind != tokenLiteral && value.kind != tokenIdent {
+		return nil, fmt.Errorf("position %d: expected a value after %s but found %s", value.pos+1, op.text, describe(value))
+	}
+
+	c := comparison{field: f, op: op.text}
+	fail := func(err error) (Filter, error) {
+		return nil, fmt.Errorf("position %d: %w", value.pos+1, err)
+	}
+	switch f.kind {
+	case kindString:
+		c.text = strings.ToLower(value.text)
+	case kindNumber:
+		if op.text == "~" {
+			return fail(fmt.Errorf("~ only applies to text fields"))
+		}
+		c.number, err = strconv.ParseFloat(value.text, 64)
+		if err != nil {
+			return fail(fmt.Errorf("%s expects a number, got %q", f.name, value.text))
+		}
+	case kindTime:
+		if op.text == "~" {
+			return fail(fmt.Errorf("~ only applies to text fields"))
+		}
+		c.from, c.to, err = parseTimeRange(value.text)
+		if err != nil {
+			return fail(fmt.Errorf("%s expects a date such as 2026-01-31, got %q", f.name, value.text))
+		}
+	}
+	return c, nil
+}
+
+// describe names a token for error messages
+func describe(t token) string {
+	if t.kind == tokenEOF {
+		return "end of query"
+	}
+	return strconv.Quote(t.text)
+}
+
+// parseTimeRange parses a date or timestamp into the half-open range of
+// unix seconds it covers, so finished = 2026-01-31 matches the whole day
+func parseTimeRange(text string) (from, to int64, err error) {
+	if day, err := time.ParseInLocation("2006-01-02", text, time.Local); err == nil {
+		return day.Unix(), day.AddDate(0, 0, 1).Unix(), nil
+	}
+	if minute, err := time.ParseInLocation("2006-01-02T15:04", text, time.Local); err == nil {
+		return minute.Unix(), minute.Add(time.Minute).Unix(), nil
+	}
+	instant, err := time.Parse(time.RFC3339, text)
+	if err != nil {
+		return 0, 0, err
+	}
+	return instant.Unix(), instant.Unix() + 1, nil
+}
+
+// comparison compares one field with a constant
+type comparison struct {
+	field    field
+	op       string
+	text     string // lower-cased, for string fields
+	number   float64
+	from, to int64 // range covered by the value, for time fields
+}
+
+func (c comparison) Match(m *entity.PlayerMatchSummary) bool {
+	switch c.field.kind {
+	case kindString:
+		value := strings.ToLower(c.field.text(m))
+		if c.op == "~" {
+			return strings.Contains(value, c.text)
+		}
+		return compareOrdered(strings.Compare(value, c.text), c.op)
+	case kindNumber:
+		value := c.field.number(m)
+		switch {
+		case value < c.number:
+			return compareOrdered(-1, c.op)
+		case value > c.number:
+			return compareOrdered(1, c.op)
+		}
+		return compareOrdered(0, c.op)
+	case kindTime:
+		value := int64(c.field.number(m))
+		if value == 0 {
+			return false // unknown times match nothing
+		}
+		switch {
+		case value < c.from:
+			return compareOrdered(-1, c.op)
+		case value >= c.to:
+			return compareOrdered(1, c.op)
+		}
+		return compareOrdered(0, c.op)
+	}
+	return false
+}
+
+// compareOrdered applies op to the sign of a comparison
+func compareOrdered(sign int, op string) bool {
+	switch op {
+	case "=":
+		return sign == 0
+	case "!=":
+		return sign != 0
+	case "<":
+		return sign < 0
+	case "<=":
+		return sign <= 0
+	case ">":
+		return sign > 0
+	case ">=":
+		return sign >= 0
+	}
+	return false
+}
+
+type andFilter struct{ left, right Filter }
+
+func (f andFilter) Match(m *entity.PlayerMatchSummary) bool {
+	return f.left.Match(m) && f.right.Match(m)
+}
+
+type orFilter struct{ left, right Filter }
+
+func (f orFilter) Match(m *entity.PlayerMatchSummary) bool {
+	return f.left.Match(m) || f.right.Match(m)
+}
+
+type notFilter struct{ inner Filter }
+
+func (f notFilter) Match(m *entity.PlayerMatchSummary) bool {
+	return !f.inner.Match(m)
+}
+
+type matchAll struct{}
+
+func (matchAll) Match(*entity.PlayerMatchSummary) bool { return true }
@@ -0,0 +1,228 @@
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/armitageee/faceit-cli/internal/entity"
+)
+
+func unix(date string) int64 {
+	t, err := time.ParseInLocation("2006-01-02 15:04", date, time.Local)
+	if err != nil {
+		panic(err)
+	}
+	return t.Unix()
+}
+
+func testMatches() []entity.PlayerMatchSummary {
+	return []entity.PlayerMatchSummary{
+		{MatchID: "m4", Map: "de_nuke", Result: "Win", KDRatio: 1.5, ADR: 95, Kills: 24, FinishedAt: unix("2026-02-03 21:00")},
+		{MatchID: "m3", Map: "de_mirage", Result: "Loss", KDRatio: 0.8, ADR: 70, Kills: 12, FinishedAt: unix("2026-01-15 20:00")},
+		{MatchID: "m2", Map: "de_nuke", Result: "Loss", KDRatio: 1.2, ADR: 80, Kills: 18, FinishedAt: unix("2026-01-01 18:30")},
+		{MatchID: "m1", Map: "de_nuke", Result: "Win", KDRatio: 1.4, ADR: 88, Kills: 20, FinishedAt: unix("2025-12-31 23:00")},
+	}
+}
+
+// matchIDs returns the IDs of the matches selected by expr
+func matchIDs(t *testing.T, expr string) []string {
+	t.Helper()
+	filter, err := Parse(expr)
+	if err != nil {
+		t.Fatalf("Parse(%q): %v", expr, err)
+	}
+	var ids []string
+	for _, m := range testMatches() {
+		if filter.Match(&m) {
+			ids = append(ids, m.MatchID)
+		}
+	}
+	return ids
+}
+
+func TestParseFilters(t *testing.T) {
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{``, []string{"m4", "m3", "m2", "m1"}},
+		{`map = "de_nuke" and kd > 1.3 and finished > 2026-01-01`, []string{"m4"}},
+		{`map = DE_NUKE`, []string{"m4", "m2", "m1"}},
+		{`result = win or adr >= 80`, []string{"m4", "m2", "m1"}},
+		{`not (result = win) and map != de_mirage`, []string{"m2"}},
+		{`map ~ mir || kills < 19 && kd >= 1.2`, []string{"m3", "m2"}},
+		{`finished = 2026-01-01`, []string{"m2"}},
+		{`finished >= 2026-01-01 and finished < 2026-02-01`, []string{"m3", "m2"}},
+		{`finished <= 2025-12-31`, []string{"m1"}},
+		{`month = 2026-01`, []string{"m3", "m2"}},
+		{`win = 1 and elo_change = 0`, []string{"m4", "m1"}},
+	}
+	for _, tt := range tests {
+		if got := matchIDs(t, tt.expr); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{`mapp = de_nuke`, `unknown field "mapp"`},
+		{`kd > high`, `kd expects a number`},
+		{`finished > yesterday`, `finished expects a date`},
+		{`kd ~ 1`, `~ only applies to text fields`},
+		{`map = "de_nuke`, `unterminated string`},
+		{`(kd > 1`, `expected ) but found end of query`},
+		{`kd > 1 map = x`, `position 8: unexpected "map"`},
+		{`kd =< 1`, `unknown operator "=<"`},
+		{`kd`, `expected an operator after kd`},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.expr)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.expr, tt.want, err)
+		}
+	}
+}
+
+func TestParseAggregate(t *testing.T) {
+	for _, text := range []string{"count", "COUNT()", "avg(adr)", "max( kd )"} {
+		if _, err := ParseAggregate(text); err != nil {
+			t.Errorf("ParseAggregate(%q): %v", text, err)
+		}
+	}
+	for _, text := range []string{"median(adr)", "avg(map)", "avg(nope)", "avg adr"} {
+		if _, err := ParseAggregate(text); err == nil {
+			t.Errorf("ParseAggregate(%q): expected an error", text)
+		}
+	}
+}
+
+func mustAggregates(t *testing.T, texts ...string) []Aggregate {
+	t.Helper()
+	var aggs []Aggregate
+	for _, text := range texts {
+		agg, err := ParseAggregate(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		aggs = append(aggs, agg)
+	}
+	return aggs
+}
+
+func TestRunGroupBy(t *testing.T) {
+	table, err := Run(Query{
+		GroupBy:    []string{"map"},
+		Aggregates: mustAggregates(t, "count", "avg(adr)", "avg(win)", "max(kills)"),
+	}, testMatches())
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantColumns := []string{"map", "count", "avg(adr)", "avg(win)", "max(kills)"}
+	if !reflect.DeepEqual(table.Columns, wantColumns) {
+		t.Errorf("Columns = %v, want %v", table.Columns, wantColumns)
+	}
+	wantRows := [][]interface{}{
+		{"de_mirage", 1.0, 70.0, 0.0, 12.0},
+		{"de_nuke", 3.0, (95.0 + 80 + 88) / 3, 2.0 / 3, 24.0},
+	}
+	if !reflect.DeepEqual(table.Rows, wantRows) {
+		t.Errorf("Rows = %v, want %v", table.Rows, wantRows)
+	}
+}
+
+func TestRunGroupByKeepsKeysApart(t *testing.T) {
+	matches := []entity.PlayerMatchSummary{
+		{MatchID: "m1", Map: "ab", Score: "c"},
+		{MatchID: "m2", Map: "a", Score: "bc"},
+	}
+	table, err := Run(Query{GroupBy: []string{"map", "score"}, Aggregates: mustAggregates(t, "count")}, matches)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantRows := [][]interface{}{{"a", "bc", 1.0}, {"ab", "c", 1.0}}
+	if !reflect.DeepEqual(table.Rows, wantRows) {
+		t.Errorf("Rows = %v, want %v", table.Rows, wantRows)
+	}
+}
+
+func TestRunDefaults(t *testing.T) {
+	filter, _ := Parse("map = de_nuke")
+
+	// Group-by without aggregates counts
+	table, err := Run(Query{Filter: filter, GroupBy: []string{"result"}, Sort: "-count"}, testMatches())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := [][]interface{}{{"Win", 2.0}, {"Loss", 1.0}}; !reflect.DeepEqual(table.Rows, want) {
+		t.Errorf("Rows = %v, want %v", table.Rows, want)
+	}
+
+	// Aggregates without group-by give one row, even for no matches
+	none, _ := Parse("map = de_inferno")
+	table, err = Run(Query{Filter: none, Aggregates: mustAggregates(t, "count", "avg(kd)")}, testMatches())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(table.Rows) != 1 || table.Rows[0][0] != 0.0 || !math.IsNaN(table.Rows[0][1].(float64)) {
+		t.Errorf("Expected a single empty row, got %v", table.Rows)
+	}
+
+	// Neither lists matches, newest first, up to the limit
+	table, err = Run(Query{Filter: filter, Limit: 2}, testMatches())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(table.Rows) != 2 || table.Rows[0][len(listColumns)-1] != "m4" {
+		t.Errorf("Expected m4 and m2, got %v", table.Rows)
+	}
+
+	if _, err := Run(Query{Sort: "bogus"}, testMatches()); err == nil {
+		t.Error("Expected an error for an unknown sort column")
+	}
+}
+
+func TestWriteOutput(t *testing.T) {
+	table := &Table{
+		Columns: []string{"map", "count", "avg(kd)"},
+		Rows:    [][]interface{}{{"de_nuke", 3.0, 1.3666}, {"", 0.0, math.NaN()}},
+	}
+
+	var out bytes.Buffer
+	if err := WriteTable(&out, table); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"MAP", "AVG(KD)", "de_nuke  3      1.37", "-        0      -", "2 rows"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Table missing %q:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	if err := WriteJSON(&out, table); err != nil {
+		t.Fatal(err)
+	}
+	var rows []map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &rows); err != nil {
+		t.Fatalf("Invalid JSON: %v\n%s", err, out.String())
+	}
+	if len(rows) != 2 || rows[0]["map"] != "de_nuke" || rows[1]["avg(kd)"] != nil {
+		t.Errorf("Unexpected JSON rows: %v", rows)
+	}
+
+	out.Reset()
+	if err := WriteCSV(&out, table); err != nil {
+		t.Fatal(err)
+	}
+	if want := "map,count,avg(kd)\nde_nuke,3,1.3666\n,0,\n"; out.String() != want {
+		t.Errorf("Unexpected CSV:\n%s", out.String())
+	}
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	faceit "github.com/mconnat/go-faceit"
+)
+
+// ErrPlayerNotFound is returned when no FACEIT player matches a nickname
+var ErrPlayerNotFound = errors.New("player not found")
+
+// UpstreamStatus returns the HTTP status code of a failed FACEIT API call
+// wrapped in err, or 0 when err did not come from the API
+func UpstreamStatus(err error) int {
+	var apiErr faceit.GenericSwaggerError
+	if !errors.As(err, &apiErr) {
+		return 0
+	}
+	// The client reports the response status line, e.g. "404 Not Found"
+	code, convErr := strconv.Atoi(strings.SplitN(apiErr.Error(), " ", 2)[0])
+	if convErr != nil {
+		return 0
+	}
+	return code
+}
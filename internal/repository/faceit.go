@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
 	"time"
@@ -23,6 +24,7 @@ import (
 // FaceitRepository defines the interface for FACEIT API operations
 type FaceitRepository interface {
 	GetPlayerByNickname(ctx context.Context, nickname string) (*entity.PlayerProfile, error)
+	GetPlayerByID(ctx context.Context, playerID string) (*entity.PlayerProfile, error)
 	GetPlayerStats(ctx context.Context, playerID, gameID string) (*entity.PlayerStats, error)
 	GetPlayerRecentMatches(ctx context.Context, playerID string, gameID string, limit int) ([]entity.PlayerMatchSummary, error)
 	GetMatchStats(ctx context.Context, matchID string) (*entity.MatchStats, error)
@@ -43,7 +45,17 @@ type faceitRepository struct {
 // underlying API client is created with default configuration –
 // including the base URL "https://open.faceit.com/data/v4".
 func NewFaceitRepository(apiKey string, telemetryInstance *telemetry.Telemetry) FaceitRepository {
+	return NewFaceitRepositoryWithClient(apiKey, telemetryInstance, nil)
+}
+
+// NewFaceitRepositoryWithClient is NewFaceitRepository sending the API
+// requests through httpClient, e.g. one with a RateLimitedTransport. A
+// nil client uses http.DefaultClient.
+func NewFaceitRepositoryWithClient(apiKey string, telemetryInstance *telemetry.Telemetry, httpClient *http.Client) FaceitRepository {
 	cfg := faceit.NewConfiguration()
+	if httpClient != nil {
+		cfg.HTTPClient = httpClient
+	}
 	client := faceit.NewAPIClient(cfg)
 	
 	// Create logger with default config
@@ -109,7 +121,9 @@ func (r *faceitRepository) GetPlayerByNickname(ctx context.Context, nickname str
 		"options":  opts,
 	})
 	
-	list, _, err := r.client.SearchApi.SearchPlayers(ctx, nickname, opts)
+	start := time.Now()
+	list, resp, err := r.client.SearchApi.SearchPlayers(ctx, nickname, opts)
+	r.recordRequest(ctx, "search_players", start, resp)
 	if err != nil {
 		r.logger.Error("Failed to search players", map[string]interface{}{
 			"nickname": nickname,
@@ -127,7 +141,7 @@ func (r *faceitRepository) GetPlayerByNickname(ctx context.Context, nickname str
 		r.logger.Debug("No players found", map[string]interface{}{
 			"nickname": nickname,
 		})
-		return nil, fmt.Errorf("player not found: %s", nickname)
+		return nil, fmt.Errorf("%w: %s", ErrPlayerNotFound, nickname)
 	}
 	playerID := list.Items[0].PlayerId
 	
@@ -139,7 +153,9 @@ func (r *faceitRepository) GetPlayerByNickname(ctx context.Context, nickname str
 	// Retrieve full player details using the resolved ID. A separate
 	// endpoint exists to fetch details directly by nickname, but the
 	// search call ensures we have a valid ID before proceeding.
-	player, _, err := r.client.PlayersApi.GetPlayer(ctx, playerID)
+	start = time.Now()
+	player, resp, err := r.client.PlayersApi.GetPlayer(ctx, playerID)
+	r.recordRequest(ctx, "get_player", start, resp)
 	if err != nil {
 		r.logger.Error("Failed to get player details", map[string]interface{}{
 			"nickname":  nickname,
@@ -148,6 +164,62 @@ func (r *faceitRepository) GetPlayerByNickname(ctx context.Context, nickname str
 		})
 		return nil, fmt.Errorf("get player: %w", err)
 	}
+	profile := newPlayerProfile(player)
+
+	// Add telemetry attributes if enabled
+	r.setSpanSuccessWithAttributes(span, "Player found successfully",
+		attribute.String("player.id", profile.ID),
+		attribute.String("player.country", profile.Country),
+	)
+
+	return profile, nil
+}
+
+// GetPlayerByID returns the profile of a player whose ID is already
+// known, e.g. from a match scoreboard, without searching the nickname.
+func (r *faceitRepository) GetPlayerByID(ctx context.Context, playerID string) (*entity.PlayerProfile, error) {
+	// Start tracing span if telemetry is enabled
+	var span trace.Span
+	if r.telemetry != nil {
+		ctx, span = r.telemetry.StartSpan(ctx, "repository.get_player_by_id")
+		defer span.End()
+
+		r.setSpanAttributes(span,
+			attribute.String("player.id", playerID),
+		)
+	}
+
+	if playerID == "" {
+		return nil, fmt.Errorf("playerID must not be empty")
+	}
+
+	ctx = r.contextWithAPIKey(ctx)
+	start := time.Now()
+	player, resp, err := r.client.PlayersApi.GetPlayer(ctx, playerID)
+	r.recordRequest(ctx, "get_player", start, resp)
+	if err != nil {
+		r.setSpanError(span, err)
+		r.logger.Error("Failed to get player details", map[string]interface{}{
+			"player_id": playerID,
+			"error":     err.Error(),
+		})
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("%w: %s", ErrPlayerNotFound, playerID)
+		}
+		return nil, fmt.Errorf("get player: %w", err)
+	}
+	profile := newPlayerProfile(player)
+
+	r.setSpanSuccessWithAttributes(span, "Player found successfully",
+		attribute.String("player.nickname", profile.Nickname),
+		attribute.String("player.country", profile.Country),
+	)
+
+	return profile, nil
+}
+
+// newPlayerProfile converts a player returned by the API to a profile
+func newPlayerProfile(player faceit.Player) *entity.PlayerProfile {
 	profile := &entity.PlayerProfile{
 		ID:        player.PlayerId,
 		Nickname:  player.Nickname,
@@ -158,23 +230,14 @@ func (r *faceitRepository) GetPlayerByNickname(ctx context.Context, nickname str
 	}
 	// Populate per‑game details. Each entry in the map corresponds to
 	// a registered game (e.g. "cs2", "dota2").
-	if player.Games != nil {
-		for k, g := range player.Games {
-			profile.Games[k] = entity.GameDetail{
-				Elo:        int(g.FaceitElo),
-				SkillLevel: int(g.SkillLevel),
-				Region:     g.Region,
-			}
+	for k, g := range player.Games {
+		profile.Games[k] = entity.GameDetail{
+			Elo:        int(g.FaceitElo),
+			SkillLevel: int(g.SkillLevel),
+			Region:     g.Region,
 		}
 	}
-
-	// Add telemetry attributes if enabled
-	r.setSpanSuccessWithAttributes(span, "Player found successfully",
-		attribute.String("player.id", profile.ID),
-		attribute.String("player.country", profile.Country),
-	)
-
-	return profile, nil
+	return profile
 }
 
 // GetPlayerStats fetches lifetime statistics for a given player and game.
@@ -203,7 +266,9 @@ func (r *faceitRepository) GetPlayerStats(ctx context.Context, playerID, gameID
 	}
 
 	ctx = r.contextWithAPIKey(ctx)
-	stats, _, err := r.client.PlayersApi.GetPlayerStats_1(ctx, playerID, gameID)
+	start := time.Now()
+	stats, resp, err := r.client.PlayersApi.GetPlayerStats_1(ctx, playerID, gameID)
+	r.recordRequest(ctx, "get_player_stats", start, resp)
 	if err != nil {
 		r.setSpanError(span, err)
 		return nil, fmt.Errorf("get player stats: %w", err)
@@ -274,7 +339,9 @@ func (r *faceitRepository) GetPlayerRecentMatches(ctx context.Context, playerID
 		opts.Limit = optional.NewInt32(int32(batchSize))
 		opts.Offset = optional.NewInt32(int32(offset))
 
-		history, _, err := r.client.PlayersApi.GetPlayerHistory(ctx, playerID, gameID, opts)
+		start := time.Now()
+		history, resp, err := r.client.PlayersApi.GetPlayerHistory(ctx, playerID, gameID, opts)
+		r.recordRequest(ctx, "get_player_history", start, resp)
 		if err != nil {
 			return nil, fmt.Errorf("get player history: %w", err)
 		}
@@ -334,6 +401,24 @@ func (r *faceitRepository) processMatches(items []faceit.MatchHistory, playerID
 				}
 			}
 		}
+		// Everyone else in the lobby is either a teammate or an
+		// opponent, depending on the faction they played for.
+		var teammates, opponents []entity.MatchParticipant
+		if playerTeamID != "" {
+			for teamID, faction := range item.Teams {
+				for _, p := range faction.Players {
+					if p.PlayerId == playerID {
+						continue
+					}
+					participant := entity.MatchParticipant{PlayerID: p.PlayerId, Nickname: p.Nickname}
+					if teamID == playerTeamID {
+						teammates = append(teammates, participant)
+					} else {
+						opponents = append(opponents, participant)
+					}
+				}
+			}
+		}
 		// Determine the match result (win/loss) by comparing the
 		// player's team with the winner reported in the results.
 		result := "Loss"
@@ -376,7 +461,9 @@ func (r *faceitRepository) processMatches(items []faceit.MatchHistory, playerID
 		statsCtx, statsCancel := context.WithTimeout(context.Background(), 30*time.Second)
 		statsCtx = r.contextWithAPIKey(statsCtx)
 		
-		stats, _, err := r.client.MatchesApi.GetMatchStats(statsCtx, item.MatchId)
+		start := time.Now()
+		stats, resp, err := r.client.MatchesApi.GetMatchStats(statsCtx, item.MatchId)
+		r.recordRequest(statsCtx, "get_match_stats", start, resp)
 		statsCancel()
 		
 		if err != nil {
@@ -520,6 +607,7 @@ func (r *faceitRepository) processMatches(items []faceit.MatchHistory, playerID
 		summary := entity.PlayerMatchSummary{
 			MatchID:             item.MatchId,
 			Map:                 mapName,
+			StartedAt:           item.StartedAt,
 			FinishedAt:          item.FinishedAt,
 			Score:               scoreStr,
 			Kills:               kills,
@@ -529,6 +617,9 @@ func (r *faceitRepository) processMatches(items []faceit.MatchHistory, playerID
 			HeadshotsPercentage: hsPerc,
 			ADR:                 adr,
 			Result:              result,
+			TeamID:              playerTeamID,
+			Teammates:           teammates,
+			Opponents:           opponents,
 		}
 		results = append(results, summary)
 	}
@@ -565,7 +656,9 @@ func (r *faceitRepository) GetMatchStats(ctx context.Context, matchID string) (*
 	})
 	
 	// Try to get match details first
-	match, _, err := r.client.MatchesApi.GetMatch(ctx, matchID)
+	start := time.Now()
+	match, resp, err := r.client.MatchesApi.GetMatch(ctx, matchID)
+	r.recordRequest(ctx, "get_match", start, resp)
 	if err != nil {
 		r.logger.Error("Failed to get match details", map[string]interface{}{
 			"match_id": matchID,
@@ -586,7 +679,9 @@ func (r *faceitRepository) GetMatchStats(ctx context.Context, matchID string) (*
 	})
 
 	// Get match statistics
-	stats, _, err := r.client.MatchesApi.GetMatchStats(ctx, matchID)
+	start = time.Now()
+	stats, resp, err := r.client.MatchesApi.GetMatchStats(ctx, matchID)
+	r.recordRequest(ctx, "get_match_stats", start, resp)
 	if err != nil {
 		r.logger.Error("Failed to get match statistics", map[string]interface{}{
 			"match_id": matchID,
@@ -834,6 +929,18 @@ func (r *faceitRepository) GetMatchStats(ctx context.Context, matchID string) (*
 
 // Helper methods for telemetry operations
 
+// recordRequest records the metrics of one FACEIT API request
+func (r *faceitRepository) recordRequest(ctx context.Context, endpoint string, start time.Time, resp *http.Response) {
+	if r.telemetry == nil {
+		return
+	}
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+	}
+	r.telemetry.Metrics().RecordUpstreamRequest(ctx, endpoint, status, time.Since(start))
+}
+
 // setSpanAttributes sets attributes on a span if telemetry is enabled
 func (r *faceitRepository) setSpanAttributes(span trace.Span, attrs ...attribute.KeyValue) {
 	if r.telemetry != nil && span != nil {
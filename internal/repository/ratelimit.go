@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/armitageee/faceit-cli/internal/telemetry"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimitedTransport wraps an http.RoundTripper and spaces out requests
+// so that all users of the FACEIT client together stay below a request
+// rate. It counts every API request, so a repository call that fetches
+// the stats of each match is limited like the requests it makes. Cached
+// responses never reach it.
+type RateLimitedTransport struct {
+	next    http.RoundTripper
+	limiter *rate.Limiter
+	metrics *telemetry.Metrics
+}
+
+// NewRateLimitedTransport allows perSecond requests per second on average
+// with bursts of up to burst requests. A nil next uses
+// http.DefaultTransport.
+func NewRateLimitedTransport(next http.RoundTripper, perSecond float64, burst int) *RateLimitedTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimitedTransport{
+		next:    next,
+		limiter: rate.NewLimiter(rate.Limit(perSecond), burst),
+	}
+}
+
+// WithMetrics records requests delayed by the limit. A nil metrics value
+// disables recording.
+func (t *RateLimitedTransport) WithMetrics(metrics *telemetry.Metrics) *RateLimitedTransport {
+	t.metrics = metrics
+	return t
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *RateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.next.RoundTrip(req)
+}
+
+// wait blocks until the limiter allows a request and records any delay
+func (t *RateLimitedTransport) wait(ctx context.Context) error {
+	reservation := t.limiter.Reserve()
+	delay := reservation.Delay()
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		reservation.Cancel()
+		return ctx.Err()
+	case <-timer.C:
+		t.metrics.RecordRateLimitWait(ctx, delay)
+		return nil
+	}
+}
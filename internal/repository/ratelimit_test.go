@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// countingTransport counts requests and answers them with an empty body
+type countingTransport struct {
+	requests int
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests++
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+}
+
+func TestRateLimitedTransport(t *testing.T) {
+	inner := &countingTransport{}
+	client := &http.Client{Transport: NewRateLimitedTransport(inner, 1, 2)}
+	get := func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://faceit.test/players", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Do(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+	ctx := context.Background()
+
+	// The burst is served immediately
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		if err := get(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected the burst to pass without waiting, took %s", elapsed)
+	}
+
+	// Further requests wait for the limiter and give up with the context
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := get(ctx); err == nil {
+		t.Error("Expected the request to be limited")
+	}
+	if inner.requests != 2 {
+		t.Errorf("Expected 2 requests to be sent, got %d", inner.requests)
+	}
+}
+
+func TestUpstreamStatus(t *testing.T) {
+	if got := UpstreamStatus(errors.New("boom")); got != 0 {
+		t.Errorf("UpstreamStatus(plain error) = %d, want 0", got)
+	}
+}
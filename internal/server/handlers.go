@@ -0,0 +1,180 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/armitageee/faceit-cli/internal/analytics"
+	"github.com/armitageee/faceit-cli/internal/entity"
+)
+
+func (s *Server) handleHealth(r *http.Request) (interface{}, error) {
+	return map[string]string{"status": "ok"}, nil
+}
+
+// handlePlayer looks a player up by nickname
+func (s *Server) handlePlayer(r *http.Request) (interface{}, error) {
+	profile, err := s.repo.GetPlayerByNickname(r.Context(), r.PathValue("nickname"))
+	if err != nil {
+		return nil, err
+	}
+	return newPlayerResponse(profile), nil
+}
+
+// handlePlayerMatches lists a player's recent matches, newest first
+func (s *Server) handlePlayerMatches(r *http.Request) (interface{}, error) {
+	limit, err := intParam(r, "limit", defaultMatches, maxMatches)
+	if err != nil {
+		return nil, err
+	}
+	matches, err := s.repo.GetPlayerRecentMatches(r.Context(), r.PathValue("id"), gameParam(r), limit)
+	if err != nil {
+		return nil, err
+	}
+	return newMatchesResponse(matches), nil
+}
+
+// handlePlayerStats returns a player's lifetime statistics
+func (s *Server) handlePlayerStats(r *http.Request) (interface{}, error) {
+	stats, err := s.repo.GetPlayerStats(r.Context(), r.PathValue("id"), gameParam(r))
+	if err != nil {
+		return nil, err
+	}
+	return statsResponse{
+		PlayerID: stats.PlayerID,
+		GameID:   stats.GameID,
+		Lifetime: stats.Lifetime,
+		Segments: stats.Segments,
+	}, nil
+}
+
+// handlePlayerSummary aggregates a player's recent form
+func (s *Server) handlePlayerSummary(r *http.Request) (interface{}, error) {
+	limit, err := intParam(r, "matches", defaultMatches, maxMatches)
+	if err != nil {
+		return nil, err
+	}
+	matches, err := s.repo.GetPlayerRecentMatches(r.Context(), r.PathValue("id"), gameParam(r), limit)
+	if err != nil {
+		return nil, err
+	}
+	return analytics.Summarize(matches), nil
+}
+
+// handleMatch returns the scoreboard of a match
+func (s *Server) handleMatch(r *http.Request) (interface{}, error) {
+	stats, err := s.repo.GetMatchStats(r.Context(), r.PathValue("id"))
+	if err != nil {
+		return nil, err
+	}
+	return newMatchStatsResponse(stats), nil
+}
+
+// handleCompare compares the recent form of several players given as
+// ?players=a,b,c and ranks them by every metric
+func (s *Server) handleCompare(r *http.Request) (interface{}, error) {
+	nicknames := splitNicknames(r.URL.Query().Get("players"))
+	if len(nicknames) < 2 || len(nicknames) > maxCompared {
+		return nil, badRequest("players must list 2 to %d comma-separated nicknames", maxCompared)
+	}
+	limit, err := intParam(r, "matches", s.comparisonMatches, maxMatches)
+	if err != nil {
+		return nil, err
+	}
+	gameID := gameParam(r)
+
+	players := make([]comparedPlayerResponse, len(nicknames))
+	errs := make([]error, len(nicknames))
+	var wg sync.WaitGroup
+	for i, nickname := range nicknames {
+		wg.Add(1)
+		go func(i int, nickname string) {
+			defer wg.Done()
+			profile, err := s.repo.GetPlayerByNickname(r.Context(), nickname)
+			if err != nil {
+				errs[i] = fmt.Errorf("load %s: %w", nickname, err)
+				return
+			}
+			matches, err := s.repo.GetPlayerRecentMatches(r.Context(), profile.ID, gameID, limit)
+			if err != nil {
+				errs[i] = fmt.Errorf("load %s's matches: %w", nickname, err)
+				return
+			}
+			players[i] = comparedPlayerResponse{
+				PlayerID: profile.ID,
+				Nickname: profile.Nickname,
+				Elo:      profile.Games[gameID].Elo,
+				Summary:  analytics.Summarize(matches),
+			}
+		}(i, nickname)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	summaries := make([]analytics.MatchSummary, len(players))
+	for i, player := range players {
+		summaries[i] = player.Summary
+	}
+	rankings := make(map[analytics.Metric][]string, len(analytics.Metrics))
+	for _, metric := range analytics.Metrics {
+		for _, index := range analytics.Rank(summaries, metric) {
+			rankings[metric] = append(rankings[metric], players[index].Nickname)
+		}
+	}
+
+	return comparisonResponse{Matches: limit, Players: players, Rankings: rankings}, nil
+}
+
+// intParam parses a positive integer query parameter no larger than max
+func intParam(r *http.Request, name string, def, max int) (int, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return def, nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || value <= 0 || value > max {
+		return 0, badRequest("%s must be a number between 1 and %d", name, max)
+	}
+	return value, nil
+}
+
+// gameParam returns the ?game parameter, defaulting to CS2
+func gameParam(r *http.Request) string {
+	if game := r.URL.Query().Get("game"); game != "" {
+		return game
+	}
+	return defaultGameID
+}
+
+// splitNicknames splits a comma-separated list, dropping duplicates
+func splitNicknames(value string) []string {
+	var nicknames []string
+	seen := make(map[string]bool)
+	for _, nickname := range strings.Split(value, ",") {
+		nickname = strings.TrimSpace(nickname)
+		key := strings.ToLower(nickname)
+		if nickname == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		nicknames = append(nicknames, nickname)
+	}
+	return nicknames
+}
+
+// newMatchesResponse converts match summaries, keeping an empty list
+// encoded as [] rather than null
+func newMatchesResponse(matches []entity.PlayerMatchSummary) []matchResponse {
+	response := make([]matchResponse, 0, len(matches))
+	for _, match := range matches {
+		response = append(response, newMatchResponse(match))
+	}
+	return response
+}
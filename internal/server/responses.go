@@ -0,0 +1,169 @@
+package server
+
+import (
+	"github.com/armitageee/faceit-cli/internal/analytics"
+	"github.com/armitageee/faceit-cli/internal/entity"
+)
+
+// errorResponse is returned for every failed request
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type gameResponse struct {
+	Elo        int    `json:"elo"`
+	SkillLevel int    `json:"skill_level"`
+	Region     string `json:"region"`
+}
+
+type playerResponse struct {
+	ID        string                  `json:"id"`
+	Nickname  string                  `json:"nickname"`
+	Country   string                  `json:"country"`
+	Avatar    string                  `json:"avatar"`
+	FaceitURL string                  `json:"faceit_url"`
+	Games     map[string]gameResponse `json:"games"`
+}
+
+func newPlayerResponse(profile *entity.PlayerProfile) playerResponse {
+	games := make(map[string]gameResponse, len(profile.Games))
+	for id, game := range profile.Games {
+		games[id] = gameResponse{Elo: game.Elo, SkillLevel: game.SkillLevel, Region: game.Region}
+	}
+	return playerResponse{
+		ID:        profile.ID,
+		Nickname:  profile.Nickname,
+		Country:   profile.Country,
+		Avatar:    profile.Avatar,
+		FaceitURL: profile.FaceitURL,
+		Games:     games,
+	}
+}
+
+type participantResponse struct {
+	PlayerID string `json:"player_id"`
+	Nickname string `json:"nickname"`
+}
+
+type matchResponse struct {
+	MatchID             string                `json:"match_id"`
+	Map                 string                `json:"map"`
+	StartedAt           int64                 `json:"started_at"`
+	FinishedAt          int64                 `json:"finished_at"`
+	Score               string                `json:"score"`
+	Result              string                `json:"result"`
+	Kills               int                   `json:"kills"`
+	Deaths              int                   `json:"deaths"`
+	Assists             int                   `json:"assists"`
+	KDRatio             float64               `json:"kd_ratio"`
+	HeadshotsPercentage float64               `json:"headshots_percentage"`
+	ADR                 float64               `json:"adr"`
+	TeamID              string                `json:"team_id"`
+	Teammates           []participantResponse `json:"teammates"`
+	Opponents           []participantResponse `json:"opponents"`
+}
+
+func newMatchResponse(match entity.PlayerMatchSummary) matchResponse {
+	return matchResponse{
+		MatchID:             match.MatchID,
+		Map:                 match.Map,
+		StartedAt:           match.StartedAt,
+		FinishedAt:          match.FinishedAt,
+		Score:               match.Score,
+		Result:              match.Result,
+		Kills:               match.Kills,
+		Deaths:              match.Deaths,
+		Assists:             match.Assists,
+		KDRatio:             match.KDRatio,
+		HeadshotsPercentage: match.HeadshotsPercentage,
+		ADR:                 match.ADR,
+		TeamID:              match.TeamID,
+		Teammates:           newParticipantsResponse(match.Teammates),
+		Opponents:           newParticipantsResponse(match.Opponents),
+	}
+}
+
+func newParticipantsResponse(participants []entity.MatchParticipant) []participantResponse {
+	response := make([]participantResponse, 0, len(participants))
+	for _, p := range participants {
+		response = append(response, participantResponse{PlayerID: p.PlayerID, Nickname: p.Nickname})
+	}
+	return response
+}
+
+type statsResponse struct {
+	PlayerID string                   `json:"player_id"`
+	GameID   string                   `json:"game_id"`
+	Lifetime map[string]interface{}   `json:"lifetime"`
+	Segments []map[string]interface{} `json:"segments"`
+}
+
+type scoreboardPlayerResponse struct {
+	PlayerID            string  `json:"player_id"`
+	Nickname            string  `json:"nickname"`
+	Kills               int     `json:"kills"`
+	Deaths              int     `json:"deaths"`
+	Assists             int     `json:"assists"`
+	KDRatio             float64 `json:"kd_ratio"`
+	HeadshotsPercentage float64 `json:"headshots_percentage"`
+	ADR                 float64 `json:"adr"`
+	HLTVRating          float64 `json:"hltv_rating"`
+}
+
+type teamResponse struct {
+	TeamID  string                     `json:"team_id"`
+	Name    string                     `json:"name"`
+	Score   int                        `json:"score"`
+	Players []scoreboardPlayerResponse `json:"players"`
+}
+
+type matchStatsResponse struct {
+	MatchID    string         `json:"match_id"`
+	Map        string         `json:"map"`
+	FinishedAt int64          `json:"finished_at"`
+	Score      string         `json:"score"`
+	Status     string         `json:"status"`
+	Teams      []teamResponse `json:"teams"`
+}
+
+func newMatchStatsResponse(stats *entity.MatchStats) matchStatsResponse {
+	return matchStatsResponse{
+		MatchID:    stats.MatchID,
+		Map:        stats.Map,
+		FinishedAt: stats.FinishedAt,
+		Score:      stats.Score,
+		Status:     stats.Result,
+		Teams:      []teamResponse{newTeamResponse(stats.Team1), newTeamResponse(stats.Team2)},
+	}
+}
+
+func newTeamResponse(team entity.TeamMatchStats) teamResponse {
+	players := make([]scoreboardPlayerResponse, 0, len(team.Players))
+	for _, p := range team.Players {
+		players = append(players, scoreboardPlayerResponse{
+			PlayerID:            p.PlayerID,
+			Nickname:            p.Nickname,
+			Kills:               p.Kills,
+			Deaths:              p.Deaths,
+			Assists:             p.Assists,
+			KDRatio:             p.KDRatio,
+			HeadshotsPercentage: p.HeadshotsPercentage,
+			ADR:                 p.ADR,
+			HLTVRating:          p.HLTVRating,
+		})
+	}
+	return teamResponse{TeamID: team.TeamID, Name: team.TeamName, Score: team.Score, Players: players}
+}
+
+type comparedPlayerResponse struct {
+	PlayerID string                 `json:"player_id"`
+	Nickname string                 `json:"nickname"`
+	Elo      int                    `json:"elo"`
+	Summary  analytics.MatchSummary `json:"summary"`
+}
+
+type comparisonResponse struct {
+	Matches  int                           `json:"matches"`
+	Players  []comparedPlayerResponse      `json:"players"`
+	Rankings map[analytics.Metric][]string `json:"rankings"` // nicknames, best first
+}
@@ -0,0 +1,209 @@
+// Package server exposes the FACEIT repository as a small JSON API so that
+// several tools can share one cached, rate-limited gateway and API key.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/armitageee/faceit-cli/internal/logger"
+	"github.com/armitageee/faceit-cli/internal/repository"
+	"github.com/armitageee/faceit-cli/internal/telemetry"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Request limits and defaults
+const (
+	requestTimeout  = 30 * time.Second
+	shutdownTimeout = 10 * time.Second
+	defaultMatches  = 20
+	maxMatches      = 100
+	maxCompared     = 10
+	defaultGameID   = "cs2"
+)
+
+// Server serves the REST API
+type Server struct {
+	repo              repository.FaceitRepository
+	telemetry         *telemetry.Telemetry
+	logger            *logger.Logger
+	comparisonMatches int
+	mux               *http.ServeMux
+}
+
+// New creates an API server backed by repo. comparisonMatches is the
+// default number of matches used by /compare; telemetry and appLogger may
+// be nil.
+func New(repo repository.FaceitRepository, telemetryInstance *telemetry.Telemetry, appLogger *logger.Logger, comparisonMatches int) *Server {
+	if comparisonMatches <= 0 {
+		comparisonMatches = defaultMatches
+	}
+	s := &Server{
+		repo:              repo,
+		telemetry:         telemetryInstance,
+		logger:            appLogger,
+		comparisonMatches: comparisonMatches,
+		mux:               http.NewServeMux(),
+	}
+	s.routes()
+	return s
+}
+
+// routes registers the API endpoints
+func (s *Server) routes() {
+	s.handle("GET /healthz", s.handleHealth)
+	s.handle("GET /players/{nickname}", s.handlePlayer)
+	s.handle("GET /players/{id}/matches", s.handlePlayerMatches)
+	s.handle("GET /players/{id}/stats", s.handlePlayerStats)
+	s.handle("GET /players/{id}/summary", s.handlePlayerSummary)
+	s.handle("GET /matches/{id}", s.handleMatch)
+	s.handle("GET /compare", s.handleCompare)
+}
+
+// Mount serves an additional handler, such as /metrics, next to the API
+func (s *Server) Mount(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+// Handler returns the HTTP handler serving the API
+func (s *Server) Handler() http.Handler {
+	return s.mux
+}
+
+// ListenAndServe serves the API on addr until ctx is cancelled, then shuts
+// down gracefully. ready, when not nil, is called with the bound address.
+func (s *Server) ListenAndServe(ctx context.Context, addr string, ready func(net.Addr)) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", addr, err)
+	}
+	if ready != nil {
+		ready(listener.Addr())
+	}
+
+	httpServer := &http.Server{
+		Handler:           s.mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- httpServer.Serve(listener)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("shutdown: %w", err)
+		}
+		return nil
+	}
+}
+
+// apiHandler handles a request and returns the value to encode as JSON
+type apiHandler func(r *http.Request) (interface{}, error)
+
+// handle registers h for pattern, wrapping it in a request span, a
+// timeout and the JSON encoding of results and errors
+func (s *Server) handle(pattern string, h apiHandler) {
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+		defer cancel()
+
+		var span trace.Span
+		if s.telemetry != nil && s.telemetry.Tracer() != nil {
+			ctx, span = s.telemetry.StartSpan(ctx, "http "+pattern, trace.WithSpanKind(trace.SpanKindServer))
+			defer span.End()
+			span.SetAttributes(
+				attribute.String("http.request.method", r.Method),
+				attribute.String("http.route", pattern),
+				attribute.String("url.path", r.URL.Path),
+			)
+		}
+
+		result, err := h(r.WithContext(ctx))
+		status := http.StatusOK
+		if err != nil {
+			status = statusForError(err)
+			result = errorResponse{Error: err.Error()}
+			if status >= http.StatusInternalServerError && s.logger != nil {
+				s.logger.Error("API request failed", map[string]interface{}{
+					"route": pattern,
+					"path":  r.URL.Path,
+					"error": err.Error(),
+				})
+			}
+		}
+
+		if span != nil {
+			span.SetAttributes(attribute.Int("http.response.status_code", status))
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			} else {
+				span.SetStatus(codes.Ok, "")
+			}
+		}
+
+		writeJSON(w, status, result)
+	})
+}
+
+// writeJSON encodes v as the response body
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// httpError is an error with a specific response status
+type httpError struct {
+	status  int
+	message string
+}
+
+func (e *httpError) Error() string {
+	return e.message
+}
+
+// badRequest reports invalid request parameters
+func badRequest(format string, args ...interface{}) error {
+	return &httpError{status: http.StatusBadRequest, message: fmt.Sprintf(format, args...)}
+}
+
+// statusForError maps handler and upstream errors to response statuses
+func statusForError(err error) int {
+	var httpErr *httpError
+	switch {
+	case errors.As(err, &httpErr):
+		return httpErr.status
+	case errors.Is(err, repository.ErrPlayerNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	}
+
+	switch repository.UpstreamStatus(err) {
+	case http.StatusNotFound, http.StatusBadRequest:
+		return http.StatusNotFound
+	case http.StatusTooManyRequests:
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusBadGateway
+	}
+}
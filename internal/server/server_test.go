@@ -0,0 +1,192 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/armitageee/faceit-cli/internal/entity"
+	"github.com/armitageee/faceit-cli/internal/repository"
+	"github.com/armitageee/faceit-cli/internal/telemetry"
+)
+
+// fakeRepository serves fixed players and matches
+type fakeRepository struct {
+	players map[string]*entity.PlayerProfile       // by lower-case nickname
+	matches map[string][]entity.PlayerMatchSummary // by player ID
+
+	mu     sync.Mutex // guards limits; compared players load concurrently
+	limits []int
+}
+
+func (f *fakeRepository) GetPlayerByNickname(ctx context.Context, nickname string) (*entity.PlayerProfile, error) {
+	if profile, ok := f.players[strings.ToLower(nickname)]; ok {
+		return profile, nil
+	}
+	return nil, fmt.Errorf("%w: %s", repository.ErrPlayerNotFound, nickname)
+}
+
+func (f *fakeRepository) GetPlayerByID(ctx context.Context, playerID string) (*entity.PlayerProfile, error) {
+	for _, profile := range f.players {
+		if profile.ID == playerID {
+			return profile, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", repository.ErrPlayerNotFound, playerID)
+}
+
+func (f *fakeRepository) GetPlayerStats(ctx context.Context, playerID, gameID string) (*entity.PlayerStats, error) {
+	return &entity.PlayerStats{PlayerID: playerID, GameID: gameID, Lifetime: map[string]interface{}{"Matches": "120"}}, nil
+}
+
+func (f *fakeRepository) GetPlayerRecentMatches(ctx context.Context, playerID string, gameID string, limit int) ([]entity.PlayerMatchSummary, error) {
+	f.mu.Lock()
+	f.limits = append(f.limits, limit)
+	f.mu.Unlock()
+	matches := f.matches[playerID]
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches, nil
+}
+
+func (f *fakeRepository) GetMatchStats(ctx context.Context, matchID string) (*entity.MatchStats, error) {
+	if matchID != "m1" {
+		return nil, fmt.Errorf("get match: upstream failure")
+	}
+	return &entity.MatchStats{
+		MatchID: "m1",
+		Map:     "de_nuke",
+		Score:   "13-9",
+		Team1:   entity.TeamMatchStats{TeamID: "faction1", TeamName: "team_alice", Score: 13, Players: []entity.PlayerMatchStats{{PlayerID: "p1", Nickname: "alice", Kills: 20}}},
+		Team2:   entity.TeamMatchStats{TeamID: "faction2", TeamName: "team_bob", Score: 9},
+	}, nil
+}
+
+func newTestServer() (*httptest.Server, *fakeRepository) {
+	repo := &fakeRepository{
+		players: map[string]*entity.PlayerProfile{
+			"alice": {ID: "p1", Nickname: "alice", Games: map[string]entity.GameDetail{"cs2": {Elo: 2100, SkillLevel: 10}}},
+			"bob":   {ID: "p2", Nickname: "bob", Games: map[string]entity.GameDetail{"cs2": {Elo: 1500, SkillLevel: 6}}},
+		},
+		matches: map[string][]entity.PlayerMatchSummary{
+			"p1": {
+				{MatchID: "m1", Map: "de_nuke", Result: "Win", Kills: 20, Deaths: 10, KDRatio: 2, Teammates: []entity.MatchParticipant{{PlayerID: "p3", Nickname: "carol"}}},
+				{MatchID: "m2", Map: "de_nuke", Result: "Loss", Kills: 10, Deaths: 10, KDRatio: 1},
+			},
+			"p2": {
+				{MatchID: "m1", Map: "de_nuke", Result: "Loss", Kills: 5, Deaths: 25, KDRatio: 0.2},
+			},
+		},
+	}
+	return httptest.NewServer(New(repo, telemetry.NewDisabled(), nil, 20).Handler()), repo
+}
+
+// get requests path and decodes the JSON response into v
+func get(t *testing.T, server *httptest.Server, path string, v interface{}) int {
+	t.Helper()
+	resp, err := http.Get(server.URL + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("%s: Content-Type = %q", path, ct)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		t.Fatalf("%s: invalid JSON: %v", path, err)
+	}
+	return resp.StatusCode
+}
+
+func TestPlayerEndpoints(t *testing.T) {
+	server, repo := newTestServer()
+	defer server.Close()
+
+	var player playerResponse
+	if status := get(t, server, "/players/Alice", &player); status != http.StatusOK || player.ID != "p1" || player.Games["cs2"].Elo != 2100 {
+		t.Errorf("GET /players/Alice = %d %+v", status, player)
+	}
+
+	var apiErr errorResponse
+	if status := get(t, server, "/players/nobody", &apiErr); status != http.StatusNotFound || !strings.Contains(apiErr.Error, "player not found") {
+		t.Errorf("GET /players/nobody = %d %+v", status, apiErr)
+	}
+
+	var matches []matchResponse
+	if status := get(t, server, "/players/p1/matches?limit=1", &matches); status != http.StatusOK || len(matches) != 1 || matches[0].Teammates[0].Nickname != "carol" {
+		t.Errorf("GET /players/p1/matches = %d %+v", status, matches)
+	}
+	repo.mu.Lock()
+	lastLimit := repo.limits[len(repo.limits)-1]
+	repo.mu.Unlock()
+	if lastLimit != 1 {
+		t.Errorf("Expected the limit to be passed on, got %d", lastLimit)
+	}
+	if status := get(t, server, "/players/p1/matches?limit=500", &apiErr); status != http.StatusBadRequest {
+		t.Errorf("Expected an out-of-range limit to be rejected, got %d", status)
+	}
+
+	var empty []matchResponse
+	if status := get(t, server, "/players/p9/matches", &empty); status != http.StatusOK || empty == nil {
+		t.Errorf("Expected an empty list for a player without matches, got %d %v", status, empty)
+	}
+
+	var stats statsResponse
+	if status := get(t, server, "/players/p1/stats", &stats); status != http.StatusOK || stats.GameID != "cs2" || stats.Lifetime["Matches"] != "120" {
+		t.Errorf("GET /players/p1/stats = %d %+v", status, stats)
+	}
+
+	var summary map[string]interface{}
+	if status := get(t, server, "/players/p1/summary", &summary); status != http.StatusOK || summary["wins"] != float64(1) || summary["kd"] != float64(1.5) {
+		t.Errorf("GET /players/p1/summary = %d %v", status, summary)
+	}
+}
+
+func TestMatchEndpoint(t *testing.T) {
+	server, _ := newTestServer()
+	defer server.Close()
+
+	var match matchStatsResponse
+	if status := get(t, server, "/matches/m1", &match); status != http.StatusOK || len(match.Teams) != 2 || match.Teams[0].Players[0].Nickname != "alice" {
+		t.Errorf("GET /matches/m1 = %d %+v", status, match)
+	}
+
+	var apiErr errorResponse
+	if status := get(t, server, "/matches/broken", &apiErr); status != http.StatusBadGateway || apiErr.Error == "" {
+		t.Errorf("GET /matches/broken = %d %+v", status, apiErr)
+	}
+}
+
+func TestCompareEndpoint(t *testing.T) {
+	server, _ := newTestServer()
+	defer server.Close()
+
+	var comparison comparisonResponse
+	status := get(t, server, "/compare?players=bob,alice,BOB&matches=5", &comparison)
+	if status != http.StatusOK || len(comparison.Players) != 2 || comparison.Matches != 5 {
+		t.Fatalf("GET /compare = %d %+v", status, comparison)
+	}
+	if comparison.Players[0].Nickname != "bob" || comparison.Players[0].Elo != 1500 {
+		t.Errorf("Expected players in request order, got %+v", comparison.Players)
+	}
+	if got := comparison.Rankings["kd"]; len(got) != 2 || got[0] != "alice" {
+		t.Errorf("Expected alice to rank first by K/D, got %v", got)
+	}
+	if got := comparison.Rankings["deaths"]; got[0] != "alice" {
+		t.Errorf("Expected fewer deaths to rank first, got %v", got)
+	}
+
+	var apiErr errorResponse
+	if status := get(t, server, "/compare?players=alice", &apiErr); status != http.StatusBadRequest {
+		t.Errorf("Expected a single player to be rejected, got %d", status)
+	}
+	if status := get(t, server, "/compare?players=alice,nobody", &apiErr); status != http.StatusNotFound {
+		t.Errorf("Expected an unknown player to give 404, got %d", status)
+	}
+}
@@ -0,0 +1,101 @@
+package store
+
+import (
+	"context"
+
+	"github.com/armitageee/faceit-cli/internal/entity"
+	"github.com/armitageee/faceit-cli/internal/repository"
+)
+
+// RecordingRepository wraps a FaceitRepository and saves every profile,
+// match summary and scoreboard it returns in the store. Match summaries
+// are returned with the ELO changes recorded for them, which the FACEIT
+// API does not report. Saving never fails a request; errors are passed to
+// onError instead.
+type RecordingRepository struct {
+	repo    repository.FaceitRepository
+	store   *Store
+	onError func(error)
+}
+
+// NewRecordingRepository creates a repository that records into store. A
+// nil onError ignores save errors.
+func NewRecordingRepository(repo repository.FaceitRepository, store *Store, onError func(error)) *RecordingRepository {
+	if onError == nil {
+		onError = func(error) {}
+	}
+	return &RecordingRepository{repo: repo, store: store, onError: onError}
+}
+
+// GetPlayerByNickname implements FaceitRepository and records the player
+func (r *RecordingRepository) GetPlayerByNickname(ctx context.Context, nickname string) (*entity.PlayerProfile, error) {
+	profile, err := r.repo.GetPlayerByNickname(ctx, nickname)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.store.SavePlayer(ctx, profile); err != nil {
+		r.onError(err)
+	}
+	return profile, nil
+}
+
+// GetPlayerByID implements FaceitRepository and records the player
+func (r *RecordingRepository) GetPlayerByID(ctx context.Context, playerID string) (*entity.PlayerProfile, error) {
+	profile, err := r.repo.GetPlayerByID(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.store.SavePlayer(ctx, profile); err != nil {
+		r.onError(err)
+	}
+	return profile, nil
+}
+
+// GetPlayerStats implements FaceitRepository. Lifetime stats change with
+// every match and are not recorded.
+func (r *RecordingRepository) GetPlayerStats(ctx context.Context, playerID, gameID string) (*entity.PlayerStats, error) {
+	return r.repo.GetPlayerStats(ctx, playerID, gameID)
+}
+
+// GetPlayerRecentMatches implements FaceitRepository and records the
+// matches
+func (r *RecordingRepository) GetPlayerRecentMatches(ctx context.Context, playerID string, gameID string, limit int) ([]entity.PlayerMatchSummary, error) {
+	matches, err := r.repo.GetPlayerRecentMatches(ctx, playerID, gameID, limit)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.store.SaveMatches(ctx, playerID, gameID, matches); err != nil {
+		r.onError(err)
+	}
+	changes, err := r.store.EloChanges(ctx, playerID, gameID)
+	if err != nil {
+		r.onError(err)
+		return matches, nil
+	}
+	for i := range matches {
+		if matches[i].EloChange == 0 {
+			matches[i].EloChange = changes[matches[i].MatchID]
+		}
+	}
+	return matches, nil
+}
+
+// RecordEloChange saves a match with the ELO change a watcher worked out
+// for it. It has the signature of watch.Recorder.
+func (r *RecordingRepository) RecordEloChange(ctx context.Context, playerID, gameID string, match entity.PlayerMatchSummary) {
+	if _, err := r.store.SaveMatches(ctx, playerID, gameID, []entity.PlayerMatchSummary{match}); err != nil {
+		r.onError(err)
+	}
+}
+
+// GetMatchStats implements FaceitRepository and records the scoreboard
+func (r *RecordingRepository) GetMatchStats(ctx context.Context, matchID string) (*entity.MatchStats, error) {
+	stats, err := r.repo.GetMatchStats(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.store.SaveMatchStats(ctx, stats); err != nil {
+		r.onError(err)
+	}
+	return stats, nil
+}
@@ -0,0 +1,379 @@
+// Package store keeps a local SQLite database of every match summary and
+// scoreboard fetched from FACEIT, so analytics can reach beyond the
+// matches loaded in one session.
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/armitageee/faceit-cli/internal/entity"
+
+	_ "modernc.org/sqlite" // pure-Go driver registered as "sqlite"
+)
+
+// ErrNotFound is returned when the requested record is not stored
+var ErrNotFound = errors.New("not found in history database")
+
+// migrations are applied in order; the database's user_version records
+// how many have run. Append new statements, never edit old ones.
+var migrations = []string{
+	`CREATE TABLE players (
+		id         TEXT PRIMARY KEY,
+		nickname   TEXT NOT NULL,
+		updated_at INTEGER NOT NULL
+	);
+	CREATE INDEX players_nickname ON players (nickname COLLATE NOCASE);
+
+	CREATE TABLE player_matches (
+		player_id     TEXT NOT NULL,
+		match_id      TEXT NOT NULL,
+		game_id       TEXT NOT NULL,
+		map           TEXT NOT NULL,
+		started_at    INTEGER NOT NULL,
+		finished_at   INTEGER NOT NULL,
+		score         TEXT NOT NULL,
+		result        TEXT NOT NULL,
+		kills         INTEGER NOT NULL,
+		deaths        INTEGER NOT NULL,
+		assists       INTEGER NOT NULL,
+		kd_ratio      REAL NOT NULL,
+		headshots_pct REAL NOT NULL,
+		adr           REAL NOT NULL,
+		team_id       TEXT NOT NULL,
+		elo_change    INTEGER NOT NULL,
+		teammates     TEXT NOT NULL,
+		opponents     TEXT NOT NULL,
+		PRIMARY KEY (player_id, match_id)
+	);
+	CREATE INDEX player_matches_finished ON player_matches (player_id, game_id, finished_at DESC);
+
+	CREATE TABLE match_stats (
+		match_id    TEXT PRIMARY KEY,
+		map         TEXT NOT NULL,
+		finished_at INTEGER NOT NULL,
+		data        TEXT NOT NULL,
+		fetched_at  INTEGER NOT NULL
+	);`,
+}
+
+// Store is a SQLite database of players, match summaries and match
+// scoreboards. It is safe for concurrent use.
+type Store struct {
+	db  *sql.DB
+	now func() time.Time
+}
+
+// Open opens or creates the database at path, creating its directory and
+// bringing the schema up to date
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("create history directory: %w", err)
+	}
+	db, err := sql.Open("sqlite", path+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, fmt.Errorf("open history database: %w", err)
+	}
+	// SQLite allows a single writer; one connection avoids lock errors
+	// between the UI's background loaders
+	db.SetMaxOpenConns(1)
+
+	s := &Store{db: db, now: time.Now}
+	if err := s.migrate(context.Background()); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Close closes the database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// migrate applies the migrations the database has not seen yet
+func (s *Store) migrate(ctx context.Context) error {
+	var version int
+	if err := s.db.QueryRowContext(ctx, "PRAGMA user_version").Scan(&version); err != nil {
+		return fmt.Errorf("read schema version: %w", err)
+	}
+	for i := version; i < len(migrations); i++ {
+		tx, err := s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("migrate history database: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, migrations[i]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migrate history database to version %d: %w", i+1, err)
+		}
+		// PRAGMA does not accept placeholders
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("PRAGMA user_version = %d", i+1)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migrate history database to version %d: %w", i+1, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("migrate history database to version %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// SavePlayer records a player's current nickname
+func (s *Store) SavePlayer(ctx context.Context, profile *entity.PlayerProfile) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO players (id, nickname, updated_at) VALUES (?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET nickname = excluded.nickname, updated_at = excluded.updated_at`,
+		profile.ID, profile.Nickname, s.now().Unix())
+	if err != nil {
+		return fmt.Errorf("save player %s: %w", profile.Nickname, err)
+	}
+	return nil
+}
+
+// PlayerID returns the ID of the player last seen with the nickname,
+// ignoring case
+func (s *Store) PlayerID(ctx context.Context, nickname string) (string, error) {
+	var id string
+	err := s.db.QueryRowContext(ctx, `
+		SELECT id FROM players WHERE nickname = ? COLLATE NOCASE
+		ORDER BY updated_at DESC LIMIT 1`, nickname).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("player %s: %w", nickname, ErrNotFound)
+	}
+	if err != nil {
+		return "", fmt.Errorf("look up player %s: %w", nickname, err)
+	}
+	return id, nil
+}
+
+// SaveMatches stores a player's match summaries and returns how many were
+// new. Known matches are updated, except that a known ELO change is not
+// overwritten by an unknown one.
+func (s *Store) SaveMatches(ctx context.Context, playerID, gameID string, matches []entity.PlayerMatchSummary) (int, error) {
+	if len(matches) == 0 {
+		return 0, nil
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("save matches: %w", err)
+	}
+	defer tx.Rollback()
+
+	exists, err := tx.PrepareContext(ctx, `SELECT 1 FROM player_matches WHERE player_id = ? AND match_id = ?`)
+	if err != nil {
+		return 0, fmt.Errorf("save matches: %w", err)
+	}
+	defer exists.Close()
+	upsert, err := tx.PrepareContext(ctx, `
+		INSERT INTO player_matches (
+			player_id, match_id, game_id, map, started_at, finished_at, score, result,
+			kills, deaths, assists, kd_ratio, headshots_pct, adr, team_id, elo_change,
+			teammates, opponents
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (player_id, match_id) DO UPDATE SET
+			map = excluded.map, started_at = excluded.started_at, finished_at = excluded.finished_at,
+			score = excluded.score, result = excluded.result, kills = excluded.kills,
+			deaths = excluded.deaths, assists = excluded.assists, kd_ratio = excluded.kd_ratio,
+			headshots_pct = excluded.headshots_pct, adr = excluded.adr, team_id = excluded.team_id,
+			elo_change = CASE WHEN excluded.elo_change != 0 THEN excluded.elo_change ELSE player_matches.elo_change END,
+			teammates = excluded.teammates, opponents = excluded.opponents`)
+	if err != nil {
+		return 0, fmt.Errorf("save matches: %w", err)
+	}
+	defer upsert.Close()
+
+	added := 0
+	for _, match := range matches {
+		if match.MatchID == "" {
+			continue
+		}
+		var found int
+		err := exists.QueryRowContext(ctx, playerID, match.MatchID).Scan(&found)
+		if errors.Is(err, sql.ErrNoRows) {
+			added++
+		} else if err != nil {
+			return 0, fmt.Errorf("save match %s: %w", match.MatchID, err)
+		}
+
+		teammates, err := json.Marshal(participantsOrEmpty(match.Teammates))
+		if err != nil {
+			return 0, fmt.Errorf("save match %s: %w", match.MatchID, err)
+		}
+		opponents, err := json.Marshal(participantsOrEmpty(match.Opponents))
+		if err != nil {
+			return 0, fmt.Errorf("save match %s: %w", match.MatchID, err)
+		}
+		_, err = upsert.ExecContext(ctx,
+			playerID, match.MatchID, gameID, match.Map, match.StartedAt, match.FinishedAt,
+			match.Score, match.Result, match.Kills, match.Deaths, match.Assists, match.KDRatio,
+			match.HeadshotsPercentage, match.ADR, match.TeamID, match.EloChange,
+			string(teammates), string(opponents))
+		if err != nil {
+			return 0, fmt.Errorf("save match %s: %w", match.MatchID, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("save matches: %w", err)
+	}
+	return added, nil
+}
+
+// participantsOrEmpty makes nil participant lists encode as []
+func participantsOrEmpty(participants []entity.MatchParticipant) []entity.MatchParticipant {
+	if participants == nil {
+		return []entity.MatchParticipant{}
+	}
+	return participants
+}
+
+// Matches returns a player's stored matches for a game, newest first. A
+// limit of zero or less returns all of them.
+func (s *Store) Matches(ctx context.Context, playerID, gameID string, limit int) ([]entity.PlayerMatchSummary, error) {
+	query := `
+		SELECT match_id, map, started_at, finished_at, score, result, kills, deaths, assists,
+			kd_ratio, headshots_pct, adr, team_id, elo_change, teammates, opponents
+		FROM player_matches
+		WHERE player_id = ? AND game_id = ?
+		ORDER BY finished_at DESC, match_id`
+	args := []interface{}{playerID, gameID}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("load matches: %w", err)
+	}
+	defer rows.Close()
+
+	var matches []entity.PlayerMatchSummary
+	for rows.Next() {
+		var match entity.PlayerMatchSummary
+		var teammates, opponents string
+		err := rows.Scan(&match.MatchID, &match.Map, &match.StartedAt, &match.FinishedAt,
+			&match.Score, &match.Result, &match.Kills, &match.Deaths, &match.Assists,
+			&match.KDRatio, &match.HeadshotsPercentage, &match.ADR, &match.TeamID,
+			&match.EloChange, &teammates, &opponents)
+		if err != nil {
+			return nil, fmt.Errorf("load matches: %w", err)
+		}
+		if err := json.Unmarshal([]byte(teammates), &match.Teammates); err != nil {
+			return nil, fmt.Errorf("load match %s: %w", match.MatchID, err)
+		}
+		if err := json.Unmarshal([]byte(opponents), &match.Opponents); err != nil {
+			return nil, fmt.Errorf("load match %s: %w", match.MatchID, err)
+		}
+		matches = append(matches, match)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("load matches: %w", err)
+	}
+	return matches, nil
+}
+
+// EloChanges returns the known ELO changes of a player's stored matches
+// for a game by match ID
+func (s *Store) EloChanges(ctx context.Context, playerID, gameID string) (map[string]int, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT match_id, elo_change FROM player_matches
+		WHERE player_id = ? AND game_id = ? AND elo_change != 0`, playerID, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("load ELO changes: %w", err)
+	}
+	defer rows.Close()
+
+	changes := make(map[string]int)
+	for rows.Next() {
+		var matchID string
+		var change int
+		if err := rows.Scan(&matchID, &change); err != nil {
+			return nil, fmt.Errorf("load ELO changes: %w", err)
+		}
+		changes[matchID] = change
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("load ELO changes: %w", err)
+	}
+	return changes, nil
+}
+
+// MatchCount returns how many matches of a player are stored for a game
+func (s *Store) MatchCount(ctx context.Context, playerID, gameID string) (int, error) {
+	var count int
+	err := s.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM player_matches WHERE player_id = ? AND game_id = ?`,
+		playerID, gameID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("count matches: %w", err)
+	}
+	return count, nil
+}
+
+// MatchesWithoutStats returns the IDs of a player's stored matches whose
+// scoreboard is not stored yet, newest first
+func (s *Store) MatchesWithoutStats(ctx context.Context, playerID, gameID string) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT pm.match_id FROM player_matches pm
+		LEFT JOIN match_stats ms ON ms.match_id = pm.match_id
+		WHERE pm.player_id = ? AND pm.game_id = ? AND ms.match_id IS NULL
+		ORDER BY pm.finished_at DESC`, playerID, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("find matches without stats: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("find matches without stats: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// SaveMatchStats stores a match scoreboard, replacing any earlier copy
+func (s *Store) SaveMatchStats(ctx context.Context, stats *entity.MatchStats) error {
+	if stats == nil || stats.MatchID == "" {
+		return nil
+	}
+	data, err := json.Marshal(stats)
+	if err != nil {
+		return fmt.Errorf("save match stats %s: %w", stats.MatchID, err)
+	}
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO match_stats (match_id, map, finished_at, data, fetched_at) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (match_id) DO UPDATE SET
+			map = excluded.map, finished_at = excluded.finished_at,
+			data = excluded.data, fetched_at = excluded.fetched_at`,
+		stats.MatchID, stats.Map, stats.FinishedAt, string(data), s.now().Unix())
+	if err != nil {
+		return fmt.Errorf("save match stats %s: %w", stats.MatchID, err)
+	}
+	return nil
+}
+
+// MatchStats returns a stored match scoreboard
+func (s *Store) MatchStats(ctx context.Context, matchID string) (*entity.MatchStats, error) {
+	var data string
+	err := s.db.QueryRowContext(ctx, `SELECT data FROM match_stats WHERE match_id = ?`, matchID).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("match %s: %w", matchID, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load match stats %s: %w", matchID, err)
+	}
+	var stats entity.MatchStats
+	if err := json.Unmarshal([]byte(data), &stats); err != nil {
+		return nil, fmt.Errorf("load match stats %s: %w", matchID, err)
+	}
+	return &stats, nil
+}
@@ -0,0 +1,243 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/armitageee/faceit-cli/internal/entity"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+	s, err := Open(filepath.Join(t.TempDir(), "nested", "history.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestOpenIsIdempotent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.db")
+	for i := 0; i < 2; i++ {
+		s, err := Open(path)
+		if err != nil {
+			t.Fatalf("Open #%d: %v", i+1, err)
+		}
+		var version int
+		if err := s.db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+			t.Fatal(err)
+		}
+		if version != len(migrations) {
+			t.Errorf("Expected schema version %d, got %d", len(migrations), version)
+		}
+		s.Close()
+	}
+}
+
+func TestSaveMatchesRoundTrip(t *testing.T) {
+	s := openTestStore(t)
+	ctx := context.Background()
+
+	older := entity.PlayerMatchSummary{
+		MatchID: "m1", Map: "de_nuke", FinishedAt: 100, Score: "13-9", Result: "Win",
+		Kills: 20, Deaths: 10, Assists: 3, KDRatio: 2, HeadshotsPercentage: 50, ADR: 90.5,
+		TeamID: "faction1", EloChange: 25,
+		Teammates: []entity.MatchParticipant{{PlayerID: "t1", Nickname: "mate"}},
+		Opponents: []entity.MatchParticipant{{PlayerID: "o1", Nickname: "rival"}},
+	}
+	newer := entity.PlayerMatchSummary{MatchID: "m2", Map: "de_mirage", FinishedAt: 200, Result: "Loss", Kills: 5, Deaths: 15}
+
+	added, err := s.SaveMatches(ctx, "p1", "cs2", []entity.PlayerMatchSummary{older})
+	if err != nil || added != 1 {
+		t.Fatalf("Expected 1 new match, got %d (%v)", added, err)
+	}
+
+	// Re-fetched without the ELO change, alongside a new match
+	refetched := older
+	refetched.EloChange = 0
+	added, err = s.SaveMatches(ctx, "p1", "cs2", []entity.PlayerMatchSummary{newer, refetched})
+	if err != nil || added != 1 {
+		t.Fatalf("Expected 1 new match, got %d (%v)", added, err)
+	}
+
+	matches, err := s.Matches(ctx, "p1", "cs2", 0)
+	if err != nil {
+		t.Fatalf("Matches: %v", err)
+	}
+	if len(matches) != 2 || matches[0].MatchID != "m2" {
+		t.Fatalf("Expected m2 then m1, got %+v", matches)
+	}
+	if !reflect.DeepEqual(matches[1], older) {
+		t.Errorf("Stored match differs:\n got %+v\nwant %+v", matches[1], older)
+	}
+	if matches[0].Teammates == nil || len(matches[0].Teammates) != 0 {
+		t.Errorf("Expected an empty teammate list, got %#v", matches[0].Teammates)
+	}
+
+	limited, err := s.Matches(ctx, "p1", "cs2", 1)
+	if err != nil || len(limited) != 1 {
+		t.Errorf("Expected 1 match with a limit, got %d (%v)", len(limited), err)
+	}
+	if count, _ := s.MatchCount(ctx, "p1", "cs2"); count != 2 {
+		t.Errorf("Expected 2 stored matches, got %d", count)
+	}
+	if count, _ := s.MatchCount(ctx, "p2", "cs2"); count != 0 {
+		t.Errorf("Expected no matches for another player, got %d", count)
+	}
+}
+
+func TestPlayerID(t *testing.T) {
+	s := openTestStore(t)
+	ctx := context.Background()
+
+	if _, err := s.PlayerID(ctx, "alice"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	if err := s.SavePlayer(ctx, &entity.PlayerProfile{ID: "p1", Nickname: "Alice"}); err != nil {
+		t.Fatal(err)
+	}
+	id, err := s.PlayerID(ctx, "alice")
+	if err != nil || id != "p1" {
+		t.Errorf("Expected p1, got %q (%v)", id, err)
+	}
+}
+
+func TestMatchStats(t *testing.T) {
+	s := openTestStore(t)
+	ctx := context.Background()
+
+	if _, err := s.MatchStats(ctx, "m1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	if _, err := s.SaveMatches(ctx, "p1", "cs2", []entity.PlayerMatchSummary{
+		{MatchID: "m1", FinishedAt: 100}, {MatchID: "m2", FinishedAt: 200},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	stats := &entity.MatchStats{
+		MatchID: "m1", Map: "de_nuke", FinishedAt: 100,
+		Team1:       entity.TeamMatchStats{TeamID: "faction1", Score: 13},
+		PlayerStats: []entity.PlayerMatchStats{{PlayerID: "p1", Nickname: "alice", Kills: 20, ADR: 88.1}},
+	}
+	if err := s.SaveMatchStats(ctx, stats); err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.MatchStats(ctx, "m1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, stats) {
+		t.Errorf("Stored stats differ:\n got %+v\nwant %+v", got, stats)
+	}
+
+	missing, err := s.MatchesWithoutStats(ctx, "p1", "cs2")
+	if err != nil || !reflect.DeepEqual(missing, []string{"m2"}) {
+		t.Errorf("Expected [m2] without stats, got %v (%v)", missing, err)
+	}
+}
+
+// fakeRepository returns fixed data, or err when set
+type fakeRepository struct {
+	err error
+}
+
+func (f *fakeRepository) GetPlayerByNickname(ctx context.Context, nickname string) (*entity.PlayerProfile, error) {
+	return &entity.PlayerProfile{ID: "id-" + nickname, Nickname: nickname}, f.err
+}
+
+func (f *fakeRepository) GetPlayerByID(ctx context.Context, playerID string) (*entity.PlayerProfile, error) {
+	return &entity.PlayerProfile{ID: playerID, Nickname: "nick-" + playerID}, f.err
+}
+
+func (f *fakeRepository) GetPlayerStats(ctx context.Context, playerID, gameID string) (*entity.PlayerStats, error) {
+	return &entity.PlayerStats{PlayerID: playerID}, f.err
+}
+
+func (f *fakeRepository) GetPlayerRecentMatches(ctx context.Context, playerID string, gameID string, limit int) ([]entity.PlayerMatchSummary, error) {
+	return []entity.PlayerMatchSummary{{MatchID: "m1", FinishedAt: 100}}, f.err
+}
+
+func (f *fakeRepository) GetMatchStats(ctx context.Context, matchID string) (*entity.MatchStats, error) {
+	return &entity.MatchStats{MatchID: matchID}, f.err
+}
+
+func TestRecordingRepository(t *testing.T) {
+	s := openTestStore(t)
+	ctx := context.Background()
+	var saveErrs []error
+	repo := NewRecordingRepository(&fakeRepository{}, s, func(err error) { saveErrs = append(saveErrs, err) })
+
+	if _, err := repo.GetPlayerByNickname(ctx, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.GetPlayerByID(ctx, "id-bob"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.GetPlayerRecentMatches(ctx, "id-alice", "cs2", 10); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.GetMatchStats(ctx, "m1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if id, err := s.PlayerID(ctx, "alice"); err != nil || id != "id-alice" {
+		t.Errorf("Expected the player to be recorded, got %q (%v)", id, err)
+	}
+	if id, err := s.PlayerID(ctx, "nick-id-bob"); err != nil || id != "id-bob" {
+		t.Errorf("Expected the player looked up by ID to be recorded, got %q (%v)", id, err)
+	}
+	if count, _ := s.MatchCount(ctx, "id-alice", "cs2"); count != 1 {
+		t.Errorf("Expected 1 recorded match, got %d", count)
+	}
+	if _, err := s.MatchStats(ctx, "m1"); err != nil {
+		t.Errorf("Expected the scoreboard to be recorded: %v", err)
+	}
+
+	// Save errors are reported without failing the request
+	s.Close()
+	if _, err := repo.GetMatchStats(ctx, "m2"); err != nil {
+		t.Errorf("Expected the request to succeed, got %v", err)
+	}
+	if len(saveErrs) != 1 || !strings.Contains(saveErrs[0].Error(), "m2") {
+		t.Errorf("Expected one save error for m2, got %v", saveErrs)
+	}
+}
+
+func TestRecordingRepositoryEloChanges(t *testing.T) {
+	s := openTestStore(t)
+	ctx := context.Background()
+	repo := NewRecordingRepository(&fakeRepository{}, s, func(err error) { t.Error(err) })
+
+	matches, err := repo.GetPlayerRecentMatches(ctx, "id-alice", "cs2", 10)
+	if err != nil || matches[0].EloChange != 0 {
+		t.Fatalf("Expected an unknown ELO change, got %+v (%v)", matches, err)
+	}
+
+	// A change recorded by the watcher is merged into later fetches
+	repo.RecordEloChange(ctx, "id-alice", "cs2", entity.PlayerMatchSummary{MatchID: "m1", FinishedAt: 100, EloChange: -18})
+	matches, err = repo.GetPlayerRecentMatches(ctx, "id-alice", "cs2", 10)
+	if err != nil || matches[0].EloChange != -18 {
+		t.Errorf("Expected the recorded ELO change -18, got %+v (%v)", matches, err)
+	}
+	if changes, _ := s.EloChanges(ctx, "id-alice", "cs2"); changes["m1"] != -18 {
+		t.Errorf("Expected the change to stay stored, got %v", changes)
+	}
+}
+
+func TestRecordingRepositoryPassesErrors(t *testing.T) {
+	s := openTestStore(t)
+	repo := NewRecordingRepository(&fakeRepository{err: errors.New("upstream down")}, s, nil)
+
+	if _, err := repo.GetPlayerRecentMatches(context.Background(), "p1", "cs2", 10); err == nil {
+		t.Fatal("Expected the upstream error")
+	}
+	if count, _ := s.MatchCount(context.Background(), "p1", "cs2"); count != 0 {
+		t.Errorf("Expected nothing recorded after an error, got %d", count)
+	}
+}
@@ -0,0 +1,209 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// otlpMetricsInterval is how often metrics are pushed over OTLP
+const otlpMetricsInterval = 30 * time.Second
+
+// durationBuckets are the histogram boundaries, in seconds, for request
+// latencies and load durations. Background loads of a full match history
+// can take minutes.
+var durationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120}
+
+// Metrics records application metrics. All methods are safe to call on a
+// nil *Metrics, which records nothing, so callers need not check whether
+// metrics are enabled.
+type Metrics struct {
+	upstreamRequests       metric.Int64Counter
+	upstreamDuration       metric.Float64Histogram
+	cacheHits              metric.Int64Counter
+	cacheMisses            metric.Int64Counter
+	cacheEvictions         metric.Int64Counter
+	rateLimitWaits         metric.Int64Counter
+	rateLimitWaitDuration  metric.Float64Histogram
+	backgroundLoadDuration metric.Float64Histogram
+}
+
+// newMetrics creates the instruments on meter
+func newMetrics(meter metric.Meter) (*Metrics, error) {
+	m := &Metrics{}
+	var err error
+	if m.upstreamRequests, err = meter.Int64Counter("faceit.api.requests",
+		metric.WithDescription("FACEIT API requests by endpoint and response status")); err != nil {
+		return nil, err
+	}
+	if m.upstreamDuration, err = meter.Float64Histogram("faceit.api.request.duration",
+		metric.WithDescription("FACEIT API request latency by endpoint and response status"),
+		metric.WithUnit("s"), metric.WithExplicitBucketBoundaries(durationBuckets...)); err != nil {
+		return nil, err
+	}
+	if m.cacheHits, err = meter.Int64Counter("faceit.cache.hits",
+		metric.WithDescription("Repository cache hits by kind of data")); err != nil {
+		return nil, err
+	}
+	if m.cacheMisses, err = meter.Int64Counter("faceit.cache.misses",
+		metric.WithDescription("Repository cache misses by kind of data")); err != nil {
+		return nil, err
+	}
+	if m.cacheEvictions, err = meter.Int64Counter("faceit.cache.evictions",
+		metric.WithDescription("Expired repository cache entries removed by kind of data")); err != nil {
+		return nil, err
+	}
+	if m.rateLimitWaits, err = meter.Int64Counter("faceit.ratelimit.waits",
+		metric.WithDescription("FACEIT API requests delayed by the rate limiter")); err != nil {
+		return nil, err
+	}
+	if m.rateLimitWaitDuration, err = meter.Float64Histogram("faceit.ratelimit.wait.duration",
+		metric.WithDescription("Time FACEIT API requests spent waiting for the rate limiter"),
+		metric.WithUnit("s"), metric.WithExplicitBucketBoundaries(durationBuckets...)); err != nil {
+		return nil, err
+	}
+	if m.backgroundLoadDuration, err = meter.Float64Histogram("faceit.background_load.duration",
+		metric.WithDescription("Duration of background match history loads"),
+		metric.WithUnit("s"), metric.WithExplicitBucketBoundaries(durationBuckets...)); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// RecordUpstreamRequest records a FACEIT API request. status is the HTTP
+// status code, or 0 when no response was received.
+func (m *Metrics) RecordUpstreamRequest(ctx context.Context, endpoint string, status int, duration time.Duration) {
+	if m == nil {
+		return
+	}
+	statusLabel := "error"
+	if status > 0 {
+		statusLabel = strconv.Itoa(status)
+	}
+	attrs := metric.WithAttributes(
+		attribute.String("endpoint", endpoint),
+		attribute.String("status", statusLabel),
+	)
+	m.upstreamRequests.Add(ctx, 1, attrs)
+	m.upstreamDuration.Record(ctx, duration.Seconds(), attrs)
+}
+
+// RecordCacheHit records a cache hit for a kind of data such as "profile"
+func (m *Metrics) RecordCacheHit(ctx context.Context, kind string) {
+	if m == nil {
+		return
+	}
+	m.cacheHits.Add(ctx, 1, metric.WithAttributes(attribute.String("kind", kind)))
+}
+
+// RecordCacheMiss records a cache miss for a kind of data
+func (m *Metrics) RecordCacheMiss(ctx context.Context, kind string) {
+	if m == nil {
+		return
+	}
+	m.cacheMisses.Add(ctx, 1, metric.WithAttributes(attribute.String("kind", kind)))
+}
+
+// RecordCacheEvictions records expired cache entries being removed
+func (m *Metrics) RecordCacheEvictions(ctx context.Context, kind string, count int) {
+	if m == nil || count <= 0 {
+		return
+	}
+	m.cacheEvictions.Add(ctx, int64(count), metric.WithAttributes(attribute.String("kind", kind)))
+}
+
+// RecordRateLimitWait records an API request that was delayed by the rate limit
+func (m *Metrics) RecordRateLimitWait(ctx context.Context, duration time.Duration) {
+	if m == nil {
+		return
+	}
+	m.rateLimitWaits.Add(ctx, 1)
+	m.rateLimitWaitDuration.Record(ctx, duration.Seconds())
+}
+
+// RecordBackgroundLoad records the duration of a background load. err is
+// the load's error, if any.
+func (m *Metrics) RecordBackgroundLoad(ctx context.Context, operation string, duration time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	m.backgroundLoadDuration.Record(ctx, duration.Seconds(), metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("result", result),
+	))
+}
+
+// initMetrics sets up the meter provider with a Prometheus reader and,
+// when an endpoint is configured, an OTLP exporter
+func (t *Telemetry) initMetrics(ctx context.Context, cfg Config, res *resource.Resource) error {
+	registry := prometheus.NewRegistry()
+	promExporter, err := otelprometheus.New(otelprometheus.WithRegisterer(registry))
+	if err != nil {
+		return fmt.Errorf("failed to create Prometheus exporter: %w", err)
+	}
+	options := []sdkmetric.Option{
+		sdkmetric.WithResource(res),
+		sdkmetric.WithReader(promExporter),
+	}
+
+	if cfg.OTLPMetricsEndpoint != "" {
+		endpoint := strings.TrimPrefix(cfg.OTLPMetricsEndpoint, "http://")
+		endpoint = strings.TrimPrefix(endpoint, "https://")
+		otlpExporter, err := otlpmetricgrpc.New(ctx,
+			otlpmetricgrpc.WithEndpoint(endpoint),
+			otlpmetricgrpc.WithInsecure(), // For development
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create OTLP metrics exporter: %w", err)
+		}
+		options = append(options, sdkmetric.WithReader(
+			sdkmetric.NewPeriodicReader(otlpExporter, sdkmetric.WithInterval(otlpMetricsInterval)),
+		))
+	}
+
+	provider := sdkmetric.NewMeterProvider(options...)
+	metrics, err := newMetrics(provider.Meter("faceit-cli"))
+	if err != nil {
+		provider.Shutdown(ctx)
+		return fmt.Errorf("failed to create instruments: %w", err)
+	}
+
+	t.meterProvider = provider
+	t.metrics = metrics
+	t.metricsHandler = promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+	return nil
+}
+
+// Metrics returns the application's instruments, or nil when metrics are
+// disabled
+func (t *Telemetry) Metrics() *Metrics {
+	if t == nil {
+		return nil
+	}
+	return t.metrics
+}
+
+// MetricsHandler returns the Prometheus /metrics handler, or nil when
+// metrics are disabled
+func (t *Telemetry) MetricsHandler() http.Handler {
+	if t == nil || t.metricsHandler == nil {
+		return nil
+	}
+	return t.metricsHandler
+}
@@ -0,0 +1,80 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetrics_Prometheus(t *testing.T) {
+	telemetry, err := New(context.Background(), Config{
+		ServiceName:    "test-service",
+		ServiceVersion: "1.0.0",
+		Environment:    "test",
+		MetricsEnabled: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer telemetry.Shutdown(context.Background())
+
+	metrics := telemetry.Metrics()
+	if metrics == nil || telemetry.MetricsHandler() == nil {
+		t.Fatal("Expected metrics to be enabled")
+	}
+
+	ctx := context.Background()
+	metrics.RecordUpstreamRequest(ctx, "get_player", 200, 120*time.Millisecond)
+	metrics.RecordUpstreamRequest(ctx, "get_player", 0, time.Second)
+	metrics.RecordCacheHit(ctx, "profile")
+	metrics.RecordCacheMiss(ctx, "matches")
+	metrics.RecordCacheEvictions(ctx, "stats", 3)
+	metrics.RecordRateLimitWait(ctx, 50*time.Millisecond)
+	metrics.RecordBackgroundLoad(ctx, "matches", 2*time.Second, errors.New("timeout"))
+
+	recorder := httptest.NewRecorder()
+	telemetry.MetricsHandler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(recorder.Body)
+	output := string(body)
+
+	for _, want := range []string{
+		`faceit_api_requests_total{endpoint="get_player",`,
+		`status="200"`,
+		`status="error"`,
+		`faceit_api_request_duration_seconds_bucket{endpoint="get_player"`,
+		`faceit_cache_hits_total{kind="profile"`,
+		`faceit_cache_misses_total{kind="matches"`,
+		`faceit_cache_evictions_total{kind="stats"`,
+		`faceit_ratelimit_waits_total`,
+		`faceit_background_load_duration_seconds_count{operation="matches"`,
+		`result="error"`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in the exposition", want)
+		}
+	}
+}
+
+func TestMetrics_Disabled(t *testing.T) {
+	telemetry, err := New(context.Background(), Config{ServiceName: "test-service"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if telemetry.Metrics() != nil || telemetry.MetricsHandler() != nil {
+		t.Error("Expected metrics to be disabled")
+	}
+
+	// Recording on disabled metrics is a no-op
+	var metrics *Metrics
+	metrics.RecordUpstreamRequest(context.Background(), "get_player", 200, time.Second)
+	metrics.RecordCacheHit(context.Background(), "profile")
+
+	var missing *Telemetry
+	if missing.Metrics() != nil || missing.MetricsHandler() != nil {
+		t.Error("Expected a nil telemetry to report no metrics")
+	}
+}
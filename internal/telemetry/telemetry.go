@@ -2,13 +2,16 @@ package telemetry
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
@@ -23,13 +26,19 @@ type Config struct {
 	Environment    string
 	OTLPEndpoint   string
 	Enabled        bool
+	// Metrics configuration, independent of tracing
+	MetricsEnabled      bool
+	OTLPMetricsEndpoint string // push metrics over OTLP gRPC when set
 }
 
-// Telemetry manages OpenTelemetry tracing
+// Telemetry manages OpenTelemetry tracing and metrics
 type Telemetry struct {
 	tracerProvider *sdktrace.TracerProvider
 	tracer         trace.Tracer
 	shutdown       func(context.Context) error
+	meterProvider  *sdkmetric.MeterProvider
+	metrics        *Metrics
+	metricsHandler http.Handler
 }
 
 // NewDisabled creates a disabled telemetry instance for testing
@@ -41,7 +50,7 @@ func NewDisabled() *Telemetry {
 
 // New creates a new telemetry instance
 func New(ctx context.Context, cfg Config) (*Telemetry, error) {
-	if !cfg.Enabled {
+	if !cfg.Enabled && !cfg.MetricsEnabled {
 		// Return a no-op telemetry instance
 		return &Telemetry{
 			tracer: noop.NewTracerProvider().Tracer("faceit-cli"),
@@ -62,6 +71,18 @@ func New(ctx context.Context, cfg Config) (*Telemetry, error) {
 		return nil, fmt.Errorf("failed to create resource: %w", err)
 	}
 
+	t := &Telemetry{
+		tracer: noop.NewTracerProvider().Tracer("faceit-cli"),
+	}
+	if cfg.MetricsEnabled {
+		if err := t.initMetrics(ctx, cfg, res); err != nil {
+			return nil, err
+		}
+	}
+	if !cfg.Enabled {
+		return t, nil
+	}
+
 	// Create exporters
 	var exporters []sdktrace.SpanExporter
 
@@ -87,6 +108,7 @@ func New(ctx context.Context, cfg Config) (*Telemetry, error) {
 	// Direct Zipkin export is removed to use proper OTLP → Collector → Zipkin flow
 
 	if len(exporters) == 0 {
+		t.Shutdown(ctx)
 		return nil, fmt.Errorf("no exporters configured")
 	}
 
@@ -111,11 +133,10 @@ func New(ctx context.Context, cfg Config) (*Telemetry, error) {
 		propagation.Baggage{},
 	))
 
-	return &Telemetry{
-		tracerProvider: tp,
-		tracer:         tp.Tracer("faceit-cli"),
-		shutdown:       tp.Shutdown,
-	}, nil
+	t.tracerProvider = tp
+	t.tracer = tp.Tracer("faceit-cli")
+	t.shutdown = tp.Shutdown
+	return t, nil
 }
 
 // Tracer returns the tracer instance
@@ -123,14 +144,20 @@ func (t *Telemetry) Tracer() trace.Tracer {
 	return t.tracer
 }
 
-// Shutdown gracefully shuts down the telemetry
+// Shutdown gracefully shuts down the telemetry, flushing pending spans
+// and metrics
 func (t *Telemetry) Shutdown(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var errs []error
 	if t.shutdown != nil {
-		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
-		defer cancel()
-		return t.shutdown(ctx)
+		errs = append(errs, t.shutdown(ctx))
+	}
+	if t.meterProvider != nil {
+		errs = append(errs, t.meterProvider.Shutdown(ctx))
 	}
-	return nil
+	return errors.Join(errs...)
 }
 
 // StartSpan creates a new span with the given name and options
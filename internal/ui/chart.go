@@ -0,0 +1,311 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// sparkBlocks are the glyphs used to draw sparklines, from lowest to highest
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// chartKind selects how a chart draws its primary series
+type chartKind int
+
+const (
+	chartKindLine chartKind = iota
+	chartKindBar
+)
+
+// chartMetric selects which per-match series the stats chart plots
+type chartMetric int
+
+const (
+	chartMetricKD chartMetric = iota
+	chartMetricADR
+	chartMetricHS
+	chartMetricCount
+)
+
+// chartRollingWindow is the number of matches used for the rolling average overlay
+const chartRollingWindow = 5
+
+// String returns the human readable name of the metric
+func (c chartMetric) String() string {
+	switch c {
+	case chartMetricADR:
+		return "ADR"
+	case chartMetricHS:
+		return "HS%"
+	default:
+		return "K/D"
+	}
+}
+
+// valueFormat returns the printf format used for axis labels of the metric
+func (c chartMetric) valueFormat() string {
+	if c == chartMetricKD {
+		return "%.2f"
+	}
+	return "%.0f"
+}
+
+// chart is a small terminal chart with an optional overlay series.
+// Values are plotted left to right, so callers should pass them in
+// chronological order.
+type chart struct {
+	title        string
+	kind         chartKind
+	values       []float64
+	overlay      []float64
+	width        int
+	height       int
+	format       string
+	style        lipgloss.Style
+	overlayStyle lipgloss.Style
+}
+
+// newChart creates a chart with default styles
+func newChart(title string, values []float64, width, height int) chart {
+	return chart{
+		title:        title,
+		values:       values,
+		width:        width,
+		height:       height,
+		format:       "%.2f",
+		style:        chartSeriesStyle,
+		overlayStyle: chartOverlayStyle,
+	}
+}
+
+// sparkline renders values as a single line of block glyphs. When there
+// are more values than width they are averaged into buckets.
+func sparkline(values []float64, width int) string {
+	if len(values) == 0 || width <= 0 {
+		return ""
+	}
+	if len(values) > width {
+		values = resampleValues(values, width)
+	}
+
+	minVal, maxVal := valueRange(values)
+	var b strings.Builder
+	for _, v := range values {
+		idx := 0
+		if maxVal > minVal {
+			idx = int(math.Round((v - minVal) / (maxVal - minVal) * float64(len(sparkBlocks)-1)))
+		}
+		b.WriteRune(sparkBlocks[idx])
+	}
+	return b.String()
+}
+
+// rollingAverage returns the trailing average of values over window
+// entries. The first entries average over however many values exist.
+func rollingAverage(values []float64, window int) []float64 {
+	if window <= 0 || len(values) == 0 {
+		return nil
+	}
+	result := make([]float64, len(values))
+	sum := 0.0
+	for i, v := range values {
+		sum += v
+		if i >= window {
+			sum -= values[i-window]
+		}
+		n := i + 1
+		if n > window {
+			n = window
+		}
+		result[i] = sum / float64(n)
+	}
+	return result
+}
+
+// resampleValues stretches or compresses values to exactly n columns.
+// Compression averages buckets; stretching repeats the nearest value.
+func resampleValues(values []float64, n int) []float64 {
+	if n <= 0 || len(values) == 0 {
+		return nil
+	}
+	result := make([]float64, n)
+	if len(values) >= n {
+		for i := 0; i < n; i++ {
+			start := i * len(values) / n
+			end := (i + 1) * len(values) / n
+			if end <= start {
+				end = start + 1
+			}
+			sum := 0.0
+			for _, v := range values[start:end] {
+				sum += v
+			}
+			result[i] = sum / float64(end-start)
+		}
+		return result
+	}
+	for i := 0; i < n; i++ {
+		result[i] = values[i*len(values)/n]
+	}
+	return result
+}
+
+// valueRange returns the minimum and maximum of values
+func valueRange(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	minVal, maxVal := values[0], values[0]
+	for _, v := range values[1:] {
+		if v < minVal {
+			minVal = v
+		}
+		if v > maxVal {
+			maxVal = v
+		}
+	}
+	return minVal, maxVal
+}
+
+// reverseValues returns a reversed copy of values. Matches are stored
+// newest first, charts are drawn oldest first.
+func reverseValues(values []float64) []float64 {
+	result := make([]float64, len(values))
+	for i, v := range values {
+		result[len(values)-1-i] = v
+	}
+	return result
+}
+
+// render draws the chart into a string of exactly c.height plot rows
+// plus the title and x-axis lines.
+func (c chart) render() string {
+	if len(c.values) == 0 {
+		return helpStyle.Render("No chart data")
+	}
+
+	height := c.height
+	if height < 3 {
+		height = 3
+	}
+
+	minVal, maxVal := valueRange(c.values)
+	if len(c.overlay) > 0 {
+		oMin, oMax := valueRange(c.overlay)
+		minVal = math.Min(minVal, oMin)
+		maxVal = math.Max(maxVal, oMax)
+	}
+	if c.kind == chartKindBar && minVal > 0 {
+		minVal = 0
+	}
+	if maxVal == minVal {
+		maxVal = minVal + 1
+	}
+
+	topLabel := fmt.Sprintf(c.format, maxVal)
+	midLabel := fmt.Sprintf(c.format, (maxVal+minVal)/2)
+	bottomLabel := fmt.Sprintf(c.format, minVal)
+	labelWidth := maxInt(len(topLabel), maxInt(len(midLabel), len(bottomLabel)))
+
+	cols := c.width - labelWidth - 2
+	if cols < 10 {
+		cols = 10
+	}
+	values := resampleValues(c.values, cols)
+	var overlay []float64
+	if len(c.overlay) > 0 {
+		overlay = resampleValues(c.overlay, cols)
+	}
+
+	toRow := func(v float64) int {
+		return int(math.Round((v - minVal) / (maxVal - minVal) * float64(height-1)))
+	}
+
+	// grid[row][col], row 0 is the bottom of the chart
+	grid := make([][]string, height)
+	for r := range grid {
+		grid[r] = make([]string, cols)
+		for col := range grid[r] {
+			grid[r][col] = " "
+		}
+	}
+
+	prevRow := -1
+	for col, v := range values {
+		row := toRow(v)
+		switch c.kind {
+		case chartKindBar:
+			for r := 0; r <= row; r++ {
+				grid[r][col] = c.style.Render("█")
+			}
+		default:
+			if prevRow >= 0 && absInt(row-prevRow) > 1 {
+				lo, hi := prevRow, row
+				if lo > hi {
+					lo, hi = hi, lo
+				}
+				for r := lo + 1; r < hi; r++ {
+					grid[r][col] = c.style.Render("│")
+				}
+			}
+			grid[row][col] = c.style.Render("•")
+			prevRow = row
+		}
+	}
+
+	for col, v := range overlay {
+		row := toRow(v)
+		if grid[row][col] == " " {
+			grid[row][col] = c.overlayStyle.Render("·")
+		} else if c.kind == chartKindBar && row+1 < height && grid[row+1][col] == " " {
+			grid[row+1][col] = c.overlayStyle.Render("·")
+		}
+	}
+
+	var b strings.Builder
+	if c.title != "" {
+		b.WriteString(c.title + "\n")
+	}
+	for r := height - 1; r >= 0; r-- {
+		label := ""
+		switch r {
+		case height - 1:
+			label = topLabel
+		case (height - 1) / 2:
+			label = midLabel
+		case 0:
+			label = bottomLabel
+		}
+		b.WriteString(chartAxisStyle.Render(fmt.Sprintf("%*s ┤", labelWidth, label)))
+		b.WriteString(strings.Join(grid[r], ""))
+		b.WriteString("\n")
+	}
+	b.WriteString(chartAxisStyle.Render(strings.Repeat(" ", labelWidth+1) + "└" + strings.Repeat("─", cols)))
+	b.WriteString("\n")
+
+	oldest, newest := "oldest", "newest"
+	gap := cols - len(oldest) - len(newest)
+	if gap < 1 {
+		gap = 1
+	}
+	b.WriteString(chartAxisStyle.Render(strings.Repeat(" ", labelWidth+2) + oldest + strings.Repeat(" ", gap) + newest))
+	return b.String()
+}
+
+// maxInt returns the larger of two ints
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// absInt returns the absolute value of an int
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []float64
+		width    int
+		expected string
+	}{
+		{"empty", nil, 10, ""},
+		{"zero width", []float64{1, 2}, 0, ""},
+		{"ascending", []float64{0, 7}, 10, "▁█"},
+		{"flat", []float64{1, 1, 1}, 10, "▁▁▁"},
+		{"compressed", []float64{0, 0, 7, 7}, 2, "▁█"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sparkline(tt.values, tt.width); got != tt.expected {
+				t.Errorf("sparkline() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRollingAverage(t *testing.T) {
+	got := rollingAverage([]float64{1, 2, 3, 4, 5}, 2)
+	expected := []float64{1, 1.5, 2.5, 3.5, 4.5}
+	if len(got) != len(expected) {
+		t.Fatalf("rollingAverage() returned %d values, want %d", len(got), len(expected))
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("rollingAverage()[%d] = %v, want %v", i, got[i], expected[i])
+		}
+	}
+
+	if rollingAverage(nil, 3) != nil {
+		t.Error("rollingAverage() of no values should be nil")
+	}
+}
+
+func TestResampleValues(t *testing.T) {
+	compressed := resampleValues([]float64{1, 3, 5, 7}, 2)
+	if len(compressed) != 2 || compressed[0] != 2 || compressed[1] != 6 {
+		t.Errorf("resampleValues() compress = %v, want [2 6]", compressed)
+	}
+
+	stretched := resampleValues([]float64{1, 2}, 4)
+	if len(stretched) != 4 || stretched[0] != 1 || stretched[3] != 2 {
+		t.Errorf("resampleValues() stretch = %v, want [1 1 2 2]", stretched)
+	}
+}
+
+func TestReverseValues(t *testing.T) {
+	got := reverseValues([]float64{1, 2, 3})
+	if got[0] != 3 || got[2] != 1 {
+		t.Errorf("reverseValues() = %v, want [3 2 1]", got)
+	}
+}
+
+func TestChartRender(t *testing.T) {
+	values := []float64{0.8, 1.2, 1.0, 1.5, 0.9, 1.3}
+
+	for _, kind := range []chartKind{chartKindLine, chartKindBar} {
+		c := newChart("K/D", values, 40, 6)
+		c.kind = kind
+		c.overlay = rollingAverage(values, 3)
+		out := c.render()
+
+		lines := strings.Split(out, "\n")
+		// title + plot rows + axis + axis labels
+		if len(lines) != 1+6+2 {
+			t.Errorf("kind %d: render() produced %d lines, want %d", kind, len(lines), 9)
+		}
+		for i, line := range lines[1:] {
+			if w := lipgloss.Width(line); w > 40 {
+				t.Errorf("kind %d: line %d is %d wide, want <= 40", kind, i, w)
+			}
+		}
+		if !strings.Contains(out, "1.50") {
+			t.Errorf("kind %d: render() should label the maximum value", kind)
+		}
+	}
+
+	empty := newChart("", nil, 40, 6).render()
+	if !strings.Contains(empty, "No chart data") {
+		t.Error("render() of an empty chart should say so")
+	}
+}
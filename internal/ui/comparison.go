@@ -0,0 +1,229 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/armitageee/faceit-cli/internal/analytics"
+)
+
+// maxComparisonPlayers is the largest roster that can be compared at once,
+// including the current player
+const maxComparisonPlayers = 10
+
+// comparisonMetric identifies a ranked column of the comparison table
+type comparisonMetric int
+
+const (
+	comparisonKD comparisonMetric = iota
+	comparisonTotalKD
+	comparisonWinRate
+	comparisonHS
+	comparisonKills
+	comparisonDeaths
+	comparisonAssists
+	comparisonBestKD
+	comparisonWorstKD
+	comparisonMetricCount
+)
+
+// String returns the column header for the metric
+func (c comparisonMetric) String() string {
+	switch c {
+	case comparisonKD:
+		return "K/D"
+	case comparisonTotalKD:
+		return "Tot K/D"
+	case comparisonWinRate:
+		return "Win%"
+	case comparisonHS:
+		return "HS%"
+	case comparisonKills:
+		return "Kills"
+	case comparisonDeaths:
+		return "Deaths"
+	case comparisonAssists:
+		return "Assists"
+	case comparisonBestKD:
+		return "Best"
+	case comparisonWorstKD:
+		return "Worst"
+	default:
+		return ""
+	}
+}
+
+// metric returns the analytics metric the column ranks by
+func (c comparisonMetric) metric() analytics.Metric {
+	switch c {
+	case comparisonKD:
+		return analytics.MetricAverageKD
+	case comparisonTotalKD:
+		return analytics.MetricKD
+	case comparisonWinRate:
+		return analytics.MetricWinRate
+	case comparisonHS:
+		return analytics.MetricAverageHS
+	case comparisonKills:
+		return analytics.MetricKills
+	case comparisonDeaths:
+		return analytics.MetricDeaths
+	case comparisonAssists:
+		return analytics.MetricAssists
+	case comparisonBestKD:
+		return analytics.MetricBestKD
+	case comparisonWorstKD:
+		return analytics.MetricWorstKD
+	}
+	return ""
+}
+
+// value returns the metric taken from a player's statistics
+func (c comparisonMetric) value(stats PlayerStatsSummary) float64 {
+	return c.metric().Value(stats.summary())
+}
+
+// format renders the metric value for a table cell
+func (c comparisonMetric) format(stats PlayerStatsSummary) string {
+	switch c {
+	case comparisonWinRate, comparisonHS:
+		return fmt.Sprintf("%.1f%%", c.value(stats))
+	case comparisonKills, comparisonDeaths, comparisonAssists:
+		return fmt.Sprintf("%.0f", c.value(stats))
+	default:
+		return fmt.Sprintf("%.2f", c.value(stats))
+	}
+}
+
+// lowerIsBetter reports whether a smaller value ranks higher
+func (c comparisonMetric) lowerIsBetter() bool {
+	return c.metric().LowerIsBetter()
+}
+
+// parseComparisonNicknames splits the comparison input into unique
+// nicknames. Nicknames may be separated by commas or spaces; the current
+// player is skipped since they are always part of the comparison.
+func parseComparisonNicknames(input, currentPlayer string) ([]string, error) {
+	fields := strings.FieldsFunc(input, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+
+	seen := map[string]bool{strings.ToLower(currentPlayer): true}
+	var nicknames []string
+	for _, field := range fields {
+		key := strings.ToLower(field)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		nicknames = append(nicknames, field)
+	}
+
+	if len(nicknames) == 0 {
+		return nil, fmt.Errorf("enter at least one other player")
+	}
+	if len(nicknames)+1 > maxComparisonPlayers {
+		return nil, fmt.Errorf("at most %d players can be compared", maxComparisonPlayers)
+	}
+	return nicknames, nil
+}
+
+// calculateComparisonData ranks the players on every metric and works out
+// the maps they have in common
+func calculateComparisonData(players []ComparedPlayer) ComparisonData {
+	data := ComparisonData{
+		Rankings:   make(map[comparisonMetric][]int, comparisonMetricCount),
+		CommonMaps: findCommonMaps(players),
+	}
+	for metric := comparisonMetric(0); metric < comparisonMetricCount; metric++ {
+		data.Rankings[metric] = rankPlayers(players, metric)
+	}
+	data.MostPlayedMap = findMostPlayedMap(players, data.CommonMaps)
+	return data
+}
+
+// summary returns the statistics as an analytics.MatchSummary, which the
+// comparison ranks players by
+func (s PlayerStatsSummary) summary() analytics.MatchSummary {
+	return analytics.MatchSummary{
+		Matches:       s.TotalMatches,
+		Wins:          s.Wins,
+		Losses:        s.Losses,
+		WinRate:       s.WinRate,
+		Kills:         s.TotalKills,
+		Deaths:        s.TotalDeaths,
+		Assists:       s.TotalAssists,
+		AverageKD:     s.AverageKDRatio,
+		KD:            s.TotalKDA,
+		AverageHS:     s.AverageHS,
+		AverageADR:    s.AverageADR,
+		BestKD:        s.BestKDRatio,
+		WorstKD:       s.WorstKDRatio,
+		MostPlayedMap: s.MostPlayedMap,
+	}
+}
+
+// rankPlayers returns player indices ordered from best to worst on the
+// metric. Ties keep the input order so the current player stays first.
+func rankPlayers(players []ComparedPlayer, metric comparisonMetric) []int {
+	summaries := make([]analytics.MatchSummary, len(players))
+	for i, player := range players {
+		summaries[i] = player.Stats.summary()
+	}
+	return analytics.Rank(summaries, metric.metric())
+}
+
+// isBestValue reports whether a player holds the best value of a metric,
+// sharing it on ties
+func (d ComparisonData) isBestValue(players []ComparedPlayer, metric comparisonMetric, index int) bool {
+	ranking := d.Rankings[metric]
+	if len(ranking) < 2 {
+		return false
+	}
+	return metric.value(players[index].Stats) == metric.value(players[ranking[0]].Stats)
+}
+
+// findCommonMaps returns the maps every player has played, sorted by name
+func findCommonMaps(players []ComparedPlayer) []string {
+	if len(players) == 0 {
+		return nil
+	}
+	var commonMaps []string
+	for mapName := range players[0].Stats.MapStats {
+		common := true
+		for _, player := range players[1:] {
+			if _, exists := player.Stats.MapStats[mapName]; !exists {
+				common = false
+				break
+			}
+		}
+		if common {
+			commonMaps = append(commonMaps, mapName)
+		}
+	}
+	sort.Strings(commonMaps)
+	return commonMaps
+}
+
+// findMostPlayedMap returns the common map with the most matches across
+// all players
+func findMostPlayedMap(players []ComparedPlayer, commonMaps []string) string {
+	if len(commonMaps) == 0 {
+		return "No common maps"
+	}
+
+	maxCount := 0
+	mostPlayed := ""
+	for _, mapName := range commonMaps {
+		totalCount := 0
+		for _, player := range players {
+			totalCount += player.Stats.MapStats[mapName]
+		}
+		if totalCount > maxCount {
+			maxCount = totalCount
+			mostPlayed = mapName
+		}
+	}
+	return mostPlayed
+}
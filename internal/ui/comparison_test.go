@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func comparisonRoster() []ComparedPlayer {
+	return []ComparedPlayer{
+		{Nickname: "me", Stats: PlayerStatsSummary{AverageKDRatio: 1.1, WinRate: 50, TotalDeaths: 150,
+			MapStats: map[string]int{"de_mirage": 5, "de_inferno": 3, "de_nuke": 2}}},
+		{Nickname: "entry", Stats: PlayerStatsSummary{AverageKDRatio: 1.4, WinRate: 45, TotalDeaths: 170,
+			MapStats: map[string]int{"de_mirage": 4, "de_inferno": 6}}},
+		{Nickname: "awper", Stats: PlayerStatsSummary{AverageKDRatio: 1.1, WinRate: 60, TotalDeaths: 120,
+			MapStats: map[string]int{"de_mirage": 2, "de_inferno": 8, "de_anubis": 1}}},
+	}
+}
+
+func TestRankPlayers(t *testing.T) {
+	players := comparisonRoster()
+
+	tests := []struct {
+		metric   comparisonMetric
+		expected []int
+	}{
+		// Ties keep the input order
+		{comparisonKD, []int{1, 0, 2}},
+		{comparisonWinRate, []int{2, 0, 1}},
+		// Fewer deaths rank higher
+		{comparisonDeaths, []int{2, 0, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.metric.String(), func(t *testing.T) {
+			if got := rankPlayers(players, tt.metric); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("rankPlayers(%s) = %v, want %v", tt.metric, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCalculateComparisonData(t *testing.T) {
+	players := comparisonRoster()
+	data := calculateComparisonData(players)
+
+	if len(data.Rankings) != int(comparisonMetricCount) {
+		t.Errorf("Expected rankings for %d metrics, got %d", comparisonMetricCount, len(data.Rankings))
+	}
+	if expected := []string{"de_inferno", "de_mirage"}; !reflect.DeepEqual(data.CommonMaps, expected) {
+		t.Errorf("Expected common maps %v, got %v", expected, data.CommonMaps)
+	}
+	if data.MostPlayedMap != "de_inferno" {
+		t.Errorf("Expected most played map de_inferno, got %s", data.MostPlayedMap)
+	}
+
+	// Shared best values are highlighted for every holder
+	if !data.isBestValue(players, comparisonWinRate, 2) || data.isBestValue(players, comparisonWinRate, 0) {
+		t.Error("Expected only awper to hold the best win rate")
+	}
+	if !data.isBestValue(players, comparisonKD, 1) || data.isBestValue(players, comparisonKD, 2) {
+		t.Error("Expected only entry to hold the best K/D")
+	}
+}
+
+func TestCalculateComparisonDataNoCommonMaps(t *testing.T) {
+	players := []ComparedPlayer{
+		{Nickname: "a", Stats: PlayerStatsSummary{MapStats: map[string]int{"de_mirage": 1}}},
+		{Nickname: "b", Stats: PlayerStatsSummary{MapStats: map[string]int{"de_nuke": 1}}},
+	}
+	data := calculateComparisonData(players)
+	if len(data.CommonMaps) != 0 || data.MostPlayedMap != "No common maps" {
+		t.Errorf("Expected no common maps, got %v (%s)", data.CommonMaps, data.MostPlayedMap)
+	}
+}
+
+func TestParseComparisonNicknames(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+		wantErr  bool
+	}{
+		{"single friend", "friend", []string{"friend"}, false},
+		{"commas and spaces", "a, b c,,d", []string{"a", "b", "c", "d"}, false},
+		{"duplicates and current player", "a A me Me b", []string{"a", "b"}, false},
+		{"only current player", "me", nil, true},
+		{"too many", "p1 p2 p3 p4 p5 p6 p7 p8 p9 p10", nil, true},
+		{"full roster", "p1 p2 p3 p4 p5 p6 p7 p8 p9", []string{"p1", "p2", "p3", "p4", "p5", "p6", "p7", "p8", "p9"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseComparisonNicknames(tt.input, "me")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseComparisonNicknames(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("parseComparisonNicknames(%q) = %v, want %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
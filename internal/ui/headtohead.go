@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"github.com/armitageee/faceit-cli/internal/entity"
+)
+
+// HeadToHead represents the matches two players played in the same lobby,
+// split by whether they were teammates or opponents
+type HeadToHead struct {
+	Player1  string
+	Player2  string
+	Sample1  int // matches searched in the first player's history
+	Sample2  int // matches searched in the second player's history
+	Together SharedRecord
+	Against  SharedRecord
+	Matches  []SharedMatch // newest first
+}
+
+// SharedRecord aggregates shared matches from the first player's point of
+// view together with each player's statistics in those matches
+type SharedRecord struct {
+	Matches      int
+	Wins         int
+	Losses       int
+	WinRate      float64
+	Player1Stats PlayerStatsSummary
+	Player2Stats PlayerStatsSummary
+}
+
+// SharedMatch pairs both players' summaries of one match
+type SharedMatch struct {
+	Player1   entity.PlayerMatchSummary
+	Player2   entity.PlayerMatchSummary
+	Teammates bool
+}
+
+// calculateHeadToHead finds the matches that appear in both histories and
+// uses team membership to tell teammates from opponents. Matches whose
+// teams are unknown are skipped. Histories are expected newest first.
+func calculateHeadToHead(player1 string, matches1 []entity.PlayerMatchSummary, player2 string, matches2 []entity.PlayerMatchSummary) HeadToHead {
+	h2h := HeadToHead{Player1: player1, Player2: player2, Sample1: len(matches1), Sample2: len(matches2)}
+
+	byID := make(map[string]entity.PlayerMatchSummary, len(matches2))
+	for _, match := range matches2 {
+		byID[match.MatchID] = match
+	}
+
+	var together1, together2, against1, against2 []entity.PlayerMatchSummary
+	for _, match := range matches1 {
+		other, ok := byID[match.MatchID]
+		if !ok || match.TeamID == "" || other.TeamID == "" {
+			continue
+		}
+		shared := SharedMatch{Player1: match, Player2: other, Teammates: match.TeamID == other.TeamID}
+		h2h.Matches = append(h2h.Matches, shared)
+		if shared.Teammates {
+			together1 = append(together1, match)
+			together2 = append(together2, other)
+		} else {
+			against1 = append(against1, match)
+			against2 = append(against2, other)
+		}
+	}
+
+	h2h.Together = newSharedRecord(together1, together2)
+	h2h.Against = newSharedRecord(against1, against2)
+	return h2h
+}
+
+// newSharedRecord builds the record of a set of shared matches
+func newSharedRecord(matches1, matches2 []entity.PlayerMatchSummary) SharedRecord {
+	record := SharedRecord{Matches: len(matches1)}
+	if record.Matches == 0 {
+		return record
+	}
+	record.Player1Stats = calculateStats(matches1)
+	record.Player2Stats = calculateStats(matches2)
+	record.Wins = record.Player1Stats.Wins
+	record.Losses = record.Player1Stats.Losses
+	record.WinRate = record.Player1Stats.WinRate
+	return record
+}
@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/armitageee/faceit-cli/internal/entity"
+	"github.com/armitageee/faceit-cli/internal/repository"
+)
+
+func TestCalculateHeadToHead(t *testing.T) {
+	mine := []entity.PlayerMatchSummary{
+		{MatchID: "m1", TeamID: "faction1", Result: "Win", Kills: 20, Deaths: 10, KDRatio: 2.0},
+		{MatchID: "m2", TeamID: "faction2", Result: "Loss", Kills: 10, Deaths: 20, KDRatio: 0.5},
+		{MatchID: "m3", TeamID: "faction1", Result: "Win", Kills: 15, Deaths: 15, KDRatio: 1.0},
+		{MatchID: "m4", TeamID: "faction1", Result: "Loss", Kills: 12, Deaths: 18, KDRatio: 0.67},
+		{MatchID: "m5", Result: "Win"},
+	}
+	theirs := []entity.PlayerMatchSummary{
+		{MatchID: "x1", TeamID: "faction2", Result: "Loss"},
+		{MatchID: "m1", TeamID: "faction1", Result: "Win", Kills: 18, Deaths: 12, KDRatio: 1.5},
+		{MatchID: "m2", TeamID: "faction1", Result: "Win", Kills: 25, Deaths: 10, KDRatio: 2.5},
+		{MatchID: "m3", TeamID: "faction2", Result: "Loss", Kills: 14, Deaths: 16, KDRatio: 0.88},
+		// Shared, but our team is unknown
+		{MatchID: "m5", TeamID: "faction2", Result: "Loss"},
+	}
+
+	h2h := calculateHeadToHead("me", mine, "friend", theirs)
+
+	if len(h2h.Matches) != 3 {
+		t.Fatalf("Expected 3 shared matches, got %d", len(h2h.Matches))
+	}
+	if h2h.Matches[0].Player1.MatchID != "m1" || !h2h.Matches[0].Teammates {
+		t.Errorf("Expected m1 first and played as teammates, got %+v", h2h.Matches[0])
+	}
+
+	if h2h.Together.Matches != 1 || h2h.Together.Wins != 1 || h2h.Together.Losses != 0 {
+		t.Errorf("Expected 1-0 as teammates, got %d-%d over %d", h2h.Together.Wins, h2h.Together.Losses, h2h.Together.Matches)
+	}
+	if h2h.Together.Player2Stats.TotalKills != 18 {
+		t.Errorf("Expected friend's kills as teammates to be 18, got %d", h2h.Together.Player2Stats.TotalKills)
+	}
+
+	if h2h.Against.Matches != 2 || h2h.Against.Wins != 1 || h2h.Against.Losses != 1 {
+		t.Errorf("Expected 1-1 as opponents, got %d-%d over %d", h2h.Against.Wins, h2h.Against.Losses, h2h.Against.Matches)
+	}
+	if h2h.Against.WinRate != 50 {
+		t.Errorf("Expected 50%% win rate as opponents, got %.1f", h2h.Against.WinRate)
+	}
+	if h2h.Against.Player1Stats.TotalKills != 25 || h2h.Against.Player2Stats.TotalKills != 39 {
+		t.Errorf("Expected opponent kills 25 vs 39, got %d vs %d",
+			h2h.Against.Player1Stats.TotalKills, h2h.Against.Player2Stats.TotalKills)
+	}
+}
+
+func TestCalculateHeadToHeadNoSharedMatches(t *testing.T) {
+	mine := []entity.PlayerMatchSummary{{MatchID: "m1", TeamID: "faction1", Result: "Win"}}
+	theirs := []entity.PlayerMatchSummary{{MatchID: "m2", TeamID: "faction1", Result: "Win"}}
+
+	h2h := calculateHeadToHead("me", mine, "friend", theirs)
+	if len(h2h.Matches) != 0 || h2h.Together.Matches != 0 || h2h.Against.Matches != 0 {
+		t.Errorf("Expected no shared matches, got %+v", h2h)
+	}
+}
+
+// historyRepository serves a long history for every player in which
+// every fifth match is shared, and records the limits asked for
+type historyRepository struct {
+	repository.FaceitRepository
+	mu     sync.Mutex
+	limits map[string]int
+}
+
+func (r *historyRepository) GetPlayerRecentMatches(ctx context.Context, playerID string, gameID string, limit int) ([]entity.PlayerMatchSummary, error) {
+	r.mu.Lock()
+	r.limits[playerID] = limit
+	r.mu.Unlock()
+
+	var matches []entity.PlayerMatchSummary
+	for i := 0; i < limit; i++ {
+		matchID := fmt.Sprintf("%s-%d", playerID, i)
+		if i%5 == 0 {
+			matchID = fmt.Sprintf("shared-%d", i)
+		}
+		matches = append(matches, entity.PlayerMatchSummary{MatchID: matchID, TeamID: "faction1", Result: "Win"})
+	}
+	return matches, nil
+}
+
+func TestHeadToHeadLoadsFullHistories(t *testing.T) {
+	repo := &historyRepository{limits: map[string]int{}}
+	m := filterTestModel()
+	m.repo = repo
+	m.player.ID = "alice-1"
+	players := []ComparedPlayer{{Nickname: "alice", PlayerID: "alice-1"}, {Nickname: "bob", PlayerID: "bob-1"}}
+	m.comparison = &PlayerComparison{Players: players, ComparisonData: calculateComparisonData(players)}
+	m.comparisonSelected = 1
+	m.state = StateComparison
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(AppModel)
+	if m.state != StateLoading || cmd == nil {
+		t.Fatalf("Expected the head-to-head to load, got state %v", m.state)
+	}
+	m = sendMsg(m, cmd())
+
+	if m.state != StateHeadToHead {
+		t.Fatalf("Expected the head-to-head, got state %v", m.state)
+	}
+	if repo.limits["alice-1"] != 100 || repo.limits["bob-1"] != 100 {
+		t.Errorf("Expected both histories loaded up to 100 matches, got %v", repo.limits)
+	}
+	if len(m.headToHead.Matches) != 20 || m.headToHead.Sample1 != 100 || m.headToHead.Sample2 != 100 {
+		t.Errorf("Expected 20 shared matches out of 100 each, got %d out of %d and %d",
+			len(m.headToHead.Matches), m.headToHead.Sample1, m.headToHead.Sample2)
+	}
+	if view := m.viewHeadToHead(); !strings.Contains(view, "Searched the last 100 matches of alice and 100 of bob") {
+		t.Errorf("Expected the sample size on screen, got:\n%s", view)
+	}
+}
@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// typing reports whether keys are typed into a text input, so that help
+// and palette keys must not open their overlays
+func (m AppModel) typing() bool {
+	return stateKeyScope(m.state) == "text input" || m.matchFilterEditing || m.statsWindowEditing
+}
+
+// updateOverlays handles the help overlay and the command palette. It
+// reports false when the key belongs to the current screen.
+func (m AppModel) updateOverlays(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	k := m.keyMap()
+	switch {
+	case m.palette.open:
+		model, cmd := m.updatePalette(msg)
+		return model, cmd, true
+	case m.showHelp:
+		switch {
+		case key.Matches(msg, k.ForceQuit):
+			return m, tea.Quit, true
+		case key.Matches(msg, k.Help, k.Back):
+			m.showHelp = false
+		}
+		return m, nil, true
+	case m.loading || m.typing():
+		return m, nil, false
+	case key.Matches(msg, k.Help):
+		m.showHelp = true
+		return m, nil, true
+	case key.Matches(msg, k.Palette):
+		m.palette = commandPalette{open: true}
+		return m, nil, true
+	}
+	return m, nil, false
+}
+
+// viewHelp renders the key bindings of the current screen
+func (m AppModel) viewHelp() string {
+	k := m.keyMap()
+	scope := stateKeyScope(m.state)
+	bindings := k.scopeBindings(scope)
+
+	width := 0
+	for _, binding := range bindings {
+		width = max(width, lipgloss.Width(binding.Help().Key))
+	}
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("⌨️  Keys: "+strings.ToUpper(scope[:1])+scope[1:]) + "\n\n")
+	for _, binding := range bindings {
+		content.WriteString(playerNameStyle.Render(padCell(binding.Help().Key, width, false)))
+		content.WriteString("  " + statsValueStyle.Render(binding.Help().Desc) + "\n")
+	}
+	content.WriteString("\n" + helpStyle.Render(helpLine(helpEntry(k.Help, "Close"), helpEntry(k.Back, "Close"))))
+	return m.placeOverlay(overlayStyle.Render(content.String()))
+}
@@ -1,12 +1,23 @@
 package ui
 
 import (
+	"github.com/armitageee/faceit-cli/internal/analytics"
 	"github.com/armitageee/faceit-cli/internal/entity"
-	"fmt"
 	"strconv"
 	"strings"
 )
 
+// defaultGame is the FACEIT game loaded unless another one is selected
+const defaultGame = "cs2"
+
+// game returns the FACEIT game whose matches and statistics are loaded
+func (m AppModel) game() string {
+	if m.gameID == "" {
+		return defaultGame
+	}
+	return m.gameID
+}
+
 // addToRecentPlayers adds a player to the recent players list
 func (m *AppModel) addToRecentPlayers(nickname string) {
 	// Remove if already exists
@@ -24,84 +35,46 @@ func (m *AppModel) addToRecentPlayers(nickname string) {
 	}
 }
 
-// calculateStats calculates aggregated statistics from recent matches
+// calculateStats calculates aggregated statistics from recent matches. The
+// totals and averages come from analytics.Summarize, which the API server
+// and exporter use as well; the chart series, map counts and streaks are
+// added for the statistics screens.
 func calculateStats(matches []entity.PlayerMatchSummary) PlayerStatsSummary {
 	if len(matches) == 0 {
 		return PlayerStatsSummary{}
 	}
 
+	summary := analytics.Summarize(matches)
 	stats := PlayerStatsSummary{
-		TotalMatches: len(matches),
-		MapStats:     make(map[string]int),
-		KDChartData:  make([]float64, len(matches)),
+		TotalMatches:   summary.Matches,
+		Wins:           summary.Wins,
+		Losses:         summary.Losses,
+		WinRate:        summary.WinRate,
+		TotalKills:     summary.Kills,
+		TotalDeaths:    summary.Deaths,
+		TotalAssists:   summary.Assists,
+		AverageKDRatio: summary.AverageKD,
+		TotalKDA:       summary.KD,
+		AverageHS:      summary.AverageHS,
+		AverageADR:     summary.AverageADR,
+		BestKDRatio:    summary.BestKD,
+		WorstKDRatio:   summary.WorstKD,
+		MostPlayedMap:  summary.MostPlayedMap,
+		MapStats:       make(map[string]int),
+		KDChartData:    make([]float64, len(matches)),
+		ADRChartData:   make([]float64, len(matches)),
+		HSChartData:    make([]float64, len(matches)),
 	}
 
-	var totalKDRatio, totalHS float64
-	bestKD, worstKD := 0.0, 100.0
-
 	for i, match := range matches {
-		// Basic stats
-		stats.TotalKills += match.Kills
-		stats.TotalDeaths += match.Deaths
-		stats.TotalAssists += match.Assists
-
-		// Win/Loss tracking
-		if match.Result == "Win" {
-			stats.Wins++
-		} else {
-			stats.Losses++
-		}
-
-		// K/D tracking
-		if match.KDRatio > 0 {
-			totalKDRatio += match.KDRatio
-			stats.KDChartData[i] = match.KDRatio
-			
-			if match.KDRatio > bestKD {
-				bestKD = match.KDRatio
-			}
-			if match.KDRatio < worstKD {
-				worstKD = match.KDRatio
-			}
-		}
-
-		// Headshot tracking
-		if match.HeadshotsPercentage > 0 {
-			totalHS += match.HeadshotsPercentage
-		}
-
-		// Map tracking
+		stats.KDChartData[i] = match.KDRatio
+		stats.HSChartData[i] = match.HeadshotsPercentage
+		stats.ADRChartData[i] = match.ADR
 		if match.Map != "" {
 			stats.MapStats[match.Map]++
 		}
 	}
 
-	// Calculate averages
-	if len(matches) > 0 {
-		stats.WinRate = float64(stats.Wins) / float64(len(matches)) * 100
-		stats.AverageKDRatio = totalKDRatio / float64(len(matches))
-		stats.AverageHS = totalHS / float64(len(matches))
-		
-		// Calculate total K/D ratio (total kills / total deaths)
-		if stats.TotalDeaths > 0 {
-			stats.TotalKDA = float64(stats.TotalKills) / float64(stats.TotalDeaths)
-		} else {
-			stats.TotalKDA = float64(stats.TotalKills) // If no deaths, K/D = kills
-		}
-	}
-
-	stats.BestKDRatio = bestKD
-	stats.WorstKDRatio = worstKD
-
-	// Find most played map
-	maxCount := 0
-	for mapName, count := range stats.MapStats {
-		if count > maxCount {
-			maxCount = count
-			stats.MostPlayedMap = mapName
-		}
-	}
-
 	// Calculate streaks
 	currentStreak, streakType, longestWinStreak, longestLossStreak := calculateStreaks(matches)
 	stats.CurrentStreak = currentStreak
@@ -114,59 +87,7 @@ func calculateStats(matches []entity.PlayerMatchSummary) PlayerStatsSummary {
 
 // calculateStreaks calculates win/loss streaks from match results
 func calculateStreaks(matches []entity.PlayerMatchSummary) (currentStreak int, streakType string, longestWinStreak, longestLossStreak int) {
-	if len(matches) == 0 {
-		return 0, "", 0, 0
-	}
-
-	// Calculate current streak
-	currentStreak = 0
-	streakType = ""
-	
-	// Start from the most recent match
-	for _, match := range matches {
-		if match.Result == "Win" {
-			if streakType == "win" || streakType == "" {
-				currentStreak++
-				streakType = "win"
-			} else {
-				break
-			}
-		} else if match.Result == "Loss" {
-			if streakType == "loss" || streakType == "" {
-				currentStreak++
-				streakType = "loss"
-			} else {
-				break
-			}
-		}
-	}
-
-	// Make current streak negative for loss streaks
-	if streakType == "loss" {
-		currentStreak = -currentStreak
-	}
-
-	// Calculate longest streaks
-	var tempWinStreak, tempLossStreak int
-	longestWinStreak, longestLossStreak = 0, 0
-
-	for _, match := range matches {
-		if match.Result == "Win" {
-			tempWinStreak++
-			tempLossStreak = 0
-			if tempWinStreak > longestWinStreak {
-				longestWinStreak = tempWinStreak
-			}
-		} else if match.Result == "Loss" {
-			tempLossStreak++
-			tempWinStreak = 0
-			if tempLossStreak > longestLossStreak {
-				longestLossStreak = tempLossStreak
-			}
-		}
-	}
-
-	return currentStreak, streakType, longestWinStreak, longestLossStreak
+	return analytics.CalculateStreaks(matches)
 }
 
 // generateStreakInfo generates a formatted string for streak information
@@ -328,71 +249,6 @@ func (m AppModel) calculateSupportScore(match *entity.PlayerMatchSummary) float6
 	return float64(match.Assists) * 15
 }
 
-// calculateComparisonData calculates comparison metrics between two players
-func calculateComparisonData(player1Stats, player2Stats PlayerStatsSummary) ComparisonData {
-	return ComparisonData{
-		KDRatioDiff:      player1Stats.AverageKDRatio - player2Stats.AverageKDRatio,
-		TotalKDADiff:     player1Stats.TotalKDA - player2Stats.TotalKDA,
-		WinRateDiff:      player1Stats.WinRate - player2Stats.WinRate,
-		AverageHSDiff:    player1Stats.AverageHS - player2Stats.AverageHS,
-		TotalKillsDiff:   player1Stats.TotalKills - player2Stats.TotalKills,
-		TotalDeathsDiff:  player1Stats.TotalDeaths - player2Stats.TotalDeaths,
-		TotalAssistsDiff: player1Stats.TotalAssists - player2Stats.TotalAssists,
-		BestKDDiff:       player1Stats.BestKDRatio - player2Stats.BestKDRatio,
-		WorstKDDiff:      player1Stats.WorstKDRatio - player2Stats.WorstKDRatio,
-		MostPlayedMap:    findMostPlayedMap(player1Stats, player2Stats),
-		CommonMaps:       findCommonMaps(player1Stats, player2Stats),
-	}
-}
-
-// findMostPlayedMap finds the map that both players played most
-func findMostPlayedMap(stats1, stats2 PlayerStatsSummary) string {
-	commonMaps := findCommonMaps(stats1, stats2)
-	if len(commonMaps) == 0 {
-		return "No common maps"
-	}
-	
-	maxCount := 0
-	mostPlayed := ""
-	for _, mapName := range commonMaps {
-		count1 := stats1.MapStats[mapName]
-		count2 := stats2.MapStats[mapName]
-		totalCount := count1 + count2
-		if totalCount > maxCount {
-			maxCount = totalCount
-			mostPlayed = mapName
-		}
-	}
-	return mostPlayed
-}
-
-// findCommonMaps finds maps that both players have played
-func findCommonMaps(stats1, stats2 PlayerStatsSummary) []string {
-	var commonMaps []string
-	for mapName := range stats1.MapStats {
-		if _, exists := stats2.MapStats[mapName]; exists {
-			commonMaps = append(commonMaps, mapName)
-		}
-	}
-	return commonMaps
-}
-
-// formatComparisonValue formats a comparison value with appropriate styling
-func formatComparisonValue(value float64, isBetter bool) string {
-	if isBetter {
-		return betterStyle.Render(fmt.Sprintf("+%.2f", value))
-	}
-	return worseStyle.Render(fmt.Sprintf("%.2f", value))
-}
-
-// formatComparisonInt formats an integer comparison value with appropriate styling
-func formatComparisonInt(value int, isBetter bool) string {
-	if isBetter {
-		return betterStyle.Render(fmt.Sprintf("+%d", value))
-	}
-	return worseStyle.Render(fmt.Sprintf("%d", value))
-}
-
 // getVisualLength calculates the visual length of a string (counting runes)
 func getVisualLength(s string) int {
 	return len([]rune(s))
@@ -452,59 +308,7 @@ func generateProfileFrame(content string) string {
 
 // extractLifetimeStats extracts key statistics from lifetime stats
 func extractLifetimeStats(stats *entity.PlayerStats) (kdRatio float64, totalMatches int, winRate float64) {
-	if stats == nil || stats.Lifetime == nil {
-		return 0, 0, 0
-	}
-
-	// Extract K/D ratio - try different possible keys based on FACEIT API
-	kdKeys := []string{"Average K/D Ratio", "K/D Ratio", "K/D", "KD Ratio", "Average KD", "K/D Ratio", "K/D", "KD"}
-	for _, key := range kdKeys {
-		if kd, ok := stats.Lifetime[key]; ok {
-			if kdFloat, ok := kd.(float64); ok {
-				kdRatio = kdFloat
-				break
-			} else if kdStr, ok := kd.(string); ok {
-				if parsed, err := strconv.ParseFloat(kdStr, 64); err == nil {
-					kdRatio = parsed
-					break
-				}
-			}
-		}
-	}
-
-	// Extract total matches - try different possible keys
-	matchKeys := []string{"Matches", "Total Matches", "Games", "Total Games", "Matches Played", "Total Matches Played"}
-	for _, key := range matchKeys {
-		if matches, ok := stats.Lifetime[key]; ok {
-			if matchesFloat, ok := matches.(float64); ok {
-				totalMatches = int(matchesFloat)
-				break
-			} else if matchesStr, ok := matches.(string); ok {
-				if parsed, err := strconv.ParseFloat(matchesStr, 64); err == nil {
-					totalMatches = int(parsed)
-					break
-				}
-			}
-		}
-	}
-
-	// Extract win rate - try different possible keys
-	winRateKeys := []string{"Win Rate %", "Win Rate", "Win%", "Win Percentage", "Wins %", "Winrate %"}
-	for _, key := range winRateKeys {
-		if winRateVal, ok := stats.Lifetime[key]; ok {
-			if winRateFloat, ok := winRateVal.(float64); ok {
-				winRate = winRateFloat
-				break
-			} else if winRateStr, ok := winRateVal.(string); ok {
-				if parsed, err := strconv.ParseFloat(winRateStr, 64); err == nil {
-					winRate = parsed
-					break
-				}
-			}
-		}
-	}
-
-	return kdRatio, totalMatches, winRate
+	return analytics.ExtractLifetimeStats(stats)
 }
 
 
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"github.com/armitageee/faceit-cli/internal/analytics"
 	"github.com/armitageee/faceit-cli/internal/entity"
 	"testing"
 )
@@ -112,6 +113,21 @@ func TestCalculateStats(t *testing.T) {
 	}
 }
 
+func TestCalculateStatsMatchesAnalytics(t *testing.T) {
+	matches := []entity.PlayerMatchSummary{
+		{Result: "Win", Kills: 20, Deaths: 10, KDRatio: 2.0, ADR: 90, Map: "de_nuke"},
+		{Result: "Loss", Kills: 0, Deaths: 15, KDRatio: 0, Map: "de_nuke"},
+	}
+	stats := calculateStats(matches)
+	if got, want := stats.summary(), analytics.Summarize(matches); got != want {
+		t.Errorf("calculateStats() = %+v, want the analytics summary %+v", got, want)
+	}
+	// A match without kills is the worst, not skipped
+	if stats.WorstKDRatio != 0 || stats.AverageADR != 90 {
+		t.Errorf("Expected worst K/D 0 and ADR 90, got %.2f and %.1f", stats.WorstKDRatio, stats.AverageADR)
+	}
+}
+
 func TestCalculateStreaks(t *testing.T) {
 	tests := []struct {
 		name                string
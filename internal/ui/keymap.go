@@ -0,0 +1,394 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// KeyMap holds the key bindings of every screen. Bindings are looked up
+// by their action name when loaded from the keys section of config.yml.
+type KeyMap struct {
+	// Shared by most screens
+	Quit      key.Binding
+	ForceQuit key.Binding // quits from text inputs, where letters are typed
+	Back      key.Binding
+	Up        key.Binding
+	Down      key.Binding
+	Left      key.Binding
+	Right     key.Binding
+	PageUp    key.Binding
+	PageDown  key.Binding
+	Select    key.Binding
+	Delete    key.Binding
+	Paste     key.Binding
+	Help      key.Binding
+	Palette   key.Binding
+	// Search screen
+	SearchPlayer key.Binding
+	SearchMatch  key.Binding
+	// Profile screen
+	Matches      key.Binding
+	Stats        key.Binding
+	MapStats     key.Binding
+	Sessions     key.Binding
+	Teammates    key.Binding
+	Watchlist    key.Binding
+	Compare      key.Binding
+	SwitchPlayer key.Binding
+	// Matches screen
+	MatchStats key.Binding
+	Filter     key.Binding
+	SortNext   key.Binding
+	SortPrev   key.Binding
+	Reverse    key.Binding // also reverses the map breakdown
+	Compact    key.Binding
+	// Statistics screen
+	NextWindow   key.Binding
+	CustomWindow key.Binding
+	NextMetric   key.Binding
+	PrevMetric   key.Binding
+	ChartKind    key.Binding
+	// Teammates screen
+	SwitchList key.Binding
+	// Watchlist screen
+	Refresh key.Binding
+}
+
+// DefaultKeyMap returns the built-in key bindings
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Quit:      newBinding("Quit", "ctrl+c", "q"),
+		ForceQuit: newBinding("Quit", "ctrl+c"),
+		Back:      newBinding("Back", "esc"),
+		Up:        newBinding("Up", "up", "k"),
+		Down:      newBinding("Down", "down", "j"),
+		Left:      newBinding("Left", "left", "h"),
+		Right:     newBinding("Right", "right", "l"),
+		PageUp:    newBinding("Page up", "pgup"),
+		PageDown:  newBinding("Page down", "pgdown"),
+		Select:    newBinding("Select", "enter"),
+		Delete:    newBinding("Delete", "backspace"),
+		Paste:     newBinding("Paste from clipboard", "ctrl+v", "cmd+v", "f2"),
+		Help:      newBinding("Help", "?"),
+		Palette:   newBinding("Command palette", ":"),
+
+		SearchPlayer: newBinding("Search player by nickname", "1"),
+		SearchMatch:  newBinding("Search match by ID", "2"),
+
+		Matches:      newBinding("Recent matches", "m"),
+		Stats:        newBinding("Statistics", "s"),
+		MapStats:     newBinding("Map analytics", "a"),
+		Sessions:     newBinding("Sessions", "t"),
+		Teammates:    newBinding("Teammates", "f"),
+		Watchlist:    newBinding("Watchlist", "w"),
+		Compare:      newBinding("Compare players", "c"),
+		SwitchPlayer: newBinding("Switch player", "p"),
+
+		MatchStats: newBinding("Match stats", "d", "D"),
+		Filter:     newBinding("Filter", "/"),
+		SortNext:   newBinding("Sort", "s"),
+		SortPrev:   newBinding("Previous sort", "S"),
+		Reverse:    newBinding("Reverse", "r"),
+		Compact:    newBinding("Compact", "c"),
+
+		NextWindow:   newBinding("Next window", "w"),
+		CustomWindow: newBinding("Custom window", "e"),
+		NextMetric:   newBinding("Next chart metric", "tab"),
+		PrevMetric:   newBinding("Previous chart metric", "shift+tab"),
+		ChartKind:    newBinding("Line/bar chart", "b"),
+
+		SwitchList: newBinding("Switch list", "tab"),
+
+		Refresh: newBinding("Refresh now", "r"),
+	}
+}
+
+// newBinding creates a binding whose help key is derived from its keys
+func newBinding(desc string, keys ...string) key.Binding {
+	return key.NewBinding(key.WithKeys(keys...), key.WithHelp(formatKeys(keys), desc))
+}
+
+// actions maps the action names used in config.yml to the bindings
+func (k *KeyMap) actions() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"quit":          &k.Quit,
+		"force_quit":    &k.ForceQuit,
+		"back":          &k.Back,
+		"up":            &k.Up,
+		"down":          &k.Down,
+		"left":          &k.Left,
+		"right":         &k.Right,
+		"page_up":       &k.PageUp,
+		"page_down":     &k.PageDown,
+		"select":        &k.Select,
+		"delete":        &k.Delete,
+		"paste":         &k.Paste,
+		"help":          &k.Help,
+		"palette":       &k.Palette,
+		"search_player": &k.SearchPlayer,
+		"search_match":  &k.SearchMatch,
+		"matches":       &k.Matches,
+		"stats":         &k.Stats,
+		"map_stats":     &k.MapStats,
+		"sessions":      &k.Sessions,
+		"teammates":     &k.Teammates,
+		"watchlist":     &k.Watchlist,
+		"compare":       &k.Compare,
+		"switch_player": &k.SwitchPlayer,
+		"match_stats":   &k.MatchStats,
+		"filter":        &k.Filter,
+		"sort_next":     &k.SortNext,
+		"sort_prev":     &k.SortPrev,
+		"reverse":       &k.Reverse,
+		"compact":       &k.Compact,
+		"next_window":   &k.NextWindow,
+		"custom_window": &k.CustomWindow,
+		"next_metric":   &k.NextMetric,
+		"prev_metric":   &k.PrevMetric,
+		"chart_kind":    &k.ChartKind,
+		"switch_list":   &k.SwitchList,
+		"refresh":       &k.Refresh,
+	}
+}
+
+// keyScope lists the actions handled by one screen. Two of them bound to
+// the same key would shadow each other.
+type keyScope struct {
+	name    string
+	actions []string
+	typing  bool // letters are typed into a text input, so they cannot be bound
+}
+
+var keyScopes = []keyScope{
+	{name: "search", actions: []string{"search_player", "search_match", "help", "palette", "quit"}},
+	{name: "profile", actions: []string{"matches", "stats", "map_stats", "sessions", "teammates", "watchlist", "compare", "switch_player", "back", "delete", "help", "palette", "quit"}},
+	{name: "matches", actions: []string{"up", "down", "left", "right", "select", "match_stats", "sort_next", "sort_prev", "reverse", "compact", "filter", "back", "delete", "help", "palette", "quit"}},
+	{name: "statistics", actions: []string{"next_window", "custom_window", "next_metric", "prev_metric", "chart_kind", "back", "delete", "help", "palette", "quit"}},
+	{name: "map analytics", actions: []string{"left", "right", "reverse", "back", "delete", "help", "palette", "quit"}},
+	{name: "sessions", actions: []string{"up", "down", "back", "delete", "help", "palette", "quit"}},
+	{name: "teammates", actions: []string{"up", "down", "switch_list", "left", "right", "select", "back", "delete", "help", "palette", "quit"}},
+	{name: "watchlist", actions: []string{"up", "down", "select", "refresh", "back", "delete", "help", "palette", "quit"}},
+	{name: "comparison", actions: []string{"left", "right", "up", "down", "page_up", "page_down", "select", "back", "delete", "help", "palette", "quit"}},
+	{name: "scoreboard", actions: []string{"up", "down", "page_up", "page_down", "select", "compare", "back", "delete", "help", "palette", "quit"}},
+	{name: "details", actions: []string{"back", "delete", "help", "palette", "quit"}},
+	{name: "text input", actions: []string{"select", "delete", "paste", "back", "force_quit"}, typing: true},
+}
+
+// stateKeyScope returns the name of the key scope handling a state
+func stateKeyScope(state AppState) string {
+	switch state {
+	case StateSearch:
+		return "search"
+	case StateProfile:
+		return "profile"
+	case StateMatches:
+		return "matches"
+	case StateStats:
+		return "statistics"
+	case StateMapStats:
+		return "map analytics"
+	case StateSessions:
+		return "sessions"
+	case StateTeammates:
+		return "teammates"
+	case StateWatch:
+		return "watchlist"
+	case StateComparison:
+		return "comparison"
+	case StateMatchStats, StatePlayerMatchDetail:
+		return "scoreboard"
+	case StateMatchSearch, StatePlayerSwitch, StateComparisonInput:
+		return "text input"
+	default:
+		return "details"
+	}
+}
+
+// scopeBindings returns the bindings of a key scope in display order.
+// Screens without a text input go back on delete, which is shown as a
+// key of back.
+func (k *KeyMap) scopeBindings(name string) []key.Binding {
+	actions := k.actions()
+	for _, scope := range keyScopes {
+		if scope.name == name {
+			var bindings []key.Binding
+			for _, action := range scope.actions {
+				binding := *actions[action]
+				switch {
+				case scope.typing:
+				case action == "back":
+					binding.SetHelp(binding.Help().Key+"/"+k.Delete.Help().Key, binding.Help().Desc)
+				case action == "delete":
+					continue
+				}
+				bindings = append(bindings, binding)
+			}
+			return bindings
+		}
+	}
+	return nil
+}
+
+// NewKeyMap returns the default bindings with the given actions rebound.
+// Keys are written as Bubble Tea reports them, e.g. "ctrl+v", "f2", "?"
+// or "shift+tab". Unknown actions, empty key lists and keys shared by two
+// actions of the same screen are errors.
+func NewKeyMap(overrides map[string][]string) (KeyMap, error) {
+	keys := DefaultKeyMap()
+	actions := keys.actions()
+
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		binding, ok := actions[strings.ToLower(name)]
+		if !ok {
+			return keys, fmt.Errorf("unknown key action %q", name)
+		}
+		if len(overrides[name]) == 0 {
+			return keys, fmt.Errorf("no keys bound to %q", name)
+		}
+		binding.SetKeys(overrides[name]...)
+		binding.SetHelp(formatKeys(overrides[name]), binding.Help().Desc)
+	}
+
+	if err := keys.validate(); err != nil {
+		return keys, err
+	}
+	return keys, nil
+}
+
+// validate reports keys bound to two actions of the same screen, and
+// printable keys bound in text inputs
+func (k *KeyMap) validate() error {
+	actions := k.actions()
+	var conflicts []string
+	for _, scope := range keyScopes {
+		owners := make(map[string]string)
+		for _, action := range scope.actions {
+			for _, bound := range actions[action].Keys() {
+				if scope.typing && isPrintableKey(bound) {
+					conflicts = append(conflicts, fmt.Sprintf("%s is bound to %q, which could then not be typed in text inputs", action, bound))
+					continue
+				}
+				if owner, ok := owners[bound]; ok && owner != action {
+					conflicts = append(conflicts, fmt.Sprintf("%q is bound to both %s and %s on the %s screen", bound, owner, action, scope.name))
+					continue
+				}
+				owners[bound] = action
+			}
+		}
+	}
+	if len(conflicts) > 0 {
+		return fmt.Errorf("conflicting key bindings: %s", strings.Join(conflicts, "; "))
+	}
+	return nil
+}
+
+// isPrintableKey reports whether a key types a character
+func isPrintableKey(k string) bool {
+	return len([]rune(k)) == 1 || k == " " || k == "space"
+}
+
+// formatKeys formats keys for help lines, e.g. "↑/K" or "Ctrl+V/F2"
+func formatKeys(keys []string) string {
+	var labels []string
+	seen := make(map[string]bool)
+	for _, k := range keys {
+		label := keyLabel(k)
+		if !seen[label] {
+			seen[label] = true
+			labels = append(labels, label)
+		}
+	}
+	return strings.Join(labels, "/")
+}
+
+// keyLabel formats a single key, e.g. "ctrl+c" as "Ctrl+C"
+func keyLabel(k string) string {
+	switch k {
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "left":
+		return "←"
+	case "right":
+		return "→"
+	case "pgup":
+		return "PgUp"
+	case "pgdown":
+		return "PgDn"
+	case " ", "space":
+		return "Space"
+	}
+	parts := strings.Split(k, "+")
+	for i, part := range parts {
+		if part != "" {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return strings.Join(parts, "+")
+}
+
+// helpEntry formats a binding for a help line, with an optional
+// description replacing the binding's own
+func helpEntry(b key.Binding, desc ...string) string {
+	text := b.Help().Desc
+	if len(desc) > 0 {
+		text = desc[0]
+	}
+	return b.Help().Key + " - " + text
+}
+
+// pairHelpEntry formats two bindings that work as a pair, such as up and
+// down, as "↑↓/KJ - Navigate"
+func pairHelpEntry(first, second key.Binding, desc string) string {
+	a, b := first.Keys(), second.Keys()
+	if len(a) != len(b) {
+		return first.Help().Key + " " + second.Help().Key + " - " + desc
+	}
+	labels := make([]string, len(a))
+	for i := range a {
+		labels[i] = keyLabel(a[i]) + keyLabel(b[i])
+	}
+	return strings.Join(labels, "/") + " - " + desc
+}
+
+// quitHelpEntry formats the quit binding as "Ctrl+C or Q to quit"
+func quitHelpEntry(b key.Binding) string {
+	return strings.ReplaceAll(b.Help().Key, "/", " or ") + " to quit"
+}
+
+// pageLinks returns the labels of the matches screen's pagination links,
+// e.g. "Next (→/L)", which mouse clicks are matched against as well
+func (k *KeyMap) pageLinks() (previous, next string) {
+	return "Previous (" + k.Left.Help().Key + ")", "Next (" + k.Right.Help().Key + ")"
+}
+
+// helpLine joins help entries the way every screen shows them
+func helpLine(entries ...string) string {
+	return strings.Join(entries, " • ")
+}
+
+// defaultKeys is used by models created without a key map
+var defaultKeys = DefaultKeyMap()
+
+// keyMap returns the model's bindings, the defaults when none were set
+func (m AppModel) keyMap() *KeyMap {
+	if m.keys == nil {
+		return &defaultKeys
+	}
+	return m.keys
+}
+
+// WithKeyMap sets the key bindings
+func (m AppModel) WithKeyMap(keys KeyMap) AppModel {
+	m.keys = &keys
+	return m
+}
@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/armitageee/faceit-cli/internal/config"
+	"github.com/armitageee/faceit-cli/internal/entity"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// keyMsg returns the message for a typed character
+func keyMsg(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestDefaultKeyMapHasNoConflicts(t *testing.T) {
+	keys := DefaultKeyMap()
+	if err := keys.validate(); err != nil {
+		t.Fatal(err)
+	}
+	for name, binding := range keys.actions() {
+		if len(binding.Keys()) == 0 || binding.Help().Desc == "" {
+			t.Errorf("Action %s has no keys or description", name)
+		}
+	}
+}
+
+func TestNewKeyMap(t *testing.T) {
+	keys, err := NewKeyMap(map[string][]string{"matches": {"n", "f5"}, "Paste": {"ctrl+y"}})
+	if err != nil {
+		t.Fatalf("NewKeyMap: %v", err)
+	}
+	if got := keys.Matches.Help().Key; got != "N/F5" {
+		t.Errorf("Expected help key N/F5, got %s", got)
+	}
+	if keys.Matches.Help().Desc != "Recent matches" {
+		t.Errorf("Expected the description to be kept, got %s", keys.Matches.Help().Desc)
+	}
+	if got := strings.Join(keys.Paste.Keys(), ","); got != "ctrl+y" {
+		t.Errorf("Expected paste on ctrl+y, got %s", got)
+	}
+
+	errorTests := []struct {
+		overrides map[string][]string
+		want      string
+	}{
+		{map[string][]string{"launch": {"x"}}, `unknown key action "launch"`},
+		{map[string][]string{"stats": nil}, `no keys bound to "stats"`},
+		{map[string][]string{"stats": {"m"}}, `"m" is bound to both matches and stats on the profile screen`},
+		{map[string][]string{"reverse": {"l"}}, `"l" is bound to both right and reverse on the matches screen`},
+		{map[string][]string{"paste": {"p"}}, `paste is bound to "p"`},
+	}
+	for _, tt := range errorTests {
+		_, err := NewKeyMap(tt.overrides)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%v: expected an error containing %q, got %v", tt.overrides, tt.want, err)
+		}
+	}
+
+	// Keys of different screens do not conflict
+	if _, err := NewKeyMap(map[string][]string{"refresh": {"s"}}); err != nil {
+		t.Errorf("Expected refresh on s to be allowed, got %v", err)
+	}
+}
+
+func TestKeyHelpFormatting(t *testing.T) {
+	keys := DefaultKeyMap()
+	tests := []struct {
+		got, want string
+	}{
+		{pairHelpEntry(keys.Up, keys.Down, "Navigate"), "↑↓/KJ - Navigate"},
+		{helpEntry(keys.MatchStats), "D - Match stats"},
+		{helpEntry(keys.Back, "Back to profile"), "Esc - Back to profile"},
+		{helpEntry(keys.Paste), "Ctrl+V/Cmd+V/F2 - Paste from clipboard"},
+		{helpEntry(keys.PrevMetric), "Shift+Tab - Previous chart metric"},
+		{quitHelpEntry(keys.Quit), "Ctrl+C or Q to quit"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Got %q, want %q", tt.got, tt.want)
+		}
+	}
+}
+
+func TestMatchSearchTypesLetters(t *testing.T) {
+	m := AppModel{state: StateMatchSearch, config: &config.Config{}}
+	m = typeKeys(m, "1-abc-pq")
+	if m.matchSearchInput != "1-abc-pq" {
+		t.Errorf("Expected p and q to be typed, got %q", m.matchSearchInput)
+	}
+	m = typeKeys(m, "esc")
+	if m.state != StateSearch || m.matchSearchInput != "" {
+		t.Error("Expected Esc to go back to search")
+	}
+
+	m = AppModel{state: StatePlayerSwitch, config: &config.Config{}}
+	m = typeKeys(m, "quinn")
+	if m.playerSwitchInput != "quinn" {
+		t.Errorf("Expected q to be typed in a nickname, got %q", m.playerSwitchInput)
+	}
+}
+
+func TestReboundKeys(t *testing.T) {
+	keys, err := NewKeyMap(map[string][]string{"switch_player": {"n"}, "quit": {"ctrl+c", "x"}})
+	if err != nil {
+		t.Fatalf("NewKeyMap: %v", err)
+	}
+	m := AppModel{state: StateProfile, config: &config.Config{}, player: &entity.PlayerProfile{Nickname: "alice"}}.WithKeyMap(keys)
+
+	view := m.viewProfile()
+	if !strings.Contains(view, "N - Switch player") || !strings.Contains(view, "Ctrl+C or X to quit") {
+		t.Errorf("Expected the help line to show the configured keys:\n%s", view)
+	}
+
+	if updated := typeKeys(m, "p"); updated.state != StateProfile {
+		t.Error("Expected p to be unbound")
+	}
+	if updated := typeKeys(m, "n"); updated.state != StatePlayerSwitch {
+		t.Error("Expected n to switch player")
+	}
+	if _, cmd := m.Update(keyMsg("q")); cmd != nil {
+		t.Error("Expected q not to quit")
+	}
+	if _, cmd := m.Update(keyMsg("x")); cmd == nil {
+		t.Error("Expected x to quit")
+	}
+}
@@ -0,0 +1,281 @@
+package ui
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/armitageee/faceit-cli/internal/entity"
+)
+
+// mapFormLength is the number of most recent results shown as form per map
+const mapFormLength = 5
+
+// MapPerformance represents a player's aggregated performance on one map
+type MapPerformance struct {
+	Map        string
+	Matches    int
+	Wins       int
+	Losses     int
+	WinRate    float64
+	AverageKD  float64
+	AverageADR float64
+	AverageHS  float64
+	RoundsWon  int
+	RoundsLost int
+	RecentForm string // "W"/"L" per match, newest first
+	Lifetime   *LifetimeMapStats
+}
+
+// LifetimeMapStats represents FACEIT's lifetime statistics for one map,
+// taken from the Segments of the player stats response
+type LifetimeMapStats struct {
+	Matches   int
+	WinRate   float64
+	AverageKD float64
+	AverageHS float64
+	ADR       float64
+}
+
+// mapSortColumn identifies a sortable column of the map breakdown table
+type mapSortColumn int
+
+const (
+	mapSortMap mapSortColumn = iota
+	mapSortMatches
+	mapSortWinRate
+	mapSortKD
+	mapSortADR
+	mapSortHS
+	mapSortRounds
+	mapSortLifetimeWinRate
+	mapSortColumnCount
+)
+
+// String returns the column header for the sort column
+func (c mapSortColumn) String() string {
+	switch c {
+	case mapSortMap:
+		return "Map"
+	case mapSortMatches:
+		return "M"
+	case mapSortWinRate:
+		return "Win%"
+	case mapSortKD:
+		return "K/D"
+	case mapSortADR:
+		return "ADR"
+	case mapSortHS:
+		return "HS%"
+	case mapSortRounds:
+		return "Rounds"
+	case mapSortLifetimeWinRate:
+		return "Life Win%"
+	default:
+		return ""
+	}
+}
+
+// calculateMapPerformance aggregates matches per map. Matches are expected
+// newest first, as returned by the repository.
+func calculateMapPerformance(matches []entity.PlayerMatchSummary) []MapPerformance {
+	byMap := make(map[string]*MapPerformance)
+	var order []string
+
+	for _, match := range matches {
+		if match.Map == "" {
+			continue
+		}
+		perf, ok := byMap[match.Map]
+		if !ok {
+			perf = &MapPerformance{Map: match.Map}
+			byMap[match.Map] = perf
+			order = append(order, match.Map)
+		}
+
+		perf.Matches++
+		won := match.Result == "Win"
+		if won {
+			perf.Wins++
+		} else {
+			perf.Losses++
+		}
+		if len(perf.RecentForm) < mapFormLength {
+			if won {
+				perf.RecentForm += "W"
+			} else {
+				perf.RecentForm += "L"
+			}
+		}
+
+		perf.AverageKD += match.KDRatio
+		perf.AverageADR += match.ADR
+		perf.AverageHS += match.HeadshotsPercentage
+
+		// Scores are not ordered by team, so use the result to tell
+		// which side of the score belongs to the player
+		if a, b, ok := parseScore(match.Score); ok {
+			high, low := a, b
+			if low > high {
+				high, low = low, high
+			}
+			if won {
+				perf.RoundsWon += high
+				perf.RoundsLost += low
+			} else {
+				perf.RoundsWon += low
+				perf.RoundsLost += high
+			}
+		}
+	}
+
+	result := make([]MapPerformance, 0, len(order))
+	for _, name := range order {
+		perf := byMap[name]
+		n := float64(perf.Matches)
+		perf.WinRate = float64(perf.Wins) / n * 100
+		perf.AverageKD /= n
+		perf.AverageADR /= n
+		perf.AverageHS /= n
+		result = append(result, *perf)
+	}
+	return result
+}
+
+// parseScore parses a score like "13-7" into its two sides
+func parseScore(score string) (int, int, bool) {
+	parts := strings.Split(score, "-")
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+	a, errA := strconv.Atoi(strings.TrimSpace(parts[0]))
+	b, errB := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if errA != nil || errB != nil {
+		return 0, 0, false
+	}
+	return a, b, true
+}
+
+// normalizeMapName reduces names such as "de_mirage" and "Mirage" to a
+// common key so match maps can be joined with lifetime segments
+func normalizeMapName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, prefix := range []string{"de_", "cs_"} {
+		name = strings.TrimPrefix(name, prefix)
+	}
+	return name
+}
+
+// parseStatValue converts a FACEIT stat value, which may be a number or a
+// numeric string, to a float
+func parseStatValue(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case string:
+		parsed, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(v), "%"), 64)
+		return parsed, err == nil
+	}
+	return 0, false
+}
+
+// extractLifetimeMapStats extracts per-map lifetime statistics from the
+// map segments of a player stats response, keyed by normalized map name
+func extractLifetimeMapStats(stats *entity.PlayerStats) map[string]LifetimeMapStats {
+	result := make(map[string]LifetimeMapStats)
+	if stats == nil {
+		return result
+	}
+
+	for _, segment := range stats.Segments {
+		if segType, ok := segment["type"].(string); ok && !strings.EqualFold(segType, "Map") {
+			continue
+		}
+		label, _ := segment["label"].(string)
+		values, _ := segment["stats"].(map[string]interface{})
+		if label == "" || values == nil {
+			continue
+		}
+
+		lifetime := LifetimeMapStats{}
+		if v, ok := parseStatValue(values["Matches"]); ok {
+			lifetime.Matches = int(v)
+		}
+		if v, ok := parseStatValue(values["Win Rate %"]); ok {
+			lifetime.WinRate = v
+		}
+		if v, ok := parseStatValue(values["Average K/D Ratio"]); ok {
+			lifetime.AverageKD = v
+		}
+		if v, ok := parseStatValue(values["Average Headshots %"]); ok {
+			lifetime.AverageHS = v
+		}
+		if v, ok := parseStatValue(values["ADR"]); ok {
+			lifetime.ADR = v
+		}
+		result[normalizeMapName(label)] = lifetime
+	}
+	return result
+}
+
+// attachLifetimeMapStats links lifetime segment statistics to the recent
+// per-map performance entries
+func attachLifetimeMapStats(perf []MapPerformance, lifetime map[string]LifetimeMapStats) {
+	for i := range perf {
+		if stats, ok := lifetime[normalizeMapName(perf[i].Map)]; ok {
+			stats := stats
+			perf[i].Lifetime = &stats
+		}
+	}
+}
+
+// sortMapPerformance sorts the breakdown in place by the given column.
+// Ties are broken by number of matches and then by name so the order is
+// stable between renders.
+func sortMapPerformance(perf []MapPerformance, column mapSortColumn, descending bool) {
+	key := func(p MapPerformance) float64 {
+		switch column {
+		case mapSortMatches:
+			return float64(p.Matches)
+		case mapSortWinRate:
+			return p.WinRate
+		case mapSortKD:
+			return p.AverageKD
+		case mapSortADR:
+			return p.AverageADR
+		case mapSortHS:
+			return p.AverageHS
+		case mapSortRounds:
+			return float64(p.RoundsWon - p.RoundsLost)
+		case mapSortLifetimeWinRate:
+			if p.Lifetime != nil {
+				return p.Lifetime.WinRate
+			}
+			return -1
+		}
+		return 0
+	}
+
+	sort.SliceStable(perf, func(i, j int) bool {
+		a, b := perf[i], perf[j]
+		if column == mapSortMap {
+			if descending {
+				return a.Map > b.Map
+			}
+			return a.Map < b.Map
+		}
+		ka, kb := key(a), key(b)
+		if ka != kb {
+			if descending {
+				return ka > kb
+			}
+			return ka < kb
+		}
+		if a.Matches != b.Matches {
+			return a.Matches > b.Matches
+		}
+		return a.Map < b.Map
+	})
+}
@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/armitageee/faceit-cli/internal/entity"
+)
+
+func TestCalculateMapPerformance(t *testing.T) {
+	matches := []entity.PlayerMatchSummary{
+		{Map: "de_mirage", Result: "Win", Score: "7-13", KDRatio: 1.5, ADR: 90, HeadshotsPercentage: 50},
+		{Map: "de_nuke", Result: "Loss", Score: "13-10", KDRatio: 0.8, ADR: 60, HeadshotsPercentage: 40},
+		{Map: "de_mirage", Result: "Loss", Score: "11-13", KDRatio: 0.5, ADR: 50, HeadshotsPercentage: 30},
+		{Map: "", Result: "Win"},
+	}
+
+	perf := calculateMapPerformance(matches)
+	if len(perf) != 2 {
+		t.Fatalf("calculateMapPerformance() returned %d maps, want 2", len(perf))
+	}
+
+	mirage := perf[0]
+	if mirage.Map != "de_mirage" {
+		t.Fatalf("first map = %s, want de_mirage", mirage.Map)
+	}
+	if mirage.Matches != 2 || mirage.Wins != 1 || mirage.Losses != 1 {
+		t.Errorf("mirage record = %d (%d-%d), want 2 (1-1)", mirage.Matches, mirage.Wins, mirage.Losses)
+	}
+	if mirage.WinRate != 50 {
+		t.Errorf("mirage WinRate = %v, want 50", mirage.WinRate)
+	}
+	if mirage.AverageKD != 1.0 || mirage.AverageADR != 70 || mirage.AverageHS != 40 {
+		t.Errorf("mirage averages = %v/%v/%v, want 1/70/40", mirage.AverageKD, mirage.AverageADR, mirage.AverageHS)
+	}
+	// Won 13-7 and lost 11-13
+	if mirage.RoundsWon != 24 || mirage.RoundsLost != 20 {
+		t.Errorf("mirage rounds = %d-%d, want 24-20", mirage.RoundsWon, mirage.RoundsLost)
+	}
+	if mirage.RecentForm != "WL" {
+		t.Errorf("mirage RecentForm = %q, want %q", mirage.RecentForm, "WL")
+	}
+}
+
+func TestExtractLifetimeMapStats(t *testing.T) {
+	stats := &entity.PlayerStats{
+		Segments: []map[string]interface{}{
+			{
+				"type":  "Map",
+				"label": "Mirage",
+				"stats": map[string]interface{}{
+					"Matches":             "120",
+					"Win Rate %":          "55",
+					"Average K/D Ratio":   "1.12",
+					"Average Headshots %": "48",
+				},
+			},
+			{
+				"type":  "Mode",
+				"label": "5v5",
+				"stats": map[string]interface{}{"Matches": "500"},
+			},
+		},
+	}
+
+	lifetime := extractLifetimeMapStats(stats)
+	if len(lifetime) != 1 {
+		t.Fatalf("extractLifetimeMapStats() returned %d maps, want 1", len(lifetime))
+	}
+	mirage, ok := lifetime["mirage"]
+	if !ok {
+		t.Fatal("expected lifetime stats keyed by normalized map name")
+	}
+	if mirage.Matches != 120 || mirage.WinRate != 55 || mirage.AverageKD != 1.12 || mirage.AverageHS != 48 {
+		t.Errorf("unexpected lifetime stats: %+v", mirage)
+	}
+
+	perf := []MapPerformance{{Map: "de_mirage"}, {Map: "de_nuke"}}
+	attachLifetimeMapStats(perf, lifetime)
+	if perf[0].Lifetime == nil || perf[0].Lifetime.Matches != 120 {
+		t.Error("de_mirage should be joined with the Mirage segment")
+	}
+	if perf[1].Lifetime != nil {
+		t.Error("de_nuke has no lifetime segment")
+	}
+
+	if len(extractLifetimeMapStats(nil)) != 0 {
+		t.Error("nil stats should produce no lifetime map stats")
+	}
+}
+
+func TestSortMapPerformance(t *testing.T) {
+	perf := []MapPerformance{
+		{Map: "de_nuke", Matches: 3, WinRate: 33, AverageKD: 1.2},
+		{Map: "de_ancient", Matches: 5, WinRate: 80, AverageKD: 0.9},
+		{Map: "de_mirage", Matches: 4, WinRate: 50, AverageKD: 1.4},
+	}
+
+	sortMapPerformance(perf, mapSortWinRate, true)
+	if perf[0].Map != "de_ancient" || perf[2].Map != "de_nuke" {
+		t.Errorf("sort by win rate desc = %v, %v, %v", perf[0].Map, perf[1].Map, perf[2].Map)
+	}
+
+	sortMapPerformance(perf, mapSortKD, false)
+	if perf[0].Map != "de_ancient" || perf[2].Map != "de_mirage" {
+		t.Errorf("sort by K/D asc = %v, %v, %v", perf[0].Map, perf[1].Map, perf[2].Map)
+	}
+
+	sortMapPerformance(perf, mapSortMap, false)
+	if perf[0].Map != "de_ancient" || perf[1].Map != "de_mirage" {
+		t.Errorf("sort by name asc = %v, %v, %v", perf[0].Map, perf[1].Map, perf[2].Map)
+	}
+}
@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/armitageee/faceit-cli/internal/entity"
+	"github.com/armitageee/faceit-cli/internal/query"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// matchFilter narrows the matches screen to the matches it selects
+type matchFilter struct {
+	text   string       // as typed, shown in the pagination line
+	filter query.Filter // nil when no filter is active
+}
+
+// active reports whether a filter is applied
+func (f matchFilter) active() bool {
+	return f.filter != nil
+}
+
+// parseMatchFilter compiles the text typed in the filter bar. Plain words
+// such as "ancient loss" must each appear in the map, result, score or
+// date of a match; anything with an operator is a query expression such
+// as kd > 1.3 and finished > 2026-01-01. Empty input clears the filter.
+func parseMatchFilter(input string) (query.Filter, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, nil
+	}
+	if !strings.ContainsAny(input, "=<>~!()&|\"'") {
+		return textFilter(strings.Fields(strings.ToLower(input))), nil
+	}
+	return query.Parse(input)
+}
+
+// textFilter matches when every word appears in the map, result, score or
+// finish date of a match
+type textFilter []string
+
+// Match implements query.Filter
+func (f textFilter) Match(m *entity.PlayerMatchSummary) bool {
+	haystack := strings.ToLower(strings.Join([]string{
+		m.Map, m.Result, m.Score, time.Unix(m.FinishedAt, 0).Format("2006-01-02"),
+	}, " "))
+	for _, word := range f {
+		if !strings.Contains(haystack, word) {
+			return false
+		}
+	}
+	return true
+}
+
+// visibleMatches returns the matches shown on the matches screen, filtered
+// and sorted. The selection and pagination index into this list.
+func (m AppModel) visibleMatches() []entity.PlayerMatchSummary {
+	sorted := m.matchSortField != matchSortDate || m.matchSortReversed
+	if !m.matchFilter.active() && !sorted {
+		// The API order is already newest first
+		return m.matches
+	}
+	var visible []entity.PlayerMatchSummary
+	for i := range m.matches {
+		if !m.matchFilter.active() || m.matchFilter.filter.Match(&m.matches[i]) {
+			visible = append(visible, m.matches[i])
+		}
+	}
+	if sorted {
+		sortMatches(visible, m.matchSortField, m.matchSortDescending())
+	}
+	return visible
+}
+
+// setMatchFilter applies a filter and moves back to the first match
+func (m *AppModel) setMatchFilter(filter matchFilter) {
+	m.matchFilter = filter
+	m.currentPage = 1
+	m.selectedMatchIndex = 0
+}
+
+// updateMatchFilterInput handles key events while the filter bar is open
+func (m AppModel) updateMatchFilterInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	k := m.keyMap()
+	switch {
+	case key.Matches(msg, k.ForceQuit):
+		return m, tea.Quit
+	case key.Matches(msg, k.Back):
+		m.matchFilterEditing = false
+		m.matchFilterError = ""
+		return m, nil
+	case key.Matches(msg, k.Select):
+		filter, err := parseMatchFilter(m.matchFilterInput)
+		if err != nil {
+			m.matchFilterError = err.Error()
+			return m, nil
+		}
+		m.matchFilterEditing = false
+		m.matchFilterError = ""
+		m.setMatchFilter(matchFilter{text: strings.TrimSpace(m.matchFilterInput), filter: filter})
+		return m, nil
+	case key.Matches(msg, k.Delete):
+		if len(m.matchFilterInput) > 0 {
+			m.matchFilterInput = m.matchFilterInput[:len(m.matchFilterInput)-1]
+		}
+		return m, nil
+	case key.Matches(msg, k.Paste):
+		// Handle paste from clipboard
+		if content, err := GetClipboardContent(); err == nil && content != "" {
+			m.matchFilterInput += strings.TrimSpace(content)
+		}
+		return m, nil
+	default:
+		if len(msg.String()) == 1 {
+			m.matchFilterInput += msg.String()
+		}
+		return m, nil
+	}
+}
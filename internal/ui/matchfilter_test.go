@@ -0,0 +1,176 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/armitageee/faceit-cli/internal/config"
+	"github.com/armitageee/faceit-cli/internal/entity"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// filterTestModel returns a matches screen with 25 matches, newest first.
+// Every fifth match is an Ancient loss.
+func filterTestModel() AppModel {
+	var matches []entity.PlayerMatchSummary
+	start := time.Date(2026, 1, 1, 20, 0, 0, 0, time.Local)
+	for i := 0; i < 25; i++ {
+		match := entity.PlayerMatchSummary{
+			MatchID:    fmt.Sprintf("m%d", i),
+			Map:        "de_mirage",
+			Result:     "Win",
+			Score:      "13-7",
+			KDRatio:    1.0 + float64(i)/10,
+			FinishedAt: start.AddDate(0, 0, -i).Unix(),
+		}
+		if i%5 == 0 {
+			match.Map, match.Result, match.Score = "de_ancient", "Loss", "9-13"
+		}
+		matches = append(matches, match)
+	}
+	return AppModel{
+		state:          StateMatches,
+		config:         &config.Config{MaxMatchesToLoad: 100},
+		player:         &entity.PlayerProfile{Nickname: "alice"},
+		matches:        matches,
+		matchesPerPage: 10,
+		currentPage:    1,
+	}
+}
+
+// typeKeys sends each key, or each rune of plain text, to the model
+func typeKeys(m AppModel, keys ...string) AppModel {
+	for _, key := range keys {
+		var msg tea.KeyMsg
+		switch key {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "backspace":
+			msg = tea.KeyMsg{Type: tea.KeyBackspace}
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		case "right":
+			msg = tea.KeyMsg{Type: tea.KeyRight}
+		default:
+			for _, r := range key {
+				if r == ' ' {
+					msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+				} else {
+					msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+				}
+				updated, _ := m.Update(msg)
+				m = updated.(AppModel)
+			}
+			continue
+		}
+		updated, _ := m.Update(msg)
+		m = updated.(AppModel)
+	}
+	return m
+}
+
+func TestParseMatchFilter(t *testing.T) {
+	match := entity.PlayerMatchSummary{Map: "de_ancient", Result: "Loss", Score: "9-13", KDRatio: 1.4,
+		FinishedAt: time.Date(2026, 2, 3, 20, 0, 0, 0, time.Local).Unix()}
+
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"ancient loss", true},
+		{"ANCIENT win", false},
+		{"2026-02-03", true},
+		{"9-13", true},
+		{"kd > 1.3 and map = de_ancient", true},
+		{"kd > 1.5", false},
+		{"finished > 2026-02-01 and result = loss", true},
+	}
+	for _, tt := range tests {
+		filter, err := parseMatchFilter(tt.input)
+		if err != nil {
+			t.Fatalf("parseMatchFilter(%q): %v", tt.input, err)
+		}
+		if got := filter.Match(&match); got != tt.want {
+			t.Errorf("%q matched = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+
+	if filter, err := parseMatchFilter("  "); filter != nil || err != nil {
+		t.Errorf("Expected empty input to clear the filter, got %v, %v", filter, err)
+	}
+	if _, err := parseMatchFilter("kd > lots"); err == nil {
+		t.Error("Expected an error for an invalid expression")
+	}
+}
+
+func TestMatchFilterBar(t *testing.T) {
+	m := filterTestModel()
+	m = typeKeys(m, "right", "down")
+	if m.currentPage != 2 || m.selectedMatchIndex != 11 {
+		t.Fatalf("Expected page 2 and match 11 selected, got page %d, match %d", m.currentPage, m.selectedMatchIndex)
+	}
+
+	m = typeKeys(m, "/", "ancient loss", "enter")
+	if m.matchFilterEditing || !m.matchFilter.active() {
+		t.Fatal("Expected the filter to be applied")
+	}
+	visible := m.visibleMatches()
+	if len(visible) != 5 || visible[0].MatchID != "m0" || visible[4].MatchID != "m20" {
+		t.Fatalf("Expected the 5 Ancient losses, got %d matches", len(visible))
+	}
+	if m.currentPage != 1 || m.selectedMatchIndex != 0 {
+		t.Errorf("Expected the filter to reset the page and selection, got page %d, match %d", m.currentPage, m.selectedMatchIndex)
+	}
+
+	// Navigation and paging are limited to the filtered matches
+	m = typeKeys(m, "down", "down", "down", "down", "down", "down", "right")
+	if m.selectedMatchIndex != 4 || m.currentPage != 1 {
+		t.Errorf("Expected to stop on the last filtered match, got match %d, page %d", m.selectedMatchIndex, m.currentPage)
+	}
+
+	view := m.viewMatches()
+	if !strings.Contains(view, "🔍 ancient loss (5 of 25)") {
+		t.Errorf("Expected the active filter in the pagination line:\n%s", view)
+	}
+
+	// An invalid expression keeps the bar open with an error
+	m = typeKeys(m, "/", " and kd >", "enter")
+	if !m.matchFilterEditing || m.matchFilterError == "" || m.matchFilter.text != "ancient loss" {
+		t.Errorf("Expected an error and the previous filter to stay, got %q (%q)", m.matchFilterError, m.matchFilter.text)
+	}
+	m = typeKeys(m, "esc")
+	if m.matchFilterEditing || m.state != StateMatches {
+		t.Error("Expected Esc to close the filter bar only")
+	}
+
+	// Esc clears the filter before leaving the screen
+	m = typeKeys(m, "esc")
+	if m.matchFilter.active() || m.state != StateMatches || len(m.visibleMatches()) != 25 {
+		t.Error("Expected Esc to clear the filter")
+	}
+	m = typeKeys(m, "esc")
+	if m.state != StateProfile {
+		t.Errorf("Expected a second Esc to go back to the profile, got state %v", m.state)
+	}
+}
+
+func TestMatchFilterWithoutResults(t *testing.T) {
+	m := typeKeys(filterTestModel(), "/", "inferno", "enter")
+	if len(m.visibleMatches()) != 0 {
+		t.Fatal("Expected no matches")
+	}
+	view := m.viewMatches()
+	if !strings.Contains(view, "No matches match the filter") || !strings.Contains(view, "No matches of 25") {
+		t.Errorf("Expected an empty filtered view:\n%s", view)
+	}
+	// Selecting nothing does not start a load
+	m = typeKeys(m, "enter")
+	if m.loading {
+		t.Error("Expected Enter to do nothing without matches")
+	}
+}
@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/armitageee/faceit-cli/internal/entity"
+)
+
+// matchSortField identifies the field the matches screen is sorted by
+type matchSortField int
+
+const (
+	matchSortDate matchSortField = iota
+	matchSortKD
+	matchSortKills
+	matchSortHS
+	matchSortADR
+	matchSortMap
+	matchSortFieldCount
+)
+
+// String returns the label shown in the sort indicator
+func (f matchSortField) String() string {
+	switch f {
+	case matchSortDate:
+		return "Date"
+	case matchSortKD:
+		return "K/D"
+	case matchSortKills:
+		return "Kills"
+	case matchSortHS:
+		return "HS%"
+	case matchSortADR:
+		return "ADR"
+	case matchSortMap:
+		return "Map"
+	default:
+		return ""
+	}
+}
+
+// descendingByDefault reports whether the field is first sorted highest
+// first: newest matches and best stats lead, maps are listed A to Z
+func (f matchSortField) descendingByDefault() bool {
+	return f != matchSortMap
+}
+
+// matchSortDescending reports the direction of the current sort
+func (m AppModel) matchSortDescending() bool {
+	return m.matchSortField.descendingByDefault() != m.matchSortReversed
+}
+
+// sortMatches sorts matches in place. The sort is stable, so matches with
+// equal values keep the API order (newest first) and the order does not
+// change between pages or when background loading adds matches.
+func sortMatches(matches []entity.PlayerMatchSummary, field matchSortField, descending bool) {
+	key := func(match entity.PlayerMatchSummary) float64 {
+		switch field {
+		case matchSortDate:
+			return float64(match.FinishedAt)
+		case matchSortKD:
+			return match.KDRatio
+		case matchSortKills:
+			return float64(match.Kills)
+		case matchSortHS:
+			return match.HeadshotsPercentage
+		case matchSortADR:
+			return match.ADR
+		}
+		return 0
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if field == matchSortMap {
+			ma, mb := strings.ToLower(a.Map), strings.ToLower(b.Map)
+			if descending {
+				return ma > mb
+			}
+			return ma < mb
+		}
+		ka, kb := key(a), key(b)
+		if descending {
+			return ka > kb
+		}
+		return ka < kb
+	})
+}
+
+// setMatchSort changes the sort and moves back to the first match
+func (m *AppModel) setMatchSort(field matchSortField, reversed bool) {
+	m.matchSortField = field
+	m.matchSortReversed = reversed
+	m.currentPage = 1
+	m.selectedMatchIndex = 0
+}
+
+// selectMatch moves the selection, and the page, to the match with the
+// given ID when it is visible
+func (m *AppModel) selectMatch(matchID string) {
+	for i, match := range m.visibleMatches() {
+		if match.MatchID == matchID {
+			m.selectedMatchIndex = i
+			if m.matchesPerPage > 0 {
+				m.currentPage = i/m.matchesPerPage + 1
+			}
+			return
+		}
+	}
+}
+
+// selectedMatchID returns the ID of the selected match, or "" when the
+// matches screen is empty
+func (m AppModel) selectedMatchID() string {
+	visible := m.visibleMatches()
+	if m.selectedMatchIndex >= 0 && m.selectedMatchIndex < len(visible) {
+		return visible[m.selectedMatchIndex].MatchID
+	}
+	return ""
+}
+
+// adoptMatches replaces the loaded matches with a larger list fetched for
+// another screen. The selected match stays selected, as a sorted or
+// filtered list can place the new matches before it.
+func (m *AppModel) adoptMatches(matches []entity.PlayerMatchSummary) {
+	if len(matches) <= len(m.matches) {
+		return
+	}
+	selected := m.selectedMatchID()
+	m.matches = matches
+	if selected != "" {
+		m.selectMatch(selected)
+	}
+}
@@ -0,0 +1,139 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/armitageee/faceit-cli/internal/entity"
+)
+
+func matchIDs(matches []entity.PlayerMatchSummary) string {
+	ids := make([]string, len(matches))
+	for i, match := range matches {
+		ids[i] = match.MatchID
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestSortMatches(t *testing.T) {
+	matches := []entity.PlayerMatchSummary{
+		{MatchID: "a", Map: "de_nuke", FinishedAt: 300, KDRatio: 1.2, Kills: 20, HeadshotsPercentage: 40, ADR: 80},
+		{MatchID: "b", Map: "de_Ancient", FinishedAt: 200, KDRatio: 0.8, Kills: 20, HeadshotsPercentage: 55, ADR: 95},
+		{MatchID: "c", Map: "de_mirage", FinishedAt: 100, KDRatio: 1.2, Kills: 12, HeadshotsPercentage: 30, ADR: 70},
+	}
+
+	tests := []struct {
+		field      matchSortField
+		descending bool
+		want       string
+	}{
+		{matchSortDate, true, "a,b,c"},
+		{matchSortDate, false, "c,b,a"},
+		{matchSortKD, true, "a,c,b"}, // equal values keep the API order
+		{matchSortKD, false, "b,a,c"},
+		{matchSortKills, true, "a,b,c"},
+		{matchSortHS, true, "b,a,c"},
+		{matchSortADR, false, "c,a,b"},
+		{matchSortMap, false, "b,c,a"},
+		{matchSortMap, true, "a,c,b"},
+	}
+	for _, tt := range tests {
+		sorted := append([]entity.PlayerMatchSummary(nil), matches...)
+		sortMatches(sorted, tt.field, tt.descending)
+		if got := matchIDs(sorted); got != tt.want {
+			t.Errorf("%s descending=%v: got %s, want %s", tt.field, tt.descending, got, tt.want)
+		}
+	}
+}
+
+func TestMatchSortKeys(t *testing.T) {
+	m := filterTestModel()
+	m = typeKeys(m, "right")
+
+	// K/D sorts highest first and resets the page
+	m = typeKeys(m, "s")
+	if m.matchSortField != matchSortKD || !m.matchSortDescending() || m.currentPage != 1 {
+		t.Fatalf("Expected K/D descending on page 1, got %s descending=%v page %d", m.matchSortField, m.matchSortDescending(), m.currentPage)
+	}
+	if visible := m.visibleMatches(); visible[0].MatchID != "m24" || visible[24].MatchID != "m0" {
+		t.Errorf("Unexpected K/D order: %s", matchIDs(visible))
+	}
+	if !strings.Contains(m.viewMatches(), "Sort: K/D ▼") {
+		t.Error("Expected the sort indicator in the view")
+	}
+
+	m = typeKeys(m, "r")
+	if m.matchSortDescending() || m.visibleMatches()[0].MatchID != "m0" {
+		t.Error("Expected R to reverse the order")
+	}
+
+	// Cycling moves on in the field's default direction, and back again
+	m = typeKeys(m, "S", "S")
+	if m.matchSortField != matchSortMap || m.matchSortDescending() {
+		t.Errorf("Expected Map ascending, got %s descending=%v", m.matchSortField, m.matchSortDescending())
+	}
+	m = typeKeys(m, "s")
+	if m.matchSortField != matchSortDate || m.matchSortReversed {
+		t.Errorf("Expected to wrap around to Date, got %s", m.matchSortField)
+	}
+	if visible := m.visibleMatches(); &visible[0] != &m.matches[0] {
+		t.Error("Expected the default sort to use the API order")
+	}
+
+	// The filter applies to the sorted list
+	m = typeKeys(m, "s", "/", "ancient", "enter")
+	if got := matchIDs(m.visibleMatches()); got != "m20,m15,m10,m5,m0" {
+		t.Errorf("Unexpected filtered order: %s", got)
+	}
+}
+
+func TestMatchSortKeepsSelectionOnBackgroundLoad(t *testing.T) {
+	m := filterTestModel()
+	m.matches = m.matches[:12]
+	m = typeKeys(m, "s", "down", "down")
+	if m.selectedMatchID() != "m9" {
+		t.Fatalf("Expected m9 selected, got %s", m.selectedMatchID())
+	}
+
+	// Background loading adds older matches with a better K/D
+	more := append([]entity.PlayerMatchSummary(nil), m.matches...)
+	for i := 12; i < 25; i++ {
+		more = append(more, entity.PlayerMatchSummary{MatchID: fmt.Sprintf("m%d", i), KDRatio: 1.0 + float64(i)/10})
+	}
+	m.backgroundLoading = true
+	updated, _ := m.Update(backgroundMatchesLoadedMsg{playerID: m.player.ID, gameID: m.game(), matches: more})
+	m = updated.(AppModel)
+
+	if m.selectedMatchID() != "m9" {
+		t.Errorf("Expected m9 to stay selected, got %s", m.selectedMatchID())
+	}
+	if m.selectedMatchIndex != 15 || m.currentPage != 2 {
+		t.Errorf("Expected match 15 on page 2, got match %d on page %d", m.selectedMatchIndex, m.currentPage)
+	}
+}
+
+func TestBackgroundLoadOfAnotherPlayerIsIgnored(t *testing.T) {
+	m := filterTestModel()
+	m.player.ID = "alice-1"
+	m.matches = m.matches[:12]
+	m.backgroundLoading = true
+
+	more := append([]entity.PlayerMatchSummary(nil), m.matches...)
+	for i := 12; i < 25; i++ {
+		more = append(more, entity.PlayerMatchSummary{MatchID: fmt.Sprintf("old%d", i)})
+	}
+	for _, msg := range []backgroundMatchesLoadedMsg{
+		{playerID: "bob-1", gameID: m.game(), matches: more},
+		{playerID: "alice-1", gameID: "csgo", matches: more},
+	} {
+		updated, cmd := m.Update(msg)
+		got := updated.(AppModel)
+		if len(got.matches) != 12 {
+			t.Errorf("Expected the matches of %s/%s to be ignored, got %d matches", msg.playerID, msg.gameID, len(got.matches))
+		}
+		if cmd != nil {
+			t.Errorf("Expected no further loading for %s/%s", msg.playerID, msg.gameID)
+		}
+	}
+}
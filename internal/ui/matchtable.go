@@ -0,0 +1,269 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/armitageee/faceit-cli/internal/entity"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// matchColumn is a column of the matches table
+type matchColumn struct {
+	name       string // as written in the match_columns setting
+	header     string
+	alignRight bool
+	minWidth   int // the column shrinks to this width when space is short, 0 when it never shrinks
+	sortable   bool
+	sortField  matchSortField
+	value      func(match entity.PlayerMatchSummary) string
+	style      func(match entity.PlayerMatchSummary) *lipgloss.Style // nil for plain text
+}
+
+// matchTableGap separates the columns of the matches table
+const matchTableGap = "  "
+
+// matchTableColumns lists the available columns in their default order
+var matchTableColumns = []matchColumn{
+	{
+		name:   "result",
+		header: "Result",
+		value:  func(match entity.PlayerMatchSummary) string { return match.Result },
+		style:  resultCellStyle,
+	},
+	{
+		name:      "map",
+		header:    "Map",
+		minWidth:  6,
+		sortable:  true,
+		sortField: matchSortMap,
+		value:     func(match entity.PlayerMatchSummary) string { return match.Map },
+	},
+	{
+		name:       "score",
+		header:     "Score",
+		alignRight: true,
+		value:      func(match entity.PlayerMatchSummary) string { return match.Score },
+	},
+	{
+		name:       "kda",
+		header:     "K-D-A",
+		alignRight: true,
+		sortable:   true,
+		sortField:  matchSortKills,
+		value: func(match entity.PlayerMatchSummary) string {
+			return fmt.Sprintf("%d-%d-%d", match.Kills, match.Deaths, match.Assists)
+		},
+	},
+	{
+		name:       "kd",
+		header:     "K/D",
+		alignRight: true,
+		sortable:   true,
+		sortField:  matchSortKD,
+		value:      func(match entity.PlayerMatchSummary) string { return fmt.Sprintf("%.2f", match.KDRatio) },
+	},
+	{
+		name:       "hs",
+		header:     "HS%",
+		alignRight: true,
+		sortable:   true,
+		sortField:  matchSortHS,
+		value:      func(match entity.PlayerMatchSummary) string { return fmt.Sprintf("%.0f%%", match.HeadshotsPercentage) },
+	},
+	{
+		name:       "adr",
+		header:     "ADR",
+		alignRight: true,
+		sortable:   true,
+		sortField:  matchSortADR,
+		value:      func(match entity.PlayerMatchSummary) string { return fmt.Sprintf("%.1f", match.ADR) },
+	},
+	{
+		name:      "date",
+		header:    "Date",
+		sortable:  true,
+		sortField: matchSortDate,
+		value: func(match entity.PlayerMatchSummary) string {
+			return time.Unix(match.FinishedAt, 0).Format("2006-01-02 15:04")
+		},
+	},
+	{
+		name:       "elo",
+		header:     "ELO",
+		alignRight: true,
+		value: func(match entity.PlayerMatchSummary) string {
+			return formatEloChange(match.EloChange, match.EloChange != 0)
+		},
+		style: func(match entity.PlayerMatchSummary) *lipgloss.Style {
+			switch {
+			case match.EloChange > 0:
+				return &winStyle
+			case match.EloChange < 0:
+				return &lossStyle
+			}
+			return nil
+		},
+	},
+}
+
+// resultCellStyle colours a win or a loss
+func resultCellStyle(match entity.PlayerMatchSummary) *lipgloss.Style {
+	if match.Result == "Win" {
+		return &winStyle
+	}
+	return &lossStyle
+}
+
+// parseMatchColumns looks up the configured column names. An empty list
+// selects every column. Unknown names are returned so they can be
+// reported, and skipped.
+func parseMatchColumns(names []string) (columns []matchColumn, unknown []string) {
+	if len(names) == 0 {
+		return matchTableColumns, nil
+	}
+	for _, name := range names {
+		found := false
+		for _, column := range matchTableColumns {
+			if strings.EqualFold(column.name, name) {
+				columns = append(columns, column)
+				found = true
+				break
+			}
+		}
+		if !found {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(columns) == 0 {
+		return matchTableColumns, unknown
+	}
+	return columns, unknown
+}
+
+// fitMatchColumns returns how many columns fit in maxWidth and their
+// widths. Shrinkable columns give up space first, then columns are dropped
+// from the right; the first column is always kept. A maxWidth of 0 or less
+// means no limit.
+func fitMatchColumns(columns []matchColumn, widths []int, maxWidth int) (int, []int) {
+	widths = append([]int(nil), widths...)
+	total := func(n int) int {
+		sum := len(matchTableGap) * (n - 1)
+		for _, w := range widths[:n] {
+			sum += w
+		}
+		return sum
+	}
+
+	n := len(columns)
+	if maxWidth <= 0 || total(n) <= maxWidth {
+		return n, widths
+	}
+	for i, column := range columns {
+		if column.minWidth > 0 && widths[i] > column.minWidth {
+			widths[i] -= min(widths[i]-column.minWidth, total(n)-maxWidth)
+		}
+	}
+	for n > 1 && total(n) > maxWidth {
+		n--
+	}
+	return n, widths[:n]
+}
+
+// truncateCell shortens text to width runes, marking the cut with an ellipsis
+func truncateCell(text string, width int) string {
+	runes := []rune(text)
+	if len(runes) <= width {
+		return text
+	}
+	if width <= 1 {
+		return string(runes[:width])
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+// padCell pads text to width, on the left for right-aligned columns
+func padCell(text string, width int, alignRight bool) string {
+	text = truncateCell(text, width)
+	padding := strings.Repeat(" ", max(0, width-lipgloss.Width(text)))
+	if alignRight {
+		return padding + text
+	}
+	return text + padding
+}
+
+// renderMatchTable renders the given page of the matches screen as a table.
+// startIndex is the index of the first match in the visible list, used to
+// mark the selected match. In compact mode matches are not separated by
+// blank lines.
+func (m AppModel) renderMatchTable(matches []entity.PlayerMatchSummary, startIndex int) string {
+	columns := m.matchColumns
+	if len(columns) == 0 {
+		columns = matchTableColumns
+	}
+
+	// Header labels, with the active sort column marked
+	headers := make([]string, len(columns))
+	widths := make([]int, len(columns))
+	for i, column := range columns {
+		headers[i] = column.header
+		if column.sortable && column.sortField == m.matchSortField {
+			headers[i] += sortIndicator(m.matchSortDescending())
+		}
+		widths[i] = lipgloss.Width(headers[i])
+	}
+	cells := make([][]string, len(matches))
+	for row, match := range matches {
+		cells[row] = make([]string, len(columns))
+		for i, column := range columns {
+			cells[row][i] = column.value(match)
+			widths[i] = max(widths[i], lipgloss.Width(cells[row][i]))
+		}
+	}
+
+	// The table sits in matchesStyle (border and padding) after the selection marker
+	maxWidth := 0
+	if m.width > 0 {
+		maxWidth = m.width - matchesStyle.GetHorizontalFrameSize() - 2
+	}
+	n, widths := fitMatchColumns(columns, widths, maxWidth)
+
+	var header strings.Builder
+	header.WriteString("  ")
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			header.WriteString(matchTableGap)
+		}
+		header.WriteString(padCell(headers[i], widths[i], columns[i].alignRight))
+	}
+
+	var content strings.Builder
+	content.WriteString(tableHeaderStyle.Render(header.String()) + "\n")
+	content.WriteString(separatorStyle.Render(strings.Repeat("─", lipgloss.Width(header.String()))) + "\n")
+	for row, match := range matches {
+		if row > 0 && !m.compactMatches {
+			content.WriteString("\n")
+		}
+		prefix := "  "
+		if startIndex+row == m.selectedMatchIndex {
+			prefix = "▶ "
+		}
+		content.WriteString(prefix)
+		for i := 0; i < n; i++ {
+			if i > 0 {
+				content.WriteString(matchTableGap)
+			}
+			cell := padCell(cells[row][i], widths[i], columns[i].alignRight)
+			if columns[i].style != nil {
+				if style := columns[i].style(match); style != nil {
+					cell = style.Render(cell)
+				}
+			}
+			content.WriteString(cell)
+		}
+		content.WriteString("\n")
+	}
+	return strings.TrimSuffix(content.String(), "\n")
+}
@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func columnNames(columns []matchColumn) string {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.name
+	}
+	return strings.Join(names, ",")
+}
+
+func TestParseMatchColumns(t *testing.T) {
+	columns, unknown := parseMatchColumns([]string{"Date", "map", "rating", "adr"})
+	if got := columnNames(columns); got != "date,map,adr" {
+		t.Errorf("Expected date,map,adr, got %s", got)
+	}
+	if len(unknown) != 1 || unknown[0] != "rating" {
+		t.Errorf("Expected rating to be reported, got %v", unknown)
+	}
+
+	// Nothing usable falls back to every column
+	columns, _ = parseMatchColumns([]string{"rating"})
+	if len(columns) != len(matchTableColumns) {
+		t.Errorf("Expected every column, got %s", columnNames(columns))
+	}
+	columns, _ = parseMatchColumns(nil)
+	if len(columns) != len(matchTableColumns) {
+		t.Errorf("Expected every column, got %s", columnNames(columns))
+	}
+}
+
+func TestFitMatchColumns(t *testing.T) {
+	columns, _ := parseMatchColumns([]string{"result", "map", "kd", "date"})
+	widths := []int{6, 20, 4, 16} // 52 wide with the gaps
+
+	if n, got := fitMatchColumns(columns, widths, 0); n != 4 || got[1] != 20 {
+		t.Errorf("Expected no limit to keep every column, got %d %v", n, got)
+	}
+	if n, got := fitMatchColumns(columns, widths, 46); n != 4 || got[1] != 14 {
+		t.Errorf("Expected the map column to shrink to 14, got %d %v", n, got)
+	}
+	if n, got := fitMatchColumns(columns, widths, 30); n != 3 || got[1] != 6 {
+		t.Errorf("Expected the date column to be dropped, got %d %v", n, got)
+	}
+	if n, _ := fitMatchColumns(columns, widths, 3); n != 1 {
+		t.Errorf("Expected the first column to be kept, got %d", n)
+	}
+	if widths[1] != 20 {
+		t.Error("Expected the widths to be left untouched")
+	}
+}
+
+func TestMatchTableView(t *testing.T) {
+	m := filterTestModel()
+	m.matches[0].ADR = 101.3
+	m.matches[0].EloChange = -23
+
+	table := m.renderMatchTable(m.matches[:3], 0)
+	lines := strings.Split(table, "\n")
+	if !strings.Contains(lines[0], "K-D-A") || !strings.Contains(lines[0], "Date▼") {
+		t.Errorf("Expected a header with the sort indicator, got %q", lines[0])
+	}
+	if !strings.Contains(lines[2], "101.3") || !strings.Contains(lines[2], "-23") || !strings.HasPrefix(lines[2], "▶ ") {
+		t.Errorf("Expected the selected match with its ADR and ELO change, got %q", lines[2])
+	}
+	if len(lines) != 2+5 {
+		t.Errorf("Expected matches separated by blank lines, got %d lines", len(lines))
+	}
+
+	// Compact mode, toggled with C, has one line per match
+	m = typeKeys(m, "c")
+	if !m.compactMatches {
+		t.Fatal("Expected C to toggle compact mode")
+	}
+	if lines := strings.Split(m.renderMatchTable(m.matches[:3], 0), "\n"); len(lines) != 2+3 {
+		t.Errorf("Expected one line per match, got %d lines", len(lines))
+	}
+
+	// Narrow terminals drop columns instead of wrapping
+	m.width = 50
+	for _, line := range strings.Split(m.renderMatchTable(m.matches[:3], 0), "\n") {
+		if w := lipgloss.Width(line); w > 50-matchesStyle.GetHorizontalFrameSize() {
+			t.Errorf("Line is %d wide: %q", w, line)
+		}
+	}
+
+	// Only the configured columns are shown
+	m.matchColumns, _ = parseMatchColumns([]string{"map", "adr"})
+	header := strings.Split(m.renderMatchTable(m.matches[:3], 0), "\n")[0]
+	if strings.Contains(header, "Result") || !strings.Contains(header, "ADR") {
+		t.Errorf("Expected the map and ADR columns only, got %q", header)
+	}
+}
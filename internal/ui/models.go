@@ -1,13 +1,16 @@
 package ui
 
 import (
+	"time"
+
 	"github.com/armitageee/faceit-cli/internal/config"
 	"github.com/armitageee/faceit-cli/internal/entity"
 	"github.com/armitageee/faceit-cli/internal/logger"
 	"github.com/armitageee/faceit-cli/internal/repository"
+	"github.com/armitageee/faceit-cli/internal/telemetry"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
 )
 
 // Custom message types for async operations
@@ -34,10 +37,21 @@ func InitialModel(repo repository.FaceitRepository, config *config.Config, appLo
 		recentPlayers:  make([]string, 0),
 		loading:        false,
 		currentPage:    1,
-		totalMatches:   0,
 		matchesPerPage: config.MatchesPerPage,
-		hasMoreMatches: false,
+		mapSortColumn:  mapSortMatches,
+		mapSortDesc:    true,
+		statsWindow:    statsWindowPresets[0],
+		compactMatches: config.CompactMatches,
+	}
+
+	// Columns of the matches table
+	columns, unknown := parseMatchColumns(config.MatchColumns)
+	if len(unknown) > 0 {
+		appLogger.Warn("Ignoring unknown match columns", map[string]interface{}{
+			"columns": unknown,
+		})
 	}
+	model.matchColumns = columns
 
 	// If default player is configured, load it automatically
 	if config.DefaultPlayer != "" {
@@ -52,8 +66,19 @@ func InitialModel(repo repository.FaceitRepository, config *config.Config, appLo
 	return model
 }
 
+// WithMetrics returns a model that records the duration of background
+// loads
+func (m AppModel) WithMetrics(metrics *telemetry.Metrics) AppModel {
+	m.metrics = metrics
+	return m
+}
+
 // Init initializes the model
 func (m AppModel) Init() tea.Cmd {
+	// Start polling when opened on the watchlist dashboard
+	if m.state == StateWatch && m.watcher != nil {
+		return m.pollWatch()
+	}
 	// If we have a default player, load it
 	if m.config.DefaultPlayer != "" {
 		return m.loadPlayerProfile(m.config.DefaultPlayer)
@@ -70,39 +95,26 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
-		switch m.state {
-		case StateSearch:
-			return m.updateSearch(msg)
-		case StateProfile:
-			return m.updateProfile(msg)
-		case StateMatches:
-			return m.updateMatches(msg)
-		case StateStats:
-			return m.updateStats(msg)
-		case StateMatchDetail:
-			return m.updateMatchDetail(msg)
-		case StateMatchSearch:
-			return m.updateMatchSearch(msg)
-		case StateMatchStats:
-			return m.updateMatchStats(msg)
-		case StatePlayerMatchDetail:
-			return m.updatePlayerMatchDetail(msg)
-		case StatePlayerSwitch:
-			return m.updatePlayerSwitch(msg)
-		case StateComparisonInput:
-			return m.updateComparisonInput(msg)
-		case StateComparison:
-			return m.updateComparison(msg)
-		case StateError:
-			return m.updateError(msg)
-		}
-
+		model, cmd := m.updateKeys(msg)
+		return m.recordHistory(msg, model.(AppModel)), cmd
 
+	case tea.MouseMsg:
+		model, cmd := m.updateMouse(msg)
+		return m.recordHistory(msg, model.(AppModel)), cmd
 
 	case profileLoadedMsg:
 		m.loading = false
 		m.player = &msg.profile
 		m.state = StateProfile
+		// Go back to the default game when the player has not played
+		// the selected one
+		if _, ok := msg.profile.Games[m.gameID]; !ok {
+			m.gameID = ""
+		}
+		// Drop data that belonged to the previous player
+		m.matches = nil
+		m.lifetimeStats = nil
+		m.matchFilter = matchFilter{}
 		// Add to recent players
 		m.addToRecentPlayers(msg.profile.Nickname)
 		// Load lifetime stats
@@ -113,10 +125,6 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.matches = msg.matches
 		m.selectedMatchIndex = 0
 		m.currentPage = 1
-		m.totalMatches = len(msg.matches)
-		// Calculate if there are more pages
-		totalPages := (len(msg.matches) + m.matchesPerPage - 1) / m.matchesPerPage
-		m.hasMoreMatches = m.currentPage < totalPages
 		m.state = StateMatches
 		
 		// Start background loading if we loaded less than the maximum
@@ -132,21 +140,18 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Replace matches with the new page data
 		m.matches = msg.matches
 		m.currentPage = msg.page
-		m.hasMoreMatches = msg.hasMore
-		m.totalMatches = msg.totalMatches
 		// Reset selected index to first match of the page
 		m.selectedMatchIndex = 0
 		return m, nil
 
 	case backgroundMatchesLoadedMsg:
-		// Update matches if we have more data from background loading
-		if len(msg.matches) > len(m.matches) {
-			m.matches = msg.matches
-			// Recalculate pagination info
-			m.totalMatches = len(m.matches)
-			totalPages := (len(m.matches) + m.matchesPerPage - 1) / m.matchesPerPage
-			m.hasMoreMatches = m.currentPage < totalPages
+		// Ignore matches of a player or game that is no longer shown, along
+		// with the rest of their loading chain
+		if m.player == nil || msg.playerID != m.player.ID || msg.gameID != m.game() {
+			return m, nil
 		}
+		// Update matches if we have more data from background loading
+		m.adoptMatches(msg.matches)
 		
 		// Check if we need to continue loading
 		if len(m.matches) < m.config.MaxMatchesToLoad && len(msg.matches) > 0 {
@@ -161,12 +166,16 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case matchStatsLoadedMsg:
 		m.loading = false
 		m.matchStats = msg.matchStats
+		m.scroll = 0
+		m.scoreboardSelected = 0
 		m.state = StateMatchStats
 		return m, nil
 
 	case playerMatchStatsLoadedMsg:
 		m.loading = false
 		m.playerMatchStats = msg.matchStats
+		m.scroll = 0
+		m.scoreboardSelected = 0
 		m.state = StatePlayerMatchDetail
 		return m, nil
 
@@ -174,6 +183,8 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.loading = false
 		m.stats = &msg.stats
 		m.state = StateStats
+		// Keep the larger match set so later windows can be recomputed locally
+		m.adoptMatches(msg.matches)
 		return m, nil
 
 	case matchDetailLoadedMsg:
@@ -185,9 +196,68 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case comparisonLoadedMsg:
 		m.loading = false
 		m.comparison = &msg.comparison
+		m.comparisonSelected = 0
+		m.scroll = 0
 		m.state = StateComparison
 		return m, nil
 
+	case headToHeadLoadedMsg:
+		m.loading = false
+		m.headToHead = &msg.headToHead
+		m.state = StateHeadToHead
+		return m, nil
+
+	case mapStatsLoadedMsg:
+		m.loading = false
+		m.mapStats = msg.maps
+		sortMapPerformance(m.mapStats, m.mapSortColumn, m.mapSortDesc)
+		m.state = StateMapStats
+		return m, nil
+
+	case sessionsLoadedMsg:
+		m.loading = false
+		m.sessions = msg.sessions
+		m.selectedSessionIndex = 0
+		m.state = StateSessions
+		m.adoptMatches(msg.matches)
+		return m, nil
+
+	case watchPolledMsg:
+		// Ignore polls of a watcher that has been replaced
+		if msg.generation != m.watchGeneration {
+			return m, nil
+		}
+		m.watchPollInFlight = false
+		m.watchStatuses = msg.statuses
+		now := time.Now()
+		for _, event := range msg.events {
+			m.watchEvents = append([]watchLogEntry{{at: now, event: event}}, m.watchEvents...)
+		}
+		if len(m.watchEvents) > watchEventLogLength {
+			m.watchEvents = m.watchEvents[:watchEventLogLength]
+		}
+		notifications := m.notifyWatchEvents(msg.events)
+		if !m.watchPolling {
+			return m, notifications
+		}
+		return m, tea.Batch(m.scheduleWatchPoll(), notifications)
+
+	case watchTickMsg:
+		if msg.timer != m.watchTimer || !m.watchPolling || m.watchPollInFlight {
+			return m, nil
+		}
+		cmd := m.pollWatch()
+		return m, cmd
+
+	case frequenciesLoadedMsg:
+		m.loading = false
+		m.frequencies = &msg.frequencies
+		m.frequencyOpponents = false
+		m.selectedFrequencyIndex = 0
+		m.state = StateTeammates
+		m.adoptMatches(msg.matches)
+		return m, nil
+
 	case lifetimeStatsLoadedMsg:
 		m.loading = false
 		m.lifetimeStats = msg.stats
@@ -209,11 +279,71 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// updateKeys handles key events
+func (m AppModel) updateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Only quitting is possible while loading
+	if m.loading || m.state == StateLoading {
+		k := m.keyMap()
+		if key.Matches(msg, k.Quit, k.ForceQuit) {
+			return m, tea.Quit
+		}
+		return m, nil
+	}
+
+	// The help overlay and command palette take keys before the screen
+	if model, cmd, handled := m.updateOverlays(msg); handled {
+		return model, cmd
+	}
+	switch m.state {
+	case StateSearch:
+		return m.updateSearch(msg)
+	case StateProfile:
+		return m.updateProfile(msg)
+	case StateMatches:
+		return m.updateMatches(msg)
+	case StateStats:
+		return m.updateStats(msg)
+	case StateMatchDetail:
+		return m.updateMatchDetail(msg)
+	case StateMatchSearch:
+		return m.updateMatchSearch(msg)
+	case StateMatchStats:
+		return m.updateMatchStats(msg)
+	case StatePlayerMatchDetail:
+		return m.updatePlayerMatchDetail(msg)
+	case StatePlayerSwitch:
+		return m.updatePlayerSwitch(msg)
+	case StateComparisonInput:
+		return m.updateComparisonInput(msg)
+	case StateComparison:
+		return m.updateComparison(msg)
+	case StateHeadToHead:
+		return m.updateHeadToHead(msg)
+	case StateMapStats:
+		return m.updateMapStats(msg)
+	case StateSessions:
+		return m.updateSessions(msg)
+	case StateTeammates:
+		return m.updateTeammates(msg)
+	case StateWatch:
+		return m.updateWatch(msg)
+	case StateError:
+		return m.updateError(msg)
+	}
+	return m, nil
+}
+
 // View renders the current state
 func (m AppModel) View() string {
 	if m.loading {
 		return m.renderLoadingScreen()
 	}
+	if m.palette.open {
+		return m.viewPalette()
+	}
+	if m.showHelp {
+		return m.viewHelp()
+	}
 
 	switch m.state {
 	case StateSearch:
@@ -238,6 +368,16 @@ func (m AppModel) View() string {
 		return m.viewComparisonInput()
 	case StateComparison:
 		return m.viewComparison()
+	case StateHeadToHead:
+		return m.viewHeadToHead()
+	case StateMapStats:
+		return m.viewMapStats()
+	case StateSessions:
+		return m.viewSessions()
+	case StateTeammates:
+		return m.viewTeammates()
+	case StateWatch:
+		return m.viewWatch()
 	case StateLoading:
 		return m.renderLoadingScreen()
 	case StateError:
@@ -246,38 +386,3 @@ func (m AppModel) View() string {
 		return "Unknown state"
 	}
 }
-
-// Additional styling constants that are used in views
-var (
-	searchStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FAFAFA")).
-			Background(lipgloss.Color("#7D56F4")).
-			Padding(1, 2)
-
-	profileStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FAFAFA")).
-			Padding(1, 0)
-
-	matchesStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#874BFD")).
-			Padding(1, 2)
-
-	statsStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#874BFD")).
-			Padding(1, 2)
-
-	helpStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#626262")).
-			Italic(true)
-
-
-	winStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#04B575")).
-			Bold(true)
-
-	lossStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FF5F87")).
-			Bold(true)
-)
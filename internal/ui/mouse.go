@@ -0,0 +1,145 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// wheelLines is the number of lines a mouse wheel step scrolls
+const wheelLines = 3
+
+// updateMouse handles mouse events. The wheel scrolls or moves the
+// selection; clicks select rows, open profiles and change pages.
+func (m AppModel) updateMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if m.loading || m.palette.open || m.showHelp || m.typing() || msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		return m.wheel(-1), nil
+	case tea.MouseButtonWheelDown:
+		return m.wheel(1), nil
+	case tea.MouseButtonLeft:
+		return m.click(msg.X, msg.Y)
+	}
+	return m, nil
+}
+
+// wheel scrolls the scrollable screens and moves the selection of the
+// matches screen, following it across pages
+func (m AppModel) wheel(direction int) AppModel {
+	switch {
+	case m.state.scrollable():
+		return m.scrollBy(direction * wheelLines)
+	case m.state == StateMatches:
+		visible := m.visibleMatches()
+		if len(visible) == 0 {
+			return m
+		}
+		m.selectedMatchIndex = max(0, min(m.selectedMatchIndex+direction, len(visible)-1))
+		m.currentPage = m.selectedMatchIndex/m.matchesPerPage + 1
+	}
+	return m
+}
+
+// click handles a click at a cell of the terminal
+func (m AppModel) click(x, y int) (tea.Model, tea.Cmd) {
+	lines := screenLines(m.View())
+	if y < 0 || y >= len(lines) {
+		return m, nil
+	}
+	switch m.state {
+	case StateMatches:
+		return m.clickMatches(lines, x, y)
+	case StateMatchStats, StatePlayerMatchDetail:
+		return m.clickScoreboard(lines[y], x)
+	}
+	return m, nil
+}
+
+// clickMatches selects the clicked match, opening it when it was already
+// selected, or follows the links of the pagination line
+func (m AppModel) clickMatches(lines []string, x, y int) (tea.Model, tea.Cmd) {
+	line := lines[y]
+	if strings.Contains(line, fmt.Sprintf("Page %d/", m.currentPage)) {
+		previousLink, nextLink := m.keyMap().pageLinks()
+		if inSpan(line, previousLink, x) {
+			return m.turnPage(-1), nil
+		}
+		if inSpan(line, nextLink, x) {
+			return m.turnPage(1), nil
+		}
+		return m, nil
+	}
+
+	visible := m.visibleMatches()
+	start := (m.currentPage - 1) * m.matchesPerPage
+	end := min(start+m.matchesPerPage, len(visible))
+	if start >= end {
+		return m, nil
+	}
+	// Rows are found below the table header, which is unique on screen
+	table := screenLines(m.renderMatchTable(visible[start:end], start))
+	header := strings.TrimSpace(table[0])
+	stride := 2
+	if m.compactMatches {
+		stride = 1
+	}
+	for i, screenLine := range lines {
+		if !strings.Contains(screenLine, header) {
+			continue
+		}
+		offset := y - i - 2
+		if offset < 0 || offset%stride != 0 || start+offset/stride >= end {
+			return m, nil
+		}
+		index := start + offset/stride
+		if index == m.selectedMatchIndex {
+			return m.openMatchDetail()
+		}
+		m.selectedMatchIndex = index
+		return m, nil
+	}
+	return m, nil
+}
+
+// clickScoreboard selects the clicked player of a scoreboard, opening
+// their profile when the click is on the nickname
+func (m AppModel) clickScoreboard(line string, x int) (tea.Model, tea.Cmd) {
+	for i, player := range m.scoreboardPlayers() {
+		name, values := scoreboardRow(player)
+		if !strings.Contains(line, name+values) {
+			continue
+		}
+		m.scoreboardSelected = i
+		if start := spanStart(line, name+values); x < start || x >= start+ansi.StringWidth(player.Nickname) {
+			return m, nil
+		}
+		return m.openScoreboardPlayer()
+	}
+	return m, nil
+}
+
+// screenLines splits rendered output into lines without styling, as they
+// appear in the terminal
+func screenLines(view string) []string {
+	return strings.Split(ansi.Strip(view), "\n")
+}
+
+// spanStart returns the terminal column where text starts in a line, or -1
+func spanStart(line, text string) int {
+	i := strings.Index(line, text)
+	if i < 0 {
+		return -1
+	}
+	return ansi.StringWidth(line[:i])
+}
+
+// inSpan reports whether column x is on text in a line
+func inSpan(line, text string, x int) bool {
+	start := spanStart(line, text)
+	return start >= 0 && x >= start && x < start+ansi.StringWidth(text)
+}
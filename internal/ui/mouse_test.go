@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func click(x, y int) tea.MouseMsg {
+	return tea.MouseMsg{X: x, Y: y, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress}
+}
+
+// findOnScreen returns the cell where text is shown
+func findOnScreen(t *testing.T, m AppModel, text string) (int, int) {
+	t.Helper()
+	for y, line := range screenLines(m.View()) {
+		if x := spanStart(line, text); x >= 0 {
+			return x, y
+		}
+	}
+	t.Fatalf("%q is not on screen", text)
+	return 0, 0
+}
+
+func TestClickMatches(t *testing.T) {
+	m := filterTestModel()
+	m.width, m.height = 120, 40
+
+	// Rows are two lines apart outside compact mode
+	x, y := findOnScreen(t, m, "▶")
+	m = sendMsg(m, click(x+4, y+6))
+	if m.selectedMatchIndex != 3 || m.state != StateMatches {
+		t.Fatalf("Expected match 3 selected, got %d", m.selectedMatchIndex)
+	}
+	m = sendMsg(m, click(x+4, y+5))
+	if m.selectedMatchIndex != 3 {
+		t.Errorf("Expected a click between rows to be ignored, got %d", m.selectedMatchIndex)
+	}
+
+	// Clicking the selected match opens it
+	m = sendMsg(m, click(x+4, y+6))
+	if m.state != StateLoading || len(m.history) != 1 {
+		t.Errorf("Expected match 3 to open from the matches screen, got state %v", m.state)
+	}
+}
+
+func TestClickPagination(t *testing.T) {
+	m := filterTestModel()
+	m.width, m.height = 120, 40
+
+	m = sendMsg(m, click(findOnScreen(t, m, "Next (→/L)")))
+	if m.currentPage != 2 || m.selectedMatchIndex != 10 {
+		t.Fatalf("Expected page 2, got %d", m.currentPage)
+	}
+	m = sendMsg(m, click(findOnScreen(t, m, "Previous (←/H)")))
+	if m.currentPage != 1 || m.selectedMatchIndex != 0 {
+		t.Errorf("Expected page 1, got %d", m.currentPage)
+	}
+
+	// The links show, and follow, rebound page keys
+	keys, err := NewKeyMap(map[string][]string{"right": {"n"}, "left": {"p"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m = m.WithKeyMap(keys)
+	m = sendMsg(m, click(findOnScreen(t, m, "Next (N)")))
+	if m.currentPage != 2 {
+		t.Fatalf("Expected page 2 with rebound keys, got %d", m.currentPage)
+	}
+	m = sendMsg(m, click(findOnScreen(t, m, "Previous (P)")))
+	if m.currentPage != 1 {
+		t.Errorf("Expected page 1 with rebound keys, got %d", m.currentPage)
+	}
+
+	// The wheel moves the selection across pages
+	for i := 0; i < 10; i++ {
+		m = sendMsg(m, wheel(tea.MouseButtonWheelDown))
+	}
+	if m.currentPage != 2 || m.selectedMatchIndex != 10 {
+		t.Errorf("Expected match 10 on page 2, got %d on page %d", m.selectedMatchIndex, m.currentPage)
+	}
+}
+
+func TestClickScoreboard(t *testing.T) {
+	m := scoreboardTestModel()
+	m.height = 60
+
+	// Clicking the values selects the row
+	x, y := findOnScreen(t, m, "bravo2")
+	m = sendMsg(m, click(x+20, y))
+	if m.scoreboardSelected != 7 || m.state != StateMatchStats {
+		t.Fatalf("Expected bravo2 selected, got row %d", m.scoreboardSelected)
+	}
+	if _, selected := findOnScreen(t, m, "▶"); selected != y {
+		t.Errorf("Expected the marker on line %d, got %d", y, selected)
+	}
+
+	// Clicking the nickname opens the profile
+	m = sendMsg(m, click(x+1, y))
+	if m.state != StateLoading || m.searchInput != "bravo2" || len(m.history) != 1 {
+		t.Errorf("Expected bravo2's profile to load, got state %v", m.state)
+	}
+}
+
+func TestMouseIgnoredInOverlays(t *testing.T) {
+	m := filterTestModel()
+	m.width, m.height = 120, 40
+	m.showHelp = true
+	m = sendMsg(m, wheel(tea.MouseButtonWheelDown))
+	if m.selectedMatchIndex != 0 {
+		t.Error("Expected the wheel to be ignored with the help open")
+	}
+	if !strings.Contains(m.View(), "Keys") {
+		t.Error("Expected the help to stay open")
+	}
+}
@@ -0,0 +1,192 @@
+package ui
+
+import (
+	"github.com/armitageee/faceit-cli/internal/analytics"
+	"github.com/armitageee/faceit-cli/internal/entity"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxHistory is the number of screens Back can return through
+const maxHistory = 50
+
+// screen is an entry of the navigation history: a screen and what is
+// needed to show it again the way it was left
+type screen struct {
+	state AppState
+	// Player and match list the screen belongs to
+	player            *entity.PlayerProfile
+	gameID            string
+	lifetimeStats     *entity.PlayerStats
+	matches           []entity.PlayerMatchSummary
+	matchFilter       matchFilter
+	matchSortField    matchSortField
+	matchSortReversed bool
+	// Pagination and selection
+	currentPage            int
+	selectedMatchID        string
+	comparisonSelected     int
+	selectedSessionIndex   int
+	frequencyOpponents     bool
+	selectedFrequencyIndex int
+	watchSelected          int
+	scoreboardSelected     int
+	scroll                 int
+	// Data shown by the screen
+	stats               *PlayerStatsSummary
+	matchDetail         *MatchDetail
+	matchStats          *entity.MatchStats
+	selectedPlayerMatch *entity.PlayerMatchSummary
+	playerMatchStats    *entity.MatchStats
+	comparison          *PlayerComparison
+	headToHead          *HeadToHead
+	mapStats            []MapPerformance
+	sessions            []PlaySession
+	frequencies         *analytics.Frequencies
+}
+
+// inHistory reports whether a state is recorded in the navigation history.
+// Text inputs, loading and errors are passed through on the way to another
+// screen, so Back skips them.
+func (s AppState) inHistory() bool {
+	switch s {
+	case StateLoading, StateError, StateMatchSearch, StatePlayerSwitch, StateComparisonInput:
+		return false
+	}
+	return true
+}
+
+// currentScreen captures the current screen for the navigation history
+func (m AppModel) currentScreen() screen {
+	return screen{
+		state:                  m.state,
+		player:                 m.player,
+		gameID:                 m.gameID,
+		lifetimeStats:          m.lifetimeStats,
+		matches:                m.matches,
+		matchFilter:            m.matchFilter,
+		matchSortField:         m.matchSortField,
+		matchSortReversed:      m.matchSortReversed,
+		currentPage:            m.currentPage,
+		selectedMatchID:        m.selectedMatchID(),
+		comparisonSelected:     m.comparisonSelected,
+		selectedSessionIndex:   m.selectedSessionIndex,
+		frequencyOpponents:     m.frequencyOpponents,
+		selectedFrequencyIndex: m.selectedFrequencyIndex,
+		watchSelected:          m.watchSelected,
+		scoreboardSelected:     m.scoreboardSelected,
+		scroll:                 m.scroll,
+		stats:                  m.stats,
+		matchDetail:            m.matchDetail,
+		matchStats:             m.matchStats,
+		selectedPlayerMatch:    m.selectedPlayerMatch,
+		playerMatchStats:       m.playerMatchStats,
+		comparison:             m.comparison,
+		headToHead:             m.headToHead,
+		mapStats:               m.mapStats,
+		sessions:               m.sessions,
+		frequencies:            m.frequencies,
+	}
+}
+
+// restoreScreen shows a screen of the navigation history again
+func (m *AppModel) restoreScreen(s screen) {
+	samePlayer := m.player != nil && s.player != nil && m.player.ID == s.player.ID && m.gameID == s.gameID
+	// Matches loaded in the background since the screen was left are kept
+	if !samePlayer || len(m.matches) < len(s.matches) {
+		m.matches = s.matches
+	}
+	m.state = s.state
+	m.player = s.player
+	m.gameID = s.gameID
+	m.lifetimeStats = s.lifetimeStats
+	m.matchFilter = s.matchFilter
+	m.matchSortField = s.matchSortField
+	m.matchSortReversed = s.matchSortReversed
+	m.currentPage = s.currentPage
+	m.selectedMatchIndex = (s.currentPage - 1) * m.matchesPerPage
+	if s.selectedMatchID != "" {
+		m.selectMatch(s.selectedMatchID)
+	}
+	m.comparisonSelected = s.comparisonSelected
+	m.selectedSessionIndex = s.selectedSessionIndex
+	m.frequencyOpponents = s.frequencyOpponents
+	m.selectedFrequencyIndex = s.selectedFrequencyIndex
+	m.watchSelected = s.watchSelected
+	m.scoreboardSelected = s.scoreboardSelected
+	m.scroll = s.scroll
+	m.stats = s.stats
+	m.matchDetail = s.matchDetail
+	m.matchStats = s.matchStats
+	m.selectedPlayerMatch = s.selectedPlayerMatch
+	m.playerMatchStats = s.playerMatchStats
+	m.comparison = s.comparison
+	m.headToHead = s.headToHead
+	m.mapStats = s.mapStats
+	m.sessions = s.sessions
+	m.frequencies = s.frequencies
+}
+
+// recordHistory adds the screen a key or mouse event left to the
+// navigation history of the resulting model. Keys going back are not
+// recorded.
+func (m AppModel) recordHistory(msg tea.Msg, next AppModel) AppModel {
+	if next.state == m.state || !m.state.inHistory() {
+		return next
+	}
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.isBackKey(keyMsg) {
+		return next
+	}
+	// Leaving the dashboard stops polling
+	if m.state == StateWatch {
+		next.watchPolling = false
+	}
+	history := append([]screen(nil), m.history...)
+	history = append(history, m.currentScreen())
+	if len(history) > maxHistory {
+		history = history[len(history)-maxHistory:]
+	}
+	next.history = history
+	return next
+}
+
+// isBackKey reports whether a key goes back on the current screen. Screens
+// without a text input go back on delete as well.
+func (m AppModel) isBackKey(msg tea.KeyMsg) bool {
+	k := m.keyMap()
+	return key.Matches(msg, k.Back) || !m.typing() && m.state != StateSearch && key.Matches(msg, k.Delete)
+}
+
+// back returns to the previous screen of the navigation history, or to
+// fallback when there is none
+func (m AppModel) back(fallback AppState) (tea.Model, tea.Cmd) {
+	m.error = ""
+	if len(m.history) == 0 {
+		if fallback == StateProfile && m.player == nil {
+			fallback = StateSearch
+		}
+		m.state = fallback
+	} else {
+		previous := m.history[len(m.history)-1]
+		m.history = m.history[:len(m.history)-1]
+		m.restoreScreen(previous)
+	}
+
+	switch m.state {
+	case StateSearch:
+		m.searchInput = ""
+	case StateWatch:
+		// Resume polling the dashboard
+		if m.watcher != nil && !m.watchPolling {
+			m.watchPolling = true
+			return m.pollWatchNow()
+		}
+	}
+	return m, nil
+}
+
+// backHelpEntry formats the keys going back on screens without a text input
+func backHelpEntry(k *KeyMap) string {
+	return k.Back.Help().Key + "/" + k.Delete.Help().Key + " - " + k.Back.Help().Desc
+}
@@ -0,0 +1,114 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/armitageee/faceit-cli/internal/analytics"
+	"github.com/armitageee/faceit-cli/internal/config"
+	"github.com/armitageee/faceit-cli/internal/entity"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sendMsg passes a message other than a key to the model
+func sendMsg(m AppModel, msg tea.Msg) AppModel {
+	updated, _ := m.Update(msg)
+	return updated.(AppModel)
+}
+
+func TestBackRestoresSelection(t *testing.T) {
+	m := filterTestModel()
+	m = typeKeys(m, "right", "down", "down", "down")
+	if m.currentPage != 2 || m.selectedMatchIndex != 13 {
+		t.Fatalf("Expected match 13 on page 2, got %d on page %d", m.selectedMatchIndex, m.currentPage)
+	}
+
+	m = typeKeys(m, "enter")
+	if m.state != StateLoading || len(m.history) != 1 {
+		t.Fatalf("Expected the matches screen in the history, got %d entries", len(m.history))
+	}
+	m = sendMsg(m, matchDetailLoadedMsg{matchDetail: MatchDetail{MatchID: "m13"}})
+	if m.state != StateMatchDetail {
+		t.Fatalf("Expected the match detail, got state %v", m.state)
+	}
+
+	// Moving away and back keeps the position on the matches screen
+	m.currentPage, m.selectedMatchIndex = 1, 0
+	m = typeKeys(m, "esc")
+	if m.state != StateMatches || m.currentPage != 2 || m.selectedMatchIndex != 13 || len(m.history) != 0 {
+		t.Errorf("Expected match 13 on page 2 again, got %d on page %d", m.selectedMatchIndex, m.currentPage)
+	}
+
+	// Without history Back goes to the screen's parent
+	m = typeKeys(m, "backspace")
+	if m.state != StateProfile {
+		t.Errorf("Expected the profile, got state %v", m.state)
+	}
+}
+
+func TestBackRestoresPreviousPlayer(t *testing.T) {
+	m := AppModel{
+		state:  StateTeammates,
+		config: &config.Config{},
+		player: &entity.PlayerProfile{ID: "1", Nickname: "alice"},
+		frequencies: &analytics.Frequencies{Teammates: []analytics.PlayerFrequency{
+			{Nickname: "bob"}, {Nickname: "carol"},
+		}},
+	}
+	m = typeKeys(m, "down", "enter")
+	m = sendMsg(m, profileLoadedMsg{profile: entity.PlayerProfile{ID: "3", Nickname: "carol"}})
+	if m.state != StateProfile || m.player.Nickname != "carol" {
+		t.Fatalf("Expected carol's profile, got state %v", m.state)
+	}
+
+	m = typeKeys(m, "esc")
+	if m.state != StateTeammates || m.player.Nickname != "alice" || m.selectedFrequencyIndex != 1 {
+		t.Errorf("Expected alice's teammates with carol selected, got %s at %d", m.player.Nickname, m.selectedFrequencyIndex)
+	}
+}
+
+func TestBackFromTextInputs(t *testing.T) {
+	// Text inputs return to the screen they were opened from
+	m := AppModel{state: StateSearch, config: &config.Config{}}
+	m = typeKeys(m, "1", "esc")
+	if m.state != StateSearch {
+		t.Errorf("Expected the search screen, got state %v", m.state)
+	}
+
+	// A failed load goes back past the input to the screen before it
+	m = typeKeys(m, "2", "1-abc", "enter")
+	m = sendMsg(m, errorMsg{err: "not found"})
+	m = typeKeys(m, "esc")
+	if m.state != StateSearch || m.error != "" || len(m.history) != 0 {
+		t.Errorf("Expected the search screen, got state %v", m.state)
+	}
+}
+
+func TestStatsReloadIsNotRecorded(t *testing.T) {
+	m := filterTestModel()
+	m.state = StateStats
+	m = typeKeys(m, "w")
+	if !m.loading || len(m.history) != 0 {
+		t.Errorf("Expected a new window not to be recorded, got %d entries", len(m.history))
+	}
+}
+
+func TestQuitIsConsistent(t *testing.T) {
+	for _, state := range []AppState{StateProfile, StateMatches, StateMatchDetail, StateMatchStats, StatePlayerMatchDetail, StateError} {
+		m := filterTestModel()
+		m.state = state
+		if _, cmd := m.Update(keyMsg("q")); cmd == nil {
+			t.Errorf("Expected q to quit in state %v", state)
+		}
+	}
+
+	// Only quitting is possible while loading
+	m := filterTestModel()
+	m.state, m.loading = StateLoading, true
+	if typeKeys(m, "esc").state != StateLoading {
+		t.Error("Expected Esc to be ignored while loading")
+	}
+	if _, cmd := m.Update(keyMsg("q")); cmd == nil {
+		t.Error("Expected q to quit while loading")
+	}
+}
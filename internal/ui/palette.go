@@ -0,0 +1,361 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/armitageee/faceit-cli/internal/entity"
+	"github.com/armitageee/faceit-cli/internal/query"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxPaletteRows is the number of matching commands the palette shows
+const maxPaletteRows = 10
+
+// commandPalette is the state of the command palette opened with ":"
+type commandPalette struct {
+	open     bool
+	input    string
+	selected int
+	command  *paletteCommand // command waiting for its argument
+	err      string
+	notice   string // result of the last command, e.g. an export
+}
+
+// paletteCommand is an action of the command palette. Commands with a
+// prompt ask for an argument before they run; an error keeps the palette
+// open on the argument.
+type paletteCommand struct {
+	title     string
+	prompt    string
+	available func(m AppModel) bool // nil when always available
+	run       func(m AppModel, arg string) (tea.Model, tea.Cmd, error)
+}
+
+// paletteCommands lists the palette's actions in the order shown for an
+// empty input
+var paletteCommands = []paletteCommand{
+	{title: "Search player…", prompt: "Nickname", run: func(m AppModel, arg string) (tea.Model, tea.Cmd, error) {
+		if m.player != nil {
+			m.addToRecentPlayers(m.player.Nickname)
+		}
+		m.searchInput = arg
+		m.loading = true
+		m.state = StateLoading
+		return m, m.loadPlayerProfile(arg), nil
+	}},
+	{title: "Open match…", prompt: "Match ID", run: func(m AppModel, arg string) (tea.Model, tea.Cmd, error) {
+		m.matchSearchInput = arg
+		m.loading = true
+		m.state = StateLoading
+		return m, m.loadMatchStats(), nil
+	}},
+	{title: "Compare with…", prompt: "Nicknames", available: hasPlayer, run: func(m AppModel, arg string) (tea.Model, tea.Cmd, error) {
+		nicknames, err := parseComparisonNicknames(arg, m.player.Nickname)
+		if err != nil {
+			return m, nil, err
+		}
+		m.comparisonInput = arg
+		m.comparisonError = ""
+		m.loading = true
+		m.state = StateLoading
+		return m, m.loadPlayerComparison(nicknames), nil
+	}},
+	{title: "Switch game…", prompt: "Game", available: hasPlayer, run: func(m AppModel, arg string) (tea.Model, tea.Cmd, error) {
+		return m.switchGame(arg)
+	}},
+	{title: "Export matches…", prompt: "File (.csv, .json or .txt)", available: hasMatches, run: func(m AppModel, arg string) (tea.Model, tea.Cmd, error) {
+		matches := m.visibleMatches()
+		path, err := exportMatches(arg, matches)
+		if err != nil {
+			return m, nil, err
+		}
+		m.palette = commandPalette{open: true, notice: fmt.Sprintf("Exported %d matches to %s", len(matches), path)}
+		return m, nil, nil
+	}},
+	{title: "Recent matches", available: hasPlayer, run: withoutArg(AppModel.openMatches)},
+	{title: "Statistics", available: hasPlayer, run: withoutArg(AppModel.refreshStats)},
+	{title: "Map analytics", available: hasPlayer, run: withoutArg(AppModel.openMapStats)},
+	{title: "Play sessions", available: hasPlayer, run: withoutArg(AppModel.openSessions)},
+	{title: "Teammates & opponents", available: hasPlayer, run: withoutArg(AppModel.openTeammates)},
+	{title: "Watchlist", available: hasPlayer, run: withoutArg(AppModel.openWatchlist)},
+	{title: "Profile", available: hasPlayer, run: func(m AppModel, _ string) (tea.Model, tea.Cmd, error) {
+		m.state = StateProfile
+		return m, nil, nil
+	}},
+	{title: "Search", run: func(m AppModel, _ string) (tea.Model, tea.Cmd, error) {
+		m.state = StateSearch
+		m.searchInput = ""
+		return m, nil, nil
+	}},
+}
+
+// withoutArg adapts a screen action to a palette command
+func withoutArg(action func(AppModel) (tea.Model, tea.Cmd)) func(AppModel, string) (tea.Model, tea.Cmd, error) {
+	return func(m AppModel, _ string) (tea.Model, tea.Cmd, error) {
+		model, cmd := action(m)
+		return model, cmd, nil
+	}
+}
+
+func hasPlayer(m AppModel) bool {
+	return m.player != nil
+}
+
+func hasMatches(m AppModel) bool {
+	return len(m.visibleMatches()) > 0
+}
+
+// fuzzyScore matches pattern against text as a case-insensitive
+// subsequence. Consecutive characters and characters starting a word
+// score higher, so "sw" ranks "Switch game…" above "Play sessions".
+func fuzzyScore(pattern, text string) (int, bool) {
+	p := []rune(strings.ToLower(strings.TrimSpace(pattern)))
+	t := []rune(strings.ToLower(text))
+	score, next, previous := 0, 0, -2
+	for i := 0; i < len(t) && next < len(p); i++ {
+		if t[i] != p[next] {
+			continue
+		}
+		score++
+		if i == previous+1 {
+			score += 2
+		}
+		if i == 0 || !unicode.IsLetter(t[i-1]) && !unicode.IsDigit(t[i-1]) {
+			score += 3
+		}
+		previous = i
+		next++
+	}
+	if next < len(p) {
+		return 0, false
+	}
+	return score, true
+}
+
+// paletteMatches returns the available commands matching the input, best
+// match first
+func (m AppModel) paletteMatches() []paletteCommand {
+	type scored struct {
+		command paletteCommand
+		score   int
+	}
+	var matches []scored
+	for _, command := range paletteCommands {
+		if command.available != nil && !command.available(m) {
+			continue
+		}
+		if score, ok := fuzzyScore(m.palette.input, command.title); ok {
+			matches = append(matches, scored{command, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	commands := make([]paletteCommand, len(matches))
+	for i, match := range matches {
+		commands[i] = match.command
+	}
+	return commands
+}
+
+// updatePalette handles key events while the command palette is open
+func (m AppModel) updatePalette(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	k := m.keyMap()
+	switch {
+	case key.Matches(msg, k.ForceQuit):
+		return m, tea.Quit
+	case key.Matches(msg, k.Back):
+		if m.palette.command != nil {
+			// Back from the argument to the command list
+			m.palette = commandPalette{open: true}
+			return m, nil
+		}
+		m.palette = commandPalette{}
+		return m, nil
+	case key.Matches(msg, k.Select):
+		if command := m.palette.command; command != nil {
+			arg := strings.TrimSpace(m.palette.input)
+			if arg == "" {
+				m.palette.err = "Enter a " + strings.ToLower(command.prompt)
+				return m, nil
+			}
+			return m.runPaletteCommand(*command, arg)
+		}
+		commands := m.paletteMatches()
+		if m.palette.selected >= len(commands) {
+			return m, nil
+		}
+		command := commands[m.palette.selected]
+		if command.prompt != "" {
+			m.palette = commandPalette{open: true, command: &command}
+			return m, nil
+		}
+		return m.runPaletteCommand(command, "")
+	case msg.Type == tea.KeyUp:
+		if m.palette.selected > 0 {
+			m.palette.selected--
+		}
+		return m, nil
+	case msg.Type == tea.KeyDown:
+		if m.palette.selected < min(len(m.paletteMatches()), maxPaletteRows)-1 {
+			m.palette.selected++
+		}
+		return m, nil
+	case key.Matches(msg, k.Delete):
+		if len(m.palette.input) > 0 {
+			m.palette.input = m.palette.input[:len(m.palette.input)-1]
+			m.palette.selected = 0
+		}
+		return m, nil
+	case key.Matches(msg, k.Paste):
+		if content, err := GetClipboardContent(); err == nil && content != "" {
+			m.palette.input += content
+			m.palette.selected = 0
+		}
+		return m, nil
+	default:
+		if len(msg.String()) == 1 || msg.Type == tea.KeySpace {
+			m.palette.input += msg.String()
+			m.palette.selected = 0
+			m.palette.err = ""
+			m.palette.notice = ""
+		}
+		return m, nil
+	}
+}
+
+// runPaletteCommand closes the palette and runs a command, reopening the
+// palette on the command's argument when it fails
+func (m AppModel) runPaletteCommand(command paletteCommand, arg string) (tea.Model, tea.Cmd) {
+	previous := m
+	m.palette = commandPalette{}
+	model, cmd, err := command.run(m, arg)
+	if err != nil {
+		previous.palette.err = err.Error()
+		return previous, nil
+	}
+	return model, cmd
+}
+
+// switchGame loads the current player's matches and statistics of another
+// FACEIT game
+func (m AppModel) switchGame(game string) (tea.Model, tea.Cmd, error) {
+	game = strings.ToLower(game)
+	if _, ok := m.player.Games[game]; !ok {
+		games := make([]string, 0, len(m.player.Games))
+		for name := range m.player.Games {
+			games = append(games, name)
+		}
+		sort.Strings(games)
+		return m, nil, fmt.Errorf("%s has not played %s (games: %s)", m.player.Nickname, game, strings.Join(games, ", "))
+	}
+	if game == defaultGame {
+		game = ""
+	}
+	m.gameID = game
+	// Drop data that belonged to the previous game
+	m.matches = nil
+	m.stats = nil
+	m.lifetimeStats = nil
+	m.matchFilter = matchFilter{}
+	m.selectedMatchIndex = 0
+	m.currentPage = 1
+	m.state = StateProfile
+	return m, m.loadLifetimeStats(), nil
+}
+
+// exportMatches writes matches to a file in the format given by its
+// extension: CSV, JSON or an aligned table. It returns the path written.
+func exportMatches(path string, matches []entity.PlayerMatchSummary) (string, error) {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to find the home directory: %w", err)
+		}
+		path = filepath.Join(home, rest)
+	}
+
+	table, err := query.Run(query.Query{}, matches)
+	if err != nil {
+		return "", err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		err = query.WriteCSV(file, table)
+	case ".json":
+		err = query.WriteJSON(file, table)
+	default:
+		err = query.WriteTable(file, table)
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// viewPalette renders the command palette
+func (m AppModel) viewPalette() string {
+	k := m.keyMap()
+	var content strings.Builder
+
+	if command := m.palette.command; command != nil {
+		content.WriteString(titleStyle.Render(strings.TrimSuffix(command.title, "…")) + "\n\n")
+		content.WriteString(fmt.Sprintf("%s: %s▌\n", command.prompt, m.palette.input))
+	} else {
+		content.WriteString(titleStyle.Render("Command Palette") + "\n\n")
+		content.WriteString(fmt.Sprintf(": %s▌\n\n", m.palette.input))
+		commands := m.paletteMatches()
+		if len(commands) == 0 {
+			content.WriteString(helpStyle.Render("No matching commands") + "\n")
+		}
+		for i, command := range commands {
+			if i == maxPaletteRows {
+				break
+			}
+			if i == m.palette.selected {
+				content.WriteString(playerNameStyle.Render("▶ "+command.title) + "\n")
+			} else {
+				content.WriteString("  " + command.title + "\n")
+			}
+		}
+	}
+
+	if m.palette.err != "" {
+		content.WriteString("\n" + errorStyle.Render(m.palette.err) + "\n")
+	}
+	if m.palette.notice != "" {
+		content.WriteString("\n" + winStyle.Render(m.palette.notice) + "\n")
+	}
+
+	help := helpLine(
+		helpEntry(k.Select, "Run"),
+		helpEntry(k.Back, "Close"),
+		helpEntry(k.ForceQuit, "Quit"),
+	)
+	content.WriteString("\n" + helpStyle.Render(help))
+	return m.placeOverlay(overlayStyle.Render(content.String()))
+}
+
+// placeOverlay centers a box in the terminal when its size is known
+func (m AppModel) placeOverlay(box string) string {
+	if m.width == 0 || m.height == 0 {
+		return box
+	}
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
@@ -0,0 +1,186 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/armitageee/faceit-cli/internal/config"
+	"github.com/armitageee/faceit-cli/internal/entity"
+)
+
+func paletteTitles(commands []paletteCommand) []string {
+	titles := make([]string, len(commands))
+	for i, command := range commands {
+		titles[i] = command.title
+	}
+	return titles
+}
+
+func TestFuzzyScore(t *testing.T) {
+	if _, ok := fuzzyScore("xyz", "Compare with…"); ok {
+		t.Error("Expected no match")
+	}
+	if _, ok := fuzzyScore("", "Compare with…"); !ok {
+		t.Error("Expected an empty pattern to match")
+	}
+	sw, _ := fuzzyScore("sw", "Switch game…")
+	sessions, _ := fuzzyScore("sw", "Show sessions")
+	if sessions >= sw {
+		t.Errorf("Expected a word start to score higher, got %d and %d", sw, sessions)
+	}
+	exp, _ := fuzzyScore("exp", "Export matches…")
+	scattered, _ := fuzzyScore("exp", "Teammates & opponents")
+	if exp <= scattered {
+		t.Errorf("Expected consecutive characters to score higher, got %d and %d", exp, scattered)
+	}
+}
+
+func TestPaletteMatches(t *testing.T) {
+	m := AppModel{config: &config.Config{}}
+	titles := strings.Join(paletteTitles(m.paletteMatches()), ",")
+	if titles != "Search player…,Open match…,Search" {
+		t.Errorf("Expected only commands without a player, got %s", titles)
+	}
+
+	m = filterTestModel()
+	m.palette.input = "cmp"
+	if titles := paletteTitles(m.paletteMatches()); len(titles) == 0 || titles[0] != "Compare with…" {
+		t.Errorf("Expected Compare with… first, got %v", titles)
+	}
+}
+
+func TestPaletteRunsCommands(t *testing.T) {
+	m := typeKeys(filterTestModel(), ":")
+	if !m.palette.open {
+		t.Fatal("Expected : to open the palette")
+	}
+	if view := m.View(); !strings.Contains(view, "Command Palette") || !strings.Contains(view, "Export matches…") {
+		t.Errorf("Expected the command list:\n%s", view)
+	}
+
+	// Letters are typed into the palette instead of reaching the screen
+	m = typeKeys(m, "map an")
+	if m.palette.input != "map an" || m.compactMatches {
+		t.Fatalf("Expected the input to be typed, got %q", m.palette.input)
+	}
+	m = typeKeys(m, "enter")
+	if m.palette.open || m.state != StateLoading {
+		t.Errorf("Expected map analytics to load, got state %v", m.state)
+	}
+
+	// Esc closes the palette without running anything
+	m = typeKeys(filterTestModel(), ":", "comp", "esc")
+	if m.palette.open || m.state != StateMatches {
+		t.Error("Expected Esc to close the palette")
+	}
+}
+
+func TestPaletteArguments(t *testing.T) {
+	m := typeKeys(filterTestModel(), ":", "compare", "enter")
+	if m.palette.command == nil || m.palette.command.title != "Compare with…" {
+		t.Fatal("Expected Compare with… to ask for nicknames")
+	}
+	if !strings.Contains(m.View(), "Nicknames: ▌") {
+		t.Errorf("Expected the argument prompt:\n%s", m.View())
+	}
+
+	// An invalid argument keeps the palette open with the error
+	m = typeKeys(m, "alice", "enter")
+	if !m.palette.open || m.palette.err != "enter at least one other player" {
+		t.Errorf("Expected the comparison error, got %q", m.palette.err)
+	}
+
+	// Esc goes back to the command list
+	m = typeKeys(m, "esc")
+	if !m.palette.open || m.palette.command != nil || m.palette.input != "" {
+		t.Error("Expected Esc to go back to the command list")
+	}
+
+	m = typeKeys(m, "compare", "enter", "bob", "enter")
+	if m.palette.open || m.state != StateLoading || m.comparisonInput != "bob" {
+		t.Errorf("Expected the comparison to load, got state %v", m.state)
+	}
+}
+
+func TestPaletteSwitchGame(t *testing.T) {
+	m := filterTestModel()
+	m.player = &entity.PlayerProfile{Nickname: "alice", Games: map[string]entity.GameDetail{
+		"cs2":  {Elo: 2100},
+		"csgo": {Elo: 1800},
+	}}
+	m.lifetimeStats = &entity.PlayerStats{}
+
+	m = typeKeys(m, ":", "switch game", "enter", "dota2", "enter")
+	if m.palette.err != "alice has not played dota2 (games: cs2, csgo)" {
+		t.Errorf("Expected the unknown game error, got %q", m.palette.err)
+	}
+
+	m = typeKeys(m, "esc", "switch game", "enter", "CSGO", "enter")
+	if m.game() != "csgo" || m.state != StateProfile || m.matches != nil || m.lifetimeStats != nil {
+		t.Fatalf("Expected csgo data to be loaded, got game %s", m.game())
+	}
+	if view := m.viewProfile(); !strings.Contains(view, "CSGO Stats") || !strings.Contains(view, "1800") {
+		t.Errorf("Expected the csgo profile:\n%s", view)
+	}
+
+	m = typeKeys(m, ":", "switch game", "enter", "cs2", "enter")
+	if m.gameID != "" {
+		t.Errorf("Expected the default game, got %s", m.gameID)
+	}
+}
+
+func TestPaletteExport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "matches.csv")
+	m := typeKeys(filterTestModel(), "/", "ancient", "enter", ":", "export", "enter", path, "enter")
+	if !m.palette.open || m.palette.notice != "Exported 5 matches to "+path {
+		t.Fatalf("Expected the export notice, got %q %q", m.palette.notice, m.palette.err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 6 || !strings.Contains(lines[1], "m0") || !strings.Contains(lines[1], "de_ancient") {
+		t.Errorf("Expected the header and the 5 filtered matches:\n%s", data)
+	}
+
+	m = typeKeys(m, "export", "enter", filepath.Join(t.TempDir(), "missing", "x.json"), "enter")
+	if !strings.HasPrefix(m.palette.err, "failed to create") {
+		t.Errorf("Expected a create error, got %q", m.palette.err)
+	}
+}
+
+func TestHelpOverlay(t *testing.T) {
+	m := typeKeys(filterTestModel(), "?")
+	if !m.showHelp {
+		t.Fatal("Expected ? to open the help overlay")
+	}
+	view := m.View()
+	for _, want := range []string{"Keys: Matches", "Match stats", "Reverse", "Command palette"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in the help overlay:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "Next window") {
+		t.Error("Expected only the bindings of the matches screen")
+	}
+
+	// Screen keys are ignored while the overlay is open
+	m = typeKeys(m, "c")
+	if m.compactMatches {
+		t.Error("Expected C to be ignored")
+	}
+	m = typeKeys(m, "?")
+	if m.showHelp {
+		t.Error("Expected ? to close the help overlay")
+	}
+
+	// ? is typed while a text input has focus
+	m = typeKeys(m, "/", "?")
+	if m.showHelp || m.matchFilterInput != "?" {
+		t.Error("Expected ? to be typed in the filter bar")
+	}
+}
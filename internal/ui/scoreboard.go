@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/armitageee/faceit-cli/internal/entity"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// scoreboardPlayers returns the players of the current scoreboard in the
+// order shown, team 1 first
+func (m AppModel) scoreboardPlayers() []entity.PlayerMatchStats {
+	stats := m.matchStats
+	if m.state == StatePlayerMatchDetail {
+		stats = m.playerMatchStats
+	}
+	if stats == nil {
+		return nil
+	}
+	players := append([]entity.PlayerMatchStats(nil), stats.Team1.Players...)
+	return append(players, stats.Team2.Players...)
+}
+
+// selectedScoreboardPlayer returns the selected player of the scoreboard
+func (m AppModel) selectedScoreboardPlayer() (entity.PlayerMatchStats, bool) {
+	players := m.scoreboardPlayers()
+	if m.scoreboardSelected < 0 || m.scoreboardSelected >= len(players) {
+		return entity.PlayerMatchStats{}, false
+	}
+	return players[m.scoreboardSelected], true
+}
+
+// canCompareScoreboardPlayer reports whether the selected player can be
+// compared with the loaded player, who must not be the same one
+func (m AppModel) canCompareScoreboardPlayer() bool {
+	player, ok := m.selectedScoreboardPlayer()
+	if !ok || m.player == nil {
+		return false
+	}
+	if player.PlayerID != "" {
+		return player.PlayerID != m.player.ID
+	}
+	return !strings.EqualFold(player.Nickname, m.player.Nickname)
+}
+
+// updateScoreboard handles the keys of the scoreboards: moving the
+// selection, scrolling, and opening or comparing the selected player
+func (m AppModel) updateScoreboard(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	k := m.keyMap()
+	switch {
+	case key.Matches(msg, k.Up):
+		if m.scoreboardSelected > 0 {
+			m.scoreboardSelected--
+		}
+		return m.scrollToSelection(), nil
+	case key.Matches(msg, k.Down):
+		if m.scoreboardSelected < len(m.scoreboardPlayers())-1 {
+			m.scoreboardSelected++
+		}
+		return m.scrollToSelection(), nil
+	case key.Matches(msg, k.PageUp):
+		return m.scrollBy(-m.pageLines()), nil
+	case key.Matches(msg, k.PageDown):
+		return m.scrollBy(m.pageLines()), nil
+	case key.Matches(msg, k.Select):
+		return m.openScoreboardPlayer()
+	case key.Matches(msg, k.Compare):
+		return m.compareScoreboardPlayer()
+	}
+	return m, nil
+}
+
+// openScoreboardPlayer loads the profile of the selected player by ID
+func (m AppModel) openScoreboardPlayer() (tea.Model, tea.Cmd) {
+	player, ok := m.selectedScoreboardPlayer()
+	if !ok {
+		return m, nil
+	}
+	if m.player != nil {
+		m.addToRecentPlayers(m.player.Nickname)
+	}
+	m.searchInput = player.Nickname
+	m.loading = true
+	m.state = StateLoading
+	return m, m.loadKnownPlayer(player.PlayerID, player.Nickname)
+}
+
+// compareScoreboardPlayer compares the loaded player with the selected one
+func (m AppModel) compareScoreboardPlayer() (tea.Model, tea.Cmd) {
+	if !m.canCompareScoreboardPlayer() {
+		return m, nil
+	}
+	player, _ := m.selectedScoreboardPlayer()
+	m.comparisonInput = player.Nickname
+	m.comparisonError = ""
+	m.loading = true
+	m.state = StateLoading
+	return m, m.loadComparison([]comparisonTarget{{nickname: player.Nickname, playerID: player.PlayerID}})
+}
@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/armitageee/faceit-cli/internal/analytics"
+	"github.com/armitageee/faceit-cli/internal/entity"
+	"github.com/armitageee/faceit-cli/internal/logger"
+	"github.com/armitageee/faceit-cli/internal/repository"
+	"github.com/armitageee/faceit-cli/internal/watch"
+)
+
+// idRepository serves profiles by ID only, failing on nickname searches
+type idRepository struct {
+	repository.FaceitRepository
+	t *testing.T
+}
+
+func (r idRepository) GetPlayerByNickname(ctx context.Context, nickname string) (*entity.PlayerProfile, error) {
+	r.t.Errorf("Unexpected search for %s", nickname)
+	return nil, repository.ErrPlayerNotFound
+}
+
+func (r idRepository) GetPlayerByID(ctx context.Context, playerID string) (*entity.PlayerProfile, error) {
+	return &entity.PlayerProfile{ID: playerID, Nickname: "nick-" + playerID}, nil
+}
+
+func (r idRepository) GetPlayerRecentMatches(ctx context.Context, playerID string, gameID string, limit int) ([]entity.PlayerMatchSummary, error) {
+	return []entity.PlayerMatchSummary{{MatchID: "m-" + playerID, Map: "de_nuke", Kills: 20, Deaths: 10}}, nil
+}
+
+func scoreboardRepoModel(t *testing.T) AppModel {
+	m := scoreboardTestModel()
+	m.repo = idRepository{t: t}
+	m.logger, _ = logger.New(logger.Config{Level: logger.LogLevelInfo, ServiceName: "test"})
+	return m
+}
+
+func TestScoreboardOpensProfileByID(t *testing.T) {
+	m := scoreboardRepoModel(t)
+	for i := 0; i < 7; i++ {
+		m = typeKeys(m, "down")
+	}
+	if _, y := findOnScreen(t, m, "bravo2"); !strings.HasPrefix(strings.TrimSpace(screenLines(m.View())[y]), "▶") {
+		t.Error("Expected bravo2 selected and visible")
+	}
+
+	updated, cmd := m.Update(keyMsg("enter"))
+	m = updated.(AppModel)
+	if m.state != StateLoading || cmd == nil {
+		t.Fatalf("Expected the profile to load, got state %v", m.state)
+	}
+	m = sendMsg(m, cmd())
+	if m.state != StateProfile || m.player.ID != "bravo-2" {
+		t.Fatalf("Expected bravo-2's profile, got state %v", m.state)
+	}
+
+	// Back returns to the scoreboard with the player selected
+	m = typeKeys(m, "esc")
+	if m.state != StateMatchStats || m.scoreboardSelected != 7 {
+		t.Errorf("Expected the scoreboard with row 7 selected, got state %v at %d", m.state, m.scoreboardSelected)
+	}
+}
+
+func TestScoreboardQuickCompare(t *testing.T) {
+	m := scoreboardRepoModel(t)
+	m.player = &entity.PlayerProfile{ID: "alpha-0", Nickname: "alpha0"}
+	if !strings.Contains(m.View(), "C - Compare with alpha0") {
+		t.Error("Expected the compare key in the help line")
+	}
+
+	// The loaded player is not compared with themselves
+	if _, cmd := m.Update(keyMsg("c")); cmd != nil {
+		t.Error("Expected no comparison of alpha0 with themselves")
+	}
+
+	m = typeKeys(m, "down", "down", "down", "down", "down")
+	updated, cmd := m.Update(keyMsg("c"))
+	m = updated.(AppModel)
+	if m.state != StateLoading || cmd == nil {
+		t.Fatalf("Expected the comparison to load, got state %v", m.state)
+	}
+	m = sendMsg(m, cmd())
+	if m.state != StateComparison || len(m.comparison.Players) != 2 || m.comparison.Players[1].Nickname != "bravo0" {
+		t.Fatalf("Expected alpha0 compared with bravo0, got state %v", m.state)
+	}
+
+	m = typeKeys(m, "esc")
+	if m.state != StateMatchStats || m.scoreboardSelected != 5 {
+		t.Errorf("Expected the scoreboard again, got state %v", m.state)
+	}
+}
+
+func TestTeammatesAndWatchlistOpenProfileByID(t *testing.T) {
+	m := scoreboardRepoModel(t)
+	m.state = StateTeammates
+	m.frequencies = &analytics.Frequencies{Teammates: []analytics.PlayerFrequency{{PlayerID: "mate-1", Nickname: "old-name"}}}
+	_, cmd := m.Update(keyMsg("enter"))
+	if cmd == nil {
+		t.Fatal("Expected the teammate's profile to load")
+	}
+	if msg, ok := cmd().(profileLoadedMsg); !ok || msg.profile.ID != "mate-1" {
+		t.Errorf("Expected mate-1's profile, got %#v", msg)
+	}
+
+	m.state = StateWatch
+	m.watchStatuses = []watch.PlayerStatus{{Nickname: "friend", PlayerID: "friend-1"}}
+	_, cmd = m.Update(keyMsg("enter"))
+	if cmd == nil {
+		t.Fatal("Expected the watched player's profile to load")
+	}
+	if msg, ok := cmd().(profileLoadedMsg); !ok || msg.profile.ID != "friend-1" {
+		t.Errorf("Expected friend-1's profile, got %#v", msg)
+	}
+}
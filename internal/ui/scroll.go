@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// scrollable reports whether a state shows its content in a viewport that
+// scrolls when the terminal is too short
+func (s AppState) scrollable() bool {
+	switch s {
+	case StateMatchStats, StatePlayerMatchDetail, StateComparison:
+		return true
+	}
+	return false
+}
+
+// withLogo joins the parts of a screen vertically, with the ASCII logo on
+// top when the terminal is tall enough to show it
+func (m AppModel) withLogo(parts ...string) string {
+	body := lipgloss.JoinVertical(lipgloss.Center, parts...)
+	logo := generateASCIILogo()
+	if m.height > 0 && lipgloss.Height(logo)+lipgloss.Height(body) > m.height {
+		return body
+	}
+	return lipgloss.JoinVertical(lipgloss.Center, logo, body)
+}
+
+// scrollParts returns the title, content and help of a scrollable screen
+func (m AppModel) scrollParts() (title, content, help string) {
+	switch m.state {
+	case StateMatchStats:
+		return m.scoreboardParts(m.matchStats)
+	case StatePlayerMatchDetail:
+		return m.scoreboardParts(m.playerMatchStats)
+	case StateComparison:
+		return m.comparisonParts()
+	}
+	return "", "", ""
+}
+
+// scrollHeight returns the number of content lines shown between the
+// title and help of a scrollable screen
+func (m AppModel) scrollHeight(title, content, help string) int {
+	lines := lipgloss.Height(content)
+	available := m.height - lipgloss.Height(title) - lipgloss.Height(help)
+	if m.height == 0 || lines <= available {
+		return lines
+	}
+	// One line shows the scroll position
+	return max(1, available-1)
+}
+
+// maxScroll returns the largest scroll offset of the current screen
+func (m AppModel) maxScroll() int {
+	title, content, help := m.scrollParts()
+	return lipgloss.Height(content) - m.scrollHeight(title, content, help)
+}
+
+// viewScrollable renders a scrollable screen, showing the part of the
+// content at the scroll offset when it does not fit
+func (m AppModel) viewScrollable() string {
+	title, content, help := m.scrollParts()
+	lines := lipgloss.Height(content)
+	height := m.scrollHeight(title, content, help)
+	if height < lines {
+		vp := viewport.New(lipgloss.Width(content), height)
+		vp.SetContent(content)
+		vp.SetYOffset(m.scroll)
+		content = vp.View()
+		position := fmt.Sprintf("Lines %d-%d of %d", vp.YOffset+1, vp.YOffset+height, lines)
+		help = lipgloss.JoinVertical(lipgloss.Center, helpStyle.Render(position), help)
+	}
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
+		m.withLogo(title, content, help))
+}
+
+// scrollBy moves the scroll offset of the current screen by a number of
+// lines, negative ones scrolling up
+func (m AppModel) scrollBy(lines int) AppModel {
+	m.scroll = max(0, min(m.scroll+lines, m.maxScroll()))
+	return m
+}
+
+// pageLines returns the number of lines a page key scrolls
+func (m AppModel) pageLines() int {
+	title, content, help := m.scrollParts()
+	return max(1, m.scrollHeight(title, content, help)-1)
+}
+
+// scrollToSelection scrolls the current screen so that the row marked as
+// selected is visible
+func (m AppModel) scrollToSelection() AppModel {
+	title, content, help := m.scrollParts()
+	height := m.scrollHeight(title, content, help)
+	for i, line := range strings.Split(content, "\n") {
+		if !strings.Contains(line, "▶") {
+			continue
+		}
+		if i < m.scroll {
+			m.scroll = i
+		} else if i >= m.scroll+height {
+			m.scroll = i - height + 1
+		}
+		break
+	}
+	return m
+}
@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/armitageee/faceit-cli/internal/config"
+	"github.com/armitageee/faceit-cli/internal/entity"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// scoreboardTestModel shows a scoreboard of two full teams on an 80x24
+// terminal
+func scoreboardTestModel() AppModel {
+	team := func(name string) entity.TeamMatchStats {
+		stats := entity.TeamMatchStats{TeamName: name}
+		for i := 0; i < 5; i++ {
+			stats.Players = append(stats.Players, entity.PlayerMatchStats{
+				PlayerID: fmt.Sprintf("%s-%d", name, i),
+				Nickname: fmt.Sprintf("%s%d", name, i),
+			})
+		}
+		return stats
+	}
+	return AppModel{
+		state:      StateMatchStats,
+		config:     &config.Config{},
+		width:      80,
+		height:     24,
+		matchStats: &entity.MatchStats{MatchID: "1-abc", Map: "de_nuke", Team1: team("alpha"), Team2: team("bravo")},
+	}
+}
+
+func wheel(button tea.MouseButton) tea.MouseMsg {
+	return tea.MouseMsg{Button: button, Action: tea.MouseActionPress}
+}
+
+func TestScoreboardScrolls(t *testing.T) {
+	m := scoreboardTestModel()
+	view := m.View()
+	if strings.Contains(view, "███") {
+		t.Error("Expected no logo on an 80x24 terminal")
+	}
+	if lines := lipgloss.Height(view); lines > m.height {
+		t.Errorf("Expected the screen to fit in %d lines, got %d", m.height, lines)
+	}
+	if !strings.Contains(view, "Lines 1-") || strings.Contains(view, "bravo4") {
+		t.Error("Expected the top of the scoreboard with its scroll position")
+	}
+
+	m = sendMsg(m, wheel(tea.MouseButtonWheelDown))
+	if m.scroll != wheelLines {
+		t.Errorf("Expected the wheel to scroll %d lines, got %d", wheelLines, m.scroll)
+	}
+	m = sendMsg(m, wheel(tea.MouseButtonWheelUp))
+	m = sendMsg(m, keyMsg("pgdown"))
+	if want := min(m.pageLines(), m.maxScroll()); m.scroll != want {
+		t.Errorf("Expected PgDn to scroll %d lines, got %d", want, m.scroll)
+	}
+
+	// Scrolling stops at the end of the scoreboard
+	for i := 0; i < 10; i++ {
+		m = sendMsg(m, keyMsg("pgdown"))
+	}
+	if m.scroll != m.maxScroll() || m.maxScroll() == 0 {
+		t.Errorf("Expected the scroll offset %d to stop at %d", m.scroll, m.maxScroll())
+	}
+	if view := m.View(); !strings.Contains(view, "bravo4") {
+		t.Error("Expected the last player after scrolling to the end")
+	}
+	m = sendMsg(m, wheel(tea.MouseButtonWheelUp))
+	m = sendMsg(m, keyMsg("pgup"))
+	m = sendMsg(m, keyMsg("pgup"))
+	if m.scroll != 0 {
+		t.Errorf("Expected the top again, got %d", m.scroll)
+	}
+}
+
+func TestLogoNeedsSpace(t *testing.T) {
+	m := scoreboardTestModel()
+	m.height = 60
+	view := m.View()
+	if !strings.Contains(view, "███") || strings.Contains(view, "Lines ") {
+		t.Error("Expected the logo and the whole scoreboard on a tall terminal")
+	}
+
+	// Screens drop the logo when it does not fit
+	m.state = StateSearch
+	if m.height = 20; strings.Contains(m.View(), "███") {
+		t.Error("Expected no logo on the search screen of an 80x20 terminal")
+	}
+	if m.height = 24; !strings.Contains(m.View(), "███") {
+		t.Error("Expected the logo on the search screen of an 80x24 terminal")
+	}
+}
+
+func TestComparisonKeepsSelectionVisible(t *testing.T) {
+	players := make([]ComparedPlayer, 20)
+	for i := range players {
+		players[i] = ComparedPlayer{Nickname: fmt.Sprintf("player%02d", i)}
+	}
+	m := AppModel{
+		state:      StateComparison,
+		config:     &config.Config{},
+		width:      120,
+		height:     24,
+		comparison: &PlayerComparison{Players: players, ComparisonData: calculateComparisonData(players)},
+	}
+	for i := 0; i < len(players)-1; i++ {
+		m = typeKeys(m, "down")
+	}
+	if m.scroll == 0 || !strings.Contains(m.View(), "▶") {
+		t.Errorf("Expected the last player scrolled into view, got offset %d", m.scroll)
+	}
+
+	// Back restores the scroll offset
+	scroll := m.scroll
+	m = typeKeys(m, "enter")
+	m = sendMsg(m, headToHeadLoadedMsg{})
+	m = typeKeys(m, "esc")
+	if m.state != StateComparison || m.scroll != scroll {
+		t.Errorf("Expected the comparison at offset %d, got %d in state %v", scroll, m.scroll, m.state)
+	}
+	for i := 0; i < 3; i++ {
+		m = sendMsg(m, keyMsg("pgdown"))
+	}
+	scroll = m.scroll
+	for i := 0; i < len(players)-1; i++ {
+		m = typeKeys(m, "k")
+	}
+	if m.scroll >= scroll || !strings.Contains(m.View(), "▶") {
+		t.Errorf("Expected the first player scrolled into view, got offset %d of %d", m.scroll, scroll)
+	}
+}
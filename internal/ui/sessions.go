@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/armitageee/faceit-cli/internal/entity"
+)
+
+// sessionMatchesToLoad is the number of matches fetched for the session
+// view when none are loaded yet
+const sessionMatchesToLoad = 50
+
+// PlaySession represents a run of matches played without a long break
+type PlaySession struct {
+	Matches   []entity.PlayerMatchSummary // newest first
+	Start     time.Time
+	End       time.Time
+	Wins      int
+	Losses    int
+	AverageKD float64
+	EloChange int
+	EloKnown  bool // true when at least one match reported an ELO change
+}
+
+// Duration returns the time from the start of the first match to the end
+// of the last one
+func (s PlaySession) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// groupSessions splits matches into play sessions. A new session starts
+// whenever two consecutive matches finished more than sessionGap apart.
+// Matches are expected newest first and sessions are returned in the
+// same order.
+func groupSessions(matches []entity.PlayerMatchSummary) []PlaySession {
+	var sessions []PlaySession
+	start := 0
+	for i := 1; i <= len(matches); i++ {
+		if i < len(matches) && !isSessionBreak(matches[i-1], matches[i]) {
+			continue
+		}
+		sessions = append(sessions, summarizeSession(matches[start:i]))
+		start = i
+	}
+	return sessions
+}
+
+// isSessionBreak reports whether the gap between a match and the one
+// played before it is long enough to separate two sessions
+func isSessionBreak(newer, older entity.PlayerMatchSummary) bool {
+	gap := time.Duration(newer.FinishedAt-older.FinishedAt) * time.Second
+	return gap > sessionGap
+}
+
+// summarizeSession aggregates the matches of one session
+func summarizeSession(matches []entity.PlayerMatchSummary) PlaySession {
+	session := PlaySession{Matches: matches}
+	if len(matches) == 0 {
+		return session
+	}
+
+	var totalKD float64
+	for _, match := range matches {
+		if match.Result == "Win" {
+			session.Wins++
+		} else {
+			session.Losses++
+		}
+		totalKD += match.KDRatio
+		if match.EloChange != 0 {
+			session.EloChange += match.EloChange
+			session.EloKnown = true
+		}
+	}
+	session.AverageKD = totalKD / float64(len(matches))
+
+	newest := matches[0]
+	oldest := matches[len(matches)-1]
+	startedAt := oldest.StartedAt
+	if startedAt == 0 {
+		startedAt = oldest.FinishedAt
+	}
+	session.Start = time.Unix(startedAt, 0)
+	session.End = time.Unix(newest.FinishedAt, 0)
+	return session
+}
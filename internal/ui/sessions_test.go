@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/armitageee/faceit-cli/internal/entity"
+)
+
+func TestGroupSessions(t *testing.T) {
+	now := time.Date(2026, 3, 10, 23, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) int64 { return now.Add(-d).Unix() }
+
+	// Newest first: three matches tonight, two yesterday, one last week
+	matches := []entity.PlayerMatchSummary{
+		{MatchID: "m1", StartedAt: at(40 * time.Minute), FinishedAt: at(5 * time.Minute), Result: "Win", KDRatio: 1.5, EloChange: 25},
+		{MatchID: "m2", StartedAt: at(85 * time.Minute), FinishedAt: at(45 * time.Minute), Result: "Loss", KDRatio: 0.9, EloChange: -20},
+		{MatchID: "m3", StartedAt: at(130 * time.Minute), FinishedAt: at(90 * time.Minute), Result: "Win", KDRatio: 1.2},
+		{MatchID: "m4", StartedAt: at(25 * time.Hour), FinishedAt: at(24 * time.Hour), Result: "Loss", KDRatio: 0.8},
+		{MatchID: "m5", FinishedAt: at(26 * time.Hour), Result: "Loss", KDRatio: 1.0},
+		{MatchID: "m6", FinishedAt: at(7 * 24 * time.Hour), Result: "Win", KDRatio: 2.0},
+	}
+
+	sessions := groupSessions(matches)
+	if len(sessions) != 3 {
+		t.Fatalf("Expected 3 sessions, got %d", len(sessions))
+	}
+
+	tonight := sessions[0]
+	if len(tonight.Matches) != 3 || tonight.Matches[0].MatchID != "m1" {
+		t.Errorf("Expected tonight to hold m1-m3, got %d matches", len(tonight.Matches))
+	}
+	if tonight.Wins != 2 || tonight.Losses != 1 {
+		t.Errorf("Expected 2-1, got %d-%d", tonight.Wins, tonight.Losses)
+	}
+	if !tonight.EloKnown || tonight.EloChange != 5 {
+		t.Errorf("Expected known ELO change of +5, got %d (known %v)", tonight.EloChange, tonight.EloKnown)
+	}
+	if expected := 1.2; tonight.AverageKD < expected-0.001 || tonight.AverageKD > expected+0.001 {
+		t.Errorf("Expected average K/D %.2f, got %.2f", expected, tonight.AverageKD)
+	}
+	if tonight.Duration() != 125*time.Minute {
+		t.Errorf("Expected duration 2h05m, got %s", tonight.Duration())
+	}
+
+	yesterday := sessions[1]
+	if len(yesterday.Matches) != 2 || yesterday.Wins != 0 || yesterday.Losses != 2 {
+		t.Errorf("Expected yesterday to be 0-2 over 2 matches, got %d-%d over %d", yesterday.Wins, yesterday.Losses, len(yesterday.Matches))
+	}
+	if yesterday.EloKnown {
+		t.Error("Expected unknown ELO change when no match reports one")
+	}
+	// Without a start time the session starts when its oldest match finished
+	if yesterday.Duration() != 2*time.Hour {
+		t.Errorf("Expected duration 2h, got %s", yesterday.Duration())
+	}
+
+	if len(sessions[2].Matches) != 1 || sessions[2].Duration() != 0 {
+		t.Errorf("Expected a single-match session without duration, got %d matches over %s", len(sessions[2].Matches), sessions[2].Duration())
+	}
+}
+
+func TestGroupSessionsEmpty(t *testing.T) {
+	if sessions := groupSessions(nil); len(sessions) != 0 {
+		t.Errorf("Expected no sessions, got %d", len(sessions))
+	}
+}
+
+func TestFormatSessionDuration(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		expected string
+	}{
+		{45 * time.Minute, "45m"},
+		{2 * time.Hour, "2h00m"},
+		{125 * time.Minute, "2h05m"},
+	}
+
+	for _, tt := range tests {
+		if got := formatSessionDuration(tt.duration); got != tt.expected {
+			t.Errorf("formatSessionDuration(%s) = %q, want %q", tt.duration, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/armitageee/faceit-cli/internal/entity"
+)
+
+// sessionGap is the longest break between two finished matches that is
+// still considered part of the same play session
+const sessionGap = 2 * time.Hour
+
+// defaultStatsMatches is the size of the default statistics window
+const defaultStatsMatches = 20
+
+// statsWindowKind selects how the statistics window is bounded
+type statsWindowKind int
+
+const (
+	statsWindowLastN statsWindowKind = iota
+	statsWindowDays
+	statsWindowSince
+	statsWindowSession
+)
+
+// statsWindow describes which matches the statistics screen aggregates
+type statsWindow struct {
+	kind  statsWindowKind
+	count int       // number of matches for LastN, number of days for Days
+	since time.Time // start date for Since
+}
+
+// statsWindowPresets are the windows cycled through on the stats screen
+var statsWindowPresets = []statsWindow{
+	{kind: statsWindowLastN, count: defaultStatsMatches},
+	{kind: statsWindowLastN, count: 50},
+	{kind: statsWindowLastN, count: 100},
+	{kind: statsWindowDays, count: 7},
+	{kind: statsWindowDays, count: 30},
+	{kind: statsWindowSession},
+}
+
+// String returns a short description of the window
+func (w statsWindow) String() string {
+	switch w.kind {
+	case statsWindowDays:
+		return fmt.Sprintf("last %d days", w.count)
+	case statsWindowSince:
+		return "since " + w.since.Format("2006-01-02")
+	case statsWindowSession:
+		return "current session"
+	default:
+		return fmt.Sprintf("last %d matches", w.count)
+	}
+}
+
+// parseStatsWindow parses a window typed on the stats screen: a number of
+// matches ("50"), a number of days ("7d"), a start date ("2026-01-01")
+// or "session"
+func parseStatsWindow(input string) (statsWindow, error) {
+	input = strings.ToLower(strings.TrimSpace(input))
+	if input == "" {
+		return statsWindow{}, fmt.Errorf("empty window")
+	}
+	if input == "session" || input == "s" {
+		return statsWindow{kind: statsWindowSession}, nil
+	}
+	if strings.HasSuffix(input, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(input, "d"))
+		if err != nil || days <= 0 {
+			return statsWindow{}, fmt.Errorf("invalid number of days: %s", input)
+		}
+		return statsWindow{kind: statsWindowDays, count: days}, nil
+	}
+	if n, err := strconv.Atoi(input); err == nil {
+		if n <= 0 {
+			return statsWindow{}, fmt.Errorf("number of matches must be positive")
+		}
+		return statsWindow{kind: statsWindowLastN, count: n}, nil
+	}
+	since, err := time.ParseInLocation("2006-01-02", input, time.Local)
+	if err != nil {
+		return statsWindow{}, fmt.Errorf("unrecognised window %q (use 50, 7d, 2026-01-01 or session)", input)
+	}
+	return statsWindow{kind: statsWindowSince, since: since}, nil
+}
+
+// start returns the earliest finish time included in a time based window
+func (w statsWindow) start(now time.Time) time.Time {
+	if w.kind == statsWindowDays {
+		return now.AddDate(0, 0, -w.count)
+	}
+	return w.since
+}
+
+// apply returns the matches that fall inside the window. Matches are
+// expected newest first.
+func (w statsWindow) apply(matches []entity.PlayerMatchSummary, now time.Time) []entity.PlayerMatchSummary {
+	switch w.kind {
+	case statsWindowLastN:
+		if len(matches) > w.count {
+			return matches[:w.count]
+		}
+		return matches
+	case statsWindowDays, statsWindowSince:
+		start := w.start(now).Unix()
+		end := 0
+		for end < len(matches) && matches[end].FinishedAt >= start {
+			end++
+		}
+		return matches[:end]
+	case statsWindowSession:
+		return currentSessionMatches(matches)
+	}
+	return matches
+}
+
+// coveredBy reports whether the loaded matches are enough to evaluate the
+// window without fetching more from the API
+func (w statsWindow) coveredBy(matches []entity.PlayerMatchSummary, now time.Time) bool {
+	if len(matches) == 0 {
+		return false
+	}
+	oldest := matches[len(matches)-1]
+	switch w.kind {
+	case statsWindowLastN:
+		return len(matches) >= w.count
+	case statsWindowDays, statsWindowSince:
+		return oldest.FinishedAt < w.start(now).Unix()
+	case statsWindowSession:
+		// The session is complete once a gap before it has been loaded
+		return len(currentSessionMatches(matches)) < len(matches)
+	}
+	return false
+}
+
+// fetchLimit returns how many matches to request when the loaded ones do
+// not cover the window. No window loads more than maxMatches.
+func (w statsWindow) fetchLimit(maxMatches int) int {
+	if w.kind == statsWindowLastN && (maxMatches <= 0 || w.count < maxMatches) {
+		return w.count
+	}
+	return maxMatches
+}
+
+// truncated reports whether the window reaches past the maxMatches most
+// recent matches, so its statistics only cover part of it
+func (w statsWindow) truncated(matches []entity.PlayerMatchSummary, maxMatches int, now time.Time) bool {
+	return maxMatches > 0 && len(matches) >= maxMatches && !w.coveredBy(matches, now)
+}
+
+// currentSessionMatches returns the most recent run of matches that were
+// finished less than sessionGap apart. Matches are expected newest first.
+func currentSessionMatches(matches []entity.PlayerMatchSummary) []entity.PlayerMatchSummary {
+	sessions := groupSessions(matches)
+	if len(sessions) == 0 {
+		return matches
+	}
+	return sessions[0].Matches
+}
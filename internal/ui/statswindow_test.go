@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/armitageee/faceit-cli/internal/entity"
+)
+
+func TestParseStatsWindow(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{"50", "last 50 matches", false},
+		{"7d", "last 7 days", false},
+		{" 30D ", "last 30 days", false},
+		{"2026-01-01", "since 2026-01-01", false},
+		{"session", "current session", false},
+		{"", "", true},
+		{"0", "", true},
+		{"-3d", "", true},
+		{"yesterday", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			window, err := parseStatsWindow(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseStatsWindow(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && window.String() != tt.expected {
+				t.Errorf("parseStatsWindow(%q) = %q, want %q", tt.input, window.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestStatsWindowApply(t *testing.T) {
+	now := time.Date(2026, 3, 10, 22, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) int64 { return now.Add(-d).Unix() }
+
+	// Newest first: a session of three matches tonight, then older ones
+	matches := []entity.PlayerMatchSummary{
+		{MatchID: "m1", FinishedAt: at(30 * time.Minute)},
+		{MatchID: "m2", FinishedAt: at(80 * time.Minute)},
+		{MatchID: "m3", FinishedAt: at(130 * time.Minute)},
+		{MatchID: "m4", FinishedAt: at(3 * 24 * time.Hour)},
+		{MatchID: "m5", FinishedAt: at(10 * 24 * time.Hour)},
+	}
+
+	tests := []struct {
+		name     string
+		window   statsWindow
+		expected int
+		covered  bool
+	}{
+		{"last 2", statsWindow{kind: statsWindowLastN, count: 2}, 2, true},
+		{"last 20", statsWindow{kind: statsWindowLastN, count: 20}, 5, false},
+		{"last 7 days", statsWindow{kind: statsWindowDays, count: 7}, 4, true},
+		{"last 30 days", statsWindow{kind: statsWindowDays, count: 30}, 5, false},
+		{"since", statsWindow{kind: statsWindowSince, since: now.AddDate(0, 0, -5)}, 4, true},
+		{"session", statsWindow{kind: statsWindowSession}, 3, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := len(tt.window.apply(matches, now)); got != tt.expected {
+				t.Errorf("apply() returned %d matches, want %d", got, tt.expected)
+			}
+			if got := tt.window.coveredBy(matches, now); got != tt.covered {
+				t.Errorf("coveredBy() = %v, want %v", got, tt.covered)
+			}
+		})
+	}
+
+	if (statsWindow{kind: statsWindowSession}).coveredBy(matches[:3], now) {
+		t.Error("a session with no gap loaded before it is not known to be complete")
+	}
+}
+
+func TestStatsWindowLimit(t *testing.T) {
+	now := time.Date(2026, 3, 10, 22, 0, 0, 0, time.UTC)
+	matches := make([]entity.PlayerMatchSummary, 5)
+	for i := range matches {
+		matches[i] = entity.PlayerMatchSummary{FinishedAt: now.Add(-time.Duration(i+1) * time.Hour).Unix()}
+	}
+
+	lastN := statsWindow{kind: statsWindowLastN, count: 500}
+	if got := lastN.fetchLimit(5); got != 5 {
+		t.Errorf("fetchLimit(5) = %d, want the window capped at 5", got)
+	}
+	if got := (statsWindow{kind: statsWindowLastN, count: 3}).fetchLimit(5); got != 3 {
+		t.Errorf("fetchLimit(5) = %d, want 3", got)
+	}
+
+	// Windows reaching past the limit are truncated, smaller ones are not
+	if !lastN.truncated(matches, 5, now) {
+		t.Error("Expected the last 500 matches to be truncated at 5")
+	}
+	if !(statsWindow{kind: statsWindowDays, count: 7}).truncated(matches, 5, now) {
+		t.Error("Expected the last 7 days to be truncated at 5 matches")
+	}
+	if lastN.truncated(matches, 10, now) {
+		t.Error("Expected no truncation when the player has fewer matches than the limit")
+	}
+	if (statsWindow{kind: statsWindowLastN, count: 3}).truncated(matches, 5, now) {
+		t.Error("Expected a window within the limit not to be truncated")
+	}
+}
+
+func TestStatsTitleShowsTruncation(t *testing.T) {
+	m := filterTestModel()
+	m.config.MaxMatchesToLoad = len(m.matches)
+	m.statsWindow = statsWindow{kind: statsWindowLastN, count: 500}
+
+	next, cmd := m.refreshStats()
+	if cmd != nil {
+		t.Fatal("Expected the loaded matches to be used rather than fetching past the limit")
+	}
+	view := next.(AppModel).viewStats()
+	if !strings.Contains(view, "limited to the last 25 matches") {
+		t.Errorf("Expected the title to show the truncation, got:\n%s", view)
+	}
+}
@@ -0,0 +1,22 @@
+package ui
+
+import "github.com/armitageee/faceit-cli/internal/analytics"
+
+// frequencyMatchesToLoad is the number of matches fetched for the
+// teammates screen when none are loaded yet
+const frequencyMatchesToLoad = 50
+
+// frequencyListLength is the number of teammates and opponents listed
+const frequencyListLength = 15
+
+// focusedFrequencies returns the list that currently has focus on the
+// teammates screen, trimmed to the displayed length
+func (m AppModel) focusedFrequencies() []analytics.PlayerFrequency {
+	if m.frequencies == nil {
+		return nil
+	}
+	if m.frequencyOpponents {
+		return analytics.Top(m.frequencies.Opponents, frequencyListLength)
+	}
+	return analytics.Top(m.frequencies.Teammates, frequencyListLength)
+}
@@ -0,0 +1,366 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme is the color palette of the interface. Colors are hex values such
+// as "#7D56F4" or ANSI color numbers from 0 to 255.
+type Theme struct {
+	Accent     lipgloss.Color // titles and highlighted boxes
+	AccentText lipgloss.Color // text on the accent color
+	Border     lipgloss.Color // borders of lists and statistics
+	Focus      lipgloss.Color // border of the focused list
+	Text       lipgloss.Color
+	Strong     lipgloss.Color // values and player names
+	Label      lipgloss.Color // table headers
+	Muted      lipgloss.Color // hints
+	Subtle     lipgloss.Color // help lines and chart axes
+	Separator  lipgloss.Color
+	Win        lipgloss.Color
+	Loss       lipgloss.Color
+	Better     lipgloss.Color // best value of a comparison
+	Worse      lipgloss.Color
+	Error      lipgloss.Color
+	Highlight  lipgloss.Color // match winner and chart averages
+	Info       lipgloss.Color // charts and progress
+	Track      lipgloss.Color // background of the progress bar
+	Team1      lipgloss.Color
+	Team2      lipgloss.Color
+	Player1    lipgloss.Color
+	Player2    lipgloss.Color
+}
+
+// colors maps the color names used in config.yml to the theme's fields
+func (t *Theme) colors() map[string]*lipgloss.Color {
+	return map[string]*lipgloss.Color{
+		"accent":      &t.Accent,
+		"accent_text": &t.AccentText,
+		"border":      &t.Border,
+		"focus":       &t.Focus,
+		"text":        &t.Text,
+		"strong":      &t.Strong,
+		"label":       &t.Label,
+		"muted":       &t.Muted,
+		"subtle":      &t.Subtle,
+		"separator":   &t.Separator,
+		"win":         &t.Win,
+		"loss":        &t.Loss,
+		"better":      &t.Better,
+		"worse":       &t.Worse,
+		"error":       &t.Error,
+		"highlight":   &t.Highlight,
+		"info":        &t.Info,
+		"track":       &t.Track,
+		"team1":       &t.Team1,
+		"team2":       &t.Team2,
+		"player1":     &t.Player1,
+		"player2":     &t.Player2,
+	}
+}
+
+// darkTheme is tuned for dark terminals and was the only palette before
+// themes could be configured
+var darkTheme = Theme{
+	Accent:     "#7D56F4",
+	AccentText: "#FAFAFA",
+	Border:     "#874BFD",
+	Focus:      "#04B575",
+	Text:       "#E8E8E8",
+	Strong:     "#FFFFFF",
+	Label:      "#B0B0B0",
+	Muted:      "#888888",
+	Subtle:     "#626262",
+	Separator:  "#555555",
+	Win:        "#04B575",
+	Loss:       "#FF5F87",
+	Better:     "#96CEB4",
+	Worse:      "#FF5F87",
+	Error:      "#FF5F87",
+	Highlight:  "#FFD700",
+	Info:       "#4A90E2",
+	Track:      "#2C3E50",
+	Team1:      "#4A90E2",
+	Team2:      "#E74C3C",
+	Player1:    "#4ECDC4",
+	Player2:    "#FF6B6B",
+}
+
+var lightTheme = Theme{
+	Accent:     "#5A3FC0",
+	AccentText: "#FFFFFF",
+	Border:     "#6B4FD8",
+	Focus:      "#007A4D",
+	Text:       "#303030",
+	Strong:     "#000000",
+	Label:      "#505050",
+	Muted:      "#6C6C6C",
+	Subtle:     "#8A8A8A",
+	Separator:  "#B0B0B0",
+	Win:        "#007A4D",
+	Loss:       "#C7254E",
+	Better:     "#2E8B57",
+	Worse:      "#C7254E",
+	Error:      "#C7254E",
+	Highlight:  "#B8860B",
+	Info:       "#1F5FAD",
+	Track:      "#D0D7DE",
+	Team1:      "#1F5FAD",
+	Team2:      "#B03A2E",
+	Player1:    "#00797A",
+	Player2:    "#C0392B",
+}
+
+var highContrastTheme = Theme{
+	Accent:     "#FFFF00",
+	AccentText: "#000000",
+	Border:     "#FFFFFF",
+	Focus:      "#00FF00",
+	Text:       "#FFFFFF",
+	Strong:     "#FFFFFF",
+	Label:      "#FFFFFF",
+	Muted:      "#D0D0D0",
+	Subtle:     "#C0C0C0",
+	Separator:  "#FFFFFF",
+	Win:        "#00FF00",
+	Loss:       "#FF0000",
+	Better:     "#00FF00",
+	Worse:      "#FF0000",
+	Error:      "#FF0000",
+	Highlight:  "#FFFF00",
+	Info:       "#00FFFF",
+	Track:      "#404040",
+	Team1:      "#00FFFF",
+	Team2:      "#FF00FF",
+	Player1:    "#00FFFF",
+	Player2:    "#FF00FF",
+}
+
+// colorblindTheme replaces the green and red pairs of the dark theme with
+// the blue and orange of the Okabe-Ito palette, which stay distinct for
+// every common form of color blindness
+var colorblindTheme = withColors(darkTheme, Theme{
+	Focus:     "#56B4E9",
+	Win:       "#56B4E9",
+	Loss:      "#E69F00",
+	Better:    "#56B4E9",
+	Worse:     "#E69F00",
+	Error:     "#D55E00",
+	Highlight: "#F0E442",
+	Info:      "#56B4E9",
+	Team1:     "#56B4E9",
+	Team2:     "#E69F00",
+	Player1:   "#009E73",
+	Player2:   "#CC79A7",
+})
+
+var colorblindLightTheme = withColors(lightTheme, Theme{
+	Focus:     "#0072B2",
+	Win:       "#0072B2",
+	Loss:      "#D55E00",
+	Better:    "#0072B2",
+	Worse:     "#D55E00",
+	Error:     "#D55E00",
+	Highlight: "#B8860B",
+	Info:      "#0072B2",
+	Team1:     "#0072B2",
+	Team2:     "#D55E00",
+	Player1:   "#009E73",
+	Player2:   "#CC79A7",
+})
+
+// builtinThemes are the themes that can be selected by name
+var builtinThemes = map[string]Theme{
+	"dark":             darkTheme,
+	"light":            lightTheme,
+	"high-contrast":    highContrastTheme,
+	"colorblind":       colorblindTheme,
+	"colorblind-light": colorblindLightTheme,
+}
+
+// withColors returns base with the colors set in overrides replaced
+func withColors(base, overrides Theme) Theme {
+	colors := base.colors()
+	for name, color := range overrides.colors() {
+		if *color != "" {
+			*colors[name] = *color
+		}
+	}
+	return base
+}
+
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// parseColor validates a hex color or an ANSI color number
+func parseColor(value string) (lipgloss.Color, error) {
+	value = strings.TrimSpace(value)
+	if hexColor.MatchString(value) {
+		return lipgloss.Color(value), nil
+	}
+	if n, err := strconv.Atoi(value); err == nil && n >= 0 && n <= 255 {
+		return lipgloss.Color(value), nil
+	}
+	return "", fmt.Errorf("invalid color %q (use #RRGGBB or an ANSI number from 0 to 255)", value)
+}
+
+// LoadTheme returns the named theme. "auto" or an empty name picks the dark
+// or light theme for the terminal background; hasDarkBackground is only
+// called then. Custom themes map color names to colors, and may name a
+// built-in theme as "base" for the colors they do not set.
+func LoadTheme(name string, custom map[string]map[string]string, hasDarkBackground func() bool) (Theme, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	defaultTheme := func() Theme {
+		if hasDarkBackground() {
+			return darkTheme
+		}
+		return lightTheme
+	}
+	if name == "" || name == "auto" {
+		return defaultTheme(), nil
+	}
+
+	colors, ok := custom[name]
+	if !ok {
+		if theme, ok := builtinThemes[name]; ok {
+			return theme, nil
+		}
+		return Theme{}, fmt.Errorf("unknown theme %q (built in: %s)", name, strings.Join(builtinThemeNames(), ", "))
+	}
+
+	theme := defaultTheme()
+	if base, ok := colors["base"]; ok {
+		if theme, ok = builtinThemes[strings.ToLower(base)]; !ok {
+			return Theme{}, fmt.Errorf("theme %s: unknown base theme %q", name, base)
+		}
+	}
+	fields := theme.colors()
+	names := make([]string, 0, len(colors))
+	for colorName := range colors {
+		names = append(names, colorName)
+	}
+	sort.Strings(names)
+	for _, colorName := range names {
+		if colorName == "base" {
+			continue
+		}
+		field, ok := fields[strings.ToLower(colorName)]
+		if !ok {
+			return Theme{}, fmt.Errorf("theme %s: unknown color %q", name, colorName)
+		}
+		color, err := parseColor(colors[colorName])
+		if err != nil {
+			return Theme{}, fmt.Errorf("theme %s: %s: %w", name, colorName, err)
+		}
+		*field = color
+	}
+	return theme, nil
+}
+
+// builtinThemeNames returns the names of the built-in themes, sorted
+func builtinThemeNames() []string {
+	names := make([]string, 0, len(builtinThemes))
+	for name := range builtinThemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// currentTheme is the theme the styles were built from
+var currentTheme Theme
+
+// Styles used by the views, built from the current theme
+var (
+	titleStyle              lipgloss.Style
+	errorStyle              lipgloss.Style
+	matchDetailStyle        lipgloss.Style
+	comparisonStyle         lipgloss.Style
+	player1Style            lipgloss.Style
+	player2Style            lipgloss.Style
+	betterStyle             lipgloss.Style
+	worseStyle              lipgloss.Style
+	matchInfoStyle          lipgloss.Style
+	matchValueStyle         lipgloss.Style
+	winnerStyle             lipgloss.Style
+	team1Style              lipgloss.Style
+	team2Style              lipgloss.Style
+	tableHeaderStyle        lipgloss.Style
+	playerNameStyle         lipgloss.Style
+	statsValueStyle         lipgloss.Style
+	separatorStyle          lipgloss.Style
+	helpTextStyle           lipgloss.Style
+	progressBarStyle        lipgloss.Style
+	progressMessageStyle    lipgloss.Style
+	progressPercentageStyle lipgloss.Style
+	searchStyle             lipgloss.Style
+	profileStyle            lipgloss.Style
+	matchesStyle            lipgloss.Style
+	statsStyle              lipgloss.Style
+	helpStyle               lipgloss.Style
+	paginationStyle         lipgloss.Style
+	winStyle                lipgloss.Style
+	lossStyle               lipgloss.Style
+	overlayStyle            lipgloss.Style
+	chartSeriesStyle        lipgloss.Style
+	chartOverlayStyle       lipgloss.Style
+	chartAxisStyle          lipgloss.Style
+)
+
+func init() {
+	SetTheme(darkTheme)
+}
+
+// SetTheme rebuilds every style from a theme
+func SetTheme(t Theme) {
+	currentTheme = t
+	box := lipgloss.NewStyle().Border(lipgloss.RoundedBorder())
+
+	titleStyle = lipgloss.NewStyle().Foreground(t.AccentText).Background(t.Accent).Padding(0, 1)
+	searchStyle = lipgloss.NewStyle().Foreground(t.AccentText).Background(t.Accent).Padding(1, 2)
+	profileStyle = lipgloss.NewStyle().Foreground(t.Text).Padding(1, 0)
+	errorStyle = lipgloss.NewStyle().Foreground(t.Error)
+
+	matchDetailStyle = box.BorderForeground(t.Accent).Padding(1, 2).Margin(1, 0)
+	comparisonStyle = box.BorderForeground(t.Accent).Padding(1, 2).Margin(1, 0)
+	overlayStyle = box.BorderForeground(t.Accent).Padding(1, 2)
+	matchesStyle = box.BorderForeground(t.Border).Padding(1, 2)
+	statsStyle = box.BorderForeground(t.Border).Padding(1, 2)
+
+	player1Style = lipgloss.NewStyle().Foreground(t.Player1).Bold(true)
+	player2Style = lipgloss.NewStyle().Foreground(t.Player2).Bold(true)
+	betterStyle = lipgloss.NewStyle().Foreground(t.Better).Bold(true)
+	worseStyle = lipgloss.NewStyle().Foreground(t.Worse).Bold(true)
+	winStyle = lipgloss.NewStyle().Foreground(t.Win).Bold(true)
+	lossStyle = lipgloss.NewStyle().Foreground(t.Loss).Bold(true)
+
+	// Match detail styles
+	matchInfoStyle = lipgloss.NewStyle().Foreground(t.Text).Bold(true)
+	matchValueStyle = lipgloss.NewStyle().Foreground(t.Strong).Bold(true)
+	winnerStyle = lipgloss.NewStyle().Foreground(t.Highlight).Bold(true)
+	team1Style = lipgloss.NewStyle().Foreground(t.Team1).Bold(true)
+	team2Style = lipgloss.NewStyle().Foreground(t.Team2).Bold(true)
+	tableHeaderStyle = lipgloss.NewStyle().Foreground(t.Label).Bold(true)
+	playerNameStyle = lipgloss.NewStyle().Foreground(t.Strong).Bold(true)
+	statsValueStyle = lipgloss.NewStyle().Foreground(t.Text)
+	separatorStyle = lipgloss.NewStyle().Foreground(t.Separator)
+
+	helpTextStyle = lipgloss.NewStyle().Foreground(t.Muted).Italic(true)
+	helpStyle = lipgloss.NewStyle().Foreground(t.Subtle).Italic(true)
+	paginationStyle = helpStyle.Align(lipgloss.Center)
+
+	// Progress bar styles
+	progressBarStyle = lipgloss.NewStyle().Foreground(t.Info).Background(t.Track).Bold(true)
+	progressMessageStyle = lipgloss.NewStyle().Foreground(t.Text).Bold(true)
+	progressPercentageStyle = lipgloss.NewStyle().Foreground(t.Info).Bold(true)
+
+	// Chart styles
+	chartSeriesStyle = lipgloss.NewStyle().Foreground(t.Info).Bold(true)
+	chartOverlayStyle = lipgloss.NewStyle().Foreground(t.Highlight)
+	chartAxisStyle = lipgloss.NewStyle().Foreground(t.Subtle)
+}
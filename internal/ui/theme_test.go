@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestLoadTheme(t *testing.T) {
+	dark := func() bool { return true }
+	light := func() bool { return false }
+
+	if theme, _ := LoadTheme("auto", nil, light); theme != lightTheme {
+		t.Error("Expected the light theme on a light background")
+	}
+	if theme, _ := LoadTheme("", nil, dark); theme != darkTheme {
+		t.Error("Expected the dark theme on a dark background")
+	}
+	if theme, _ := LoadTheme("Colorblind", nil, light); theme.Win != "#56B4E9" || theme.Loss != "#E69F00" {
+		t.Errorf("Expected blue and orange results, got %s and %s", theme.Win, theme.Loss)
+	}
+
+	custom := map[string]map[string]string{
+		"mine":  {"win": "#00f", "loss": "208"},
+		"paper": {"base": "high-contrast", "accent": "#123456"},
+	}
+	theme, err := LoadTheme("mine", custom, light)
+	if err != nil {
+		t.Fatalf("LoadTheme: %v", err)
+	}
+	if theme.Win != "#00f" || theme.Loss != "208" || theme.Text != lightTheme.Text {
+		t.Errorf("Expected the results replaced on the light theme, got %+v", theme)
+	}
+	if theme, _ := LoadTheme("paper", custom, light); theme.Accent != "#123456" || theme.Win != highContrastTheme.Win {
+		t.Errorf("Expected the accent replaced on the high contrast theme, got %+v", theme)
+	}
+
+	errorTests := []struct {
+		name   string
+		custom map[string]string
+		want   string
+	}{
+		{"neon", nil, `unknown theme "neon" (built in: colorblind, colorblind-light, dark, high-contrast, light)`},
+		{"mine", map[string]string{"base": "neon"}, `theme mine: unknown base theme "neon"`},
+		{"mine", map[string]string{"sky": "#fff"}, `theme mine: unknown color "sky"`},
+		{"mine", map[string]string{"win": "green"}, `theme mine: win: invalid color "green"`},
+		{"mine", map[string]string{"loss": "300"}, `invalid color "300"`},
+	}
+	for _, tt := range errorTests {
+		custom := map[string]map[string]string{}
+		if tt.custom != nil {
+			custom[tt.name] = tt.custom
+		}
+		_, err := LoadTheme(tt.name, custom, dark)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s %v: expected an error containing %q, got %v", tt.name, tt.custom, tt.want, err)
+		}
+	}
+}
+
+func TestSetTheme(t *testing.T) {
+	defer SetTheme(darkTheme)
+
+	SetTheme(colorblindTheme)
+	if winStyle.GetForeground() != lipgloss.Color("#56B4E9") || lossStyle.GetForeground() != lipgloss.Color("#E69F00") {
+		t.Error("Expected the win and loss styles to follow the theme")
+	}
+	if matchesStyle.GetBorderTopForeground() != colorblindTheme.Border {
+		t.Error("Expected the borders to follow the theme")
+	}
+
+	// Every built-in theme sets every color
+	for name, theme := range builtinThemes {
+		for color, value := range theme.colors() {
+			if *value == "" {
+				t.Errorf("Theme %s has no %s color", name, color)
+			}
+		}
+	}
+}
@@ -1,12 +1,14 @@
 package ui
 
 import (
+	"github.com/armitageee/faceit-cli/internal/analytics"
 	"github.com/armitageee/faceit-cli/internal/config"
 	"github.com/armitageee/faceit-cli/internal/entity"
 	"github.com/armitageee/faceit-cli/internal/logger"
+	"github.com/armitageee/faceit-cli/internal/notify"
 	"github.com/armitageee/faceit-cli/internal/repository"
-
-	"github.com/charmbracelet/lipgloss"
+	"github.com/armitageee/faceit-cli/internal/telemetry"
+	"github.com/armitageee/faceit-cli/internal/watch"
 )
 
 // AppState represents the current state of the application
@@ -24,6 +26,11 @@ const (
 	StatePlayerSwitch
 	StateComparisonInput
 	StateComparison
+	StateHeadToHead
+	StateMapStats
+	StateSessions
+	StateTeammates
+	StateWatch
 	StateLoading
 	StateError
 )
@@ -40,11 +47,14 @@ type PlayerStatsSummary struct {
 	AverageKDRatio   float64
 	TotalKDA         float64 // Total K/D ratio (total kills / total deaths)
 	AverageHS        float64
+	AverageADR       float64 // over matches that report ADR
 	BestKDRatio      float64
 	WorstKDRatio     float64
 	MostPlayedMap    string
 	MapStats         map[string]int
 	KDChartData      []float64 // K/D ratios for chart
+	ADRChartData     []float64 // ADR values for chart
+	HSChartData      []float64 // Headshot percentages for chart
 	CurrentStreak    int    // positive for win streak, negative for loss streak
 	StreakType       string // "win" or "loss"
 	LongestWinStreak int
@@ -98,33 +108,31 @@ type PerformanceMetrics struct {
 }
 
 
-// PlayerComparison represents comparison data between two players
+// PlayerComparison represents comparison data between several players.
+// The first player is the one whose profile is open.
 type PlayerComparison struct {
-	Player1Nickname string
-	Player2Nickname string
-	Player1Stats    PlayerStatsSummary
-	Player2Stats    PlayerStatsSummary
-	ComparisonData  ComparisonData
+	Players        []ComparedPlayer
+	ComparisonData ComparisonData
+}
+
+// ComparedPlayer represents one player of a comparison
+type ComparedPlayer struct {
+	Nickname string
+	PlayerID string // for loading the head-to-head
+	Stats    PlayerStatsSummary
 }
 
-// ComparisonData represents detailed comparison metrics
+// ComparisonData represents the rankings and map overlap of a comparison
 type ComparisonData struct {
-	KDRatioDiff      float64
-	TotalKDADiff     float64 // Difference in total K/D ratio
-	WinRateDiff      float64
-	AverageHSDiff    float64
-	TotalKillsDiff   int
-	TotalDeathsDiff  int
-	TotalAssistsDiff int
-	BestKDDiff       float64
-	WorstKDDiff      float64
-	MostPlayedMap    string
-	CommonMaps       []string
+	Rankings      map[comparisonMetric][]int // player indices, best first
+	MostPlayedMap string
+	CommonMaps    []string
 }
 
 // AppModel represents the main application model
 type AppModel struct {
 	state              AppState
+	keys               *KeyMap // nil for the default bindings
 	repo               repository.FaceitRepository
 	config             *config.Config
 	logger             *logger.Logger
@@ -139,32 +147,85 @@ type AppModel struct {
 	recentPlayers      []string
 	comparison         *PlayerComparison
 	comparisonInput    string
+	comparisonSort     comparisonMetric
+	comparisonError    string
+	comparisonSelected int // selected row of the ranking table
+	headToHead         *HeadToHead
 	// Match search fields
 	matchSearchInput   string
 	matchStats         *entity.MatchStats
 	// Player match detail fields
 	selectedPlayerMatch *entity.PlayerMatchSummary
 	playerMatchStats    *entity.MatchStats
+	scoreboardSelected  int // selected row of the scoreboards, team 1 first
 	error              string
 	loading            bool
 	width              int
 	height             int
+	scroll             int // scroll offset of the scrollable screens
 	// Pagination fields
 	currentPage        int
-	totalMatches       int
 	matchesPerPage     int
-	hasMoreMatches     bool
+	// Matches screen filter and sort fields
+	matchFilter        matchFilter
+	matchFilterEditing bool
+	matchFilterInput   string
+	matchFilterError   string
+	matchSortField     matchSortField
+	matchSortReversed  bool // reverses the field's default direction
+	// Matches table fields
+	matchColumns       []matchColumn // nil for every column
+	compactMatches     bool
 	// Progress bar fields
 	progress           float64
 	progressMessage    string
 	progressType       string // "matches", "stats", "match_stats", etc.
 	// Background loading fields
 	backgroundLoading  bool
+	// Stats window fields
+	statsWindow        statsWindow
+	statsWindowPreset  int
+	statsWindowEditing bool
+	statsWindowInput   string
+	statsWindowError   string
+	// Stats chart fields
+	statsChartMetric   chartMetric
+	statsChartKind     chartKind
+	// Map breakdown fields
+	mapStats           []MapPerformance
+	mapSortColumn      mapSortColumn
+	mapSortDesc        bool
+	// Play session fields
+	sessions             []PlaySession
+	selectedSessionIndex int
+	// Teammate and opponent frequency fields
+	frequencies            *analytics.Frequencies
+	frequencyOpponents     bool // true when the opponents list has focus
+	selectedFrequencyIndex int
+	// Watchlist fields
+	watcher            *watch.Watcher
+	watchStatuses      []watch.PlayerStatus
+	watchEvents        []watchLogEntry // newest first
+	watchSelected      int
+	watchGeneration    int // incremented whenever a new watcher starts
+	watchTimer         int // incremented to cancel the pending poll timer
+	watchPolling       bool
+	watchPollInFlight  bool // a poll is running; only one runs at a time
+	notifier           notify.Notifier // nil when notifications are disabled
+	recordElo          watch.Recorder  // nil when ELO changes are not recorded
+	metrics            *telemetry.Metrics // nil when metrics are disabled
+	// Help overlay and command palette fields
+	showHelp           bool
+	palette            commandPalette
+	gameID             string // "" for the default game
+	// Screens Back returns to, most recent last
+	history            []screen
 }
 
 // Custom message types for async operations
 type statsLoadedMsg struct {
-	stats PlayerStatsSummary
+	stats   PlayerStatsSummary
+	matches []entity.PlayerMatchSummary // matches fetched to cover the window
 }
 
 type matchDetailLoadedMsg struct {
@@ -175,19 +236,23 @@ type comparisonLoadedMsg struct {
 	comparison PlayerComparison
 }
 
+type headToHeadLoadedMsg struct {
+	headToHead HeadToHead
+}
+
 type lifetimeStatsLoadedMsg struct {
 	stats *entity.PlayerStats
 }
 
 type matchesPageLoadedMsg struct {
-	matches      []entity.PlayerMatchSummary
-	page         int
-	hasMore      bool
-	totalMatches int
+	matches []entity.PlayerMatchSummary
+	page    int
 }
 
 type backgroundMatchesLoadedMsg struct {
-	matches []entity.PlayerMatchSummary
+	playerID string
+	gameID   string
+	matches  []entity.PlayerMatchSummary
 }
 
 type matchStatsLoadedMsg struct {
@@ -198,95 +263,26 @@ type playerMatchStatsLoadedMsg struct {
 	matchStats *entity.MatchStats
 }
 
-// Styling constants
-var (
-	titleStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FAFAFA")).
-			Background(lipgloss.Color("#7D56F4")).
-			Padding(0, 1)
-
-	errorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FF5F87"))
-
-	matchDetailStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#7D56F4")).
-			Padding(1, 2).
-			Margin(1, 0)
-
-	comparisonStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#7D56F4")).
-			Padding(1, 2).
-			Margin(1, 0)
-
-	player1Style = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#4ECDC4")).
-			Bold(true)
-
-	player2Style = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FF6B6B")).
-			Bold(true)
-
-	betterStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#96CEB4")).
-			Bold(true)
-
-	worseStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FF5F87")).
-			Bold(true)
-
-	// Match detail styles
-	matchInfoStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#E8E8E8")).
-			Bold(true)
-
-	matchValueStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Bold(true)
-
-	winnerStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFD700")).
-			Bold(true)
-
-	team1Style = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#4A90E2")).
-			Bold(true)
-
-	team2Style = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#E74C3C")).
-			Bold(true)
-
-	tableHeaderStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#B0B0B0")).
-			Bold(true)
-
-	playerNameStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Bold(true)
-
-	statsValueStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#E0E0E0"))
-
-	separatorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#555555"))
-
-	helpTextStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#888888")).
-		Italic(true)
+type mapStatsLoadedMsg struct {
+	maps []MapPerformance
+}
 
-	// Progress bar styles
-	progressBarStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#4A90E2")).
-		Background(lipgloss.Color("#2C3E50")).
-		Bold(true)
+type watchPolledMsg struct {
+	generation int
+	events     []watch.Event
+	statuses   []watch.PlayerStatus
+}
 
+type watchTickMsg struct {
+	timer int
+}
 
-	progressMessageStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#E8E8E8")).
-		Bold(true)
+type frequenciesLoadedMsg struct {
+	frequencies analytics.Frequencies
+	matches     []entity.PlayerMatchSummary // matches fetched for the analysis
+}
 
-	progressPercentageStyle = lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#4A90E2")).
-		Bold(true)
-)
+type sessionsLoadedMsg struct {
+	sessions []PlaySession
+	matches  []entity.PlayerMatchSummary // matches fetched to build the sessions
+}
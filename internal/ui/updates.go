@@ -5,33 +5,37 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/armitageee/faceit-cli/internal/analytics"
 	"github.com/armitageee/faceit-cli/internal/entity"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 // updateSearch handles key events in the search state
 func (m AppModel) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+c", "q":
+	k := m.keyMap()
+	switch {
+	case key.Matches(msg, k.Quit):
 		return m, tea.Quit
-	case "1":
+	case key.Matches(msg, k.SearchPlayer):
 		// Search player by nickname
 		m.state = StatePlayerSwitch
 		return m, nil
-	case "2":
+	case key.Matches(msg, k.SearchMatch):
 		// Search match by ID
 		m.state = StateMatchSearch
 		return m, nil
-	case "enter":
+	case key.Matches(msg, k.Select):
 		if strings.TrimSpace(m.searchInput) != "" {
 			m.loading = true
 			m.state = StateLoading
 			return m, m.loadPlayerProfile(m.searchInput)
 		}
-	case "backspace":
+	case key.Matches(msg, k.Delete):
 		if len(m.searchInput) > 0 {
 			m.searchInput = m.searchInput[:len(m.searchInput)-1]
 		}
@@ -45,50 +49,102 @@ func (m AppModel) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // updateProfile handles key events in the profile state
 func (m AppModel) updateProfile(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+c", "q":
+	k := m.keyMap()
+	switch {
+	case key.Matches(msg, k.Quit):
 		return m, tea.Quit
-	case "esc":
-		m.state = StateSearch
-		m.searchInput = ""
-		return m, nil
-	case "m":
+	case m.isBackKey(msg):
+		return m.back(StateSearch)
+	case key.Matches(msg, k.Matches):
 		// Load recent matches
-		m.loading = true
-		m.state = StateLoading
-		m.progress = 0
-		m.progressMessage = "Loading recent matches..."
-		m.progressType = "matches"
-		return m, tea.Batch(m.loadMatchesWithProgress(), m.simulateProgress())
-	case "s":
+		return m.openMatches()
+	case key.Matches(msg, k.Stats):
 		// Load statistics
-		m.loading = true
-		m.state = StateLoading
-		return m, m.loadStatistics()
-	case "c":
+		return m.refreshStats()
+	case key.Matches(msg, k.Compare):
 		// Compare with friend
-		m.state = StateComparisonInput
-		m.comparisonInput = ""
-		return m, nil
-	case "p":
+		return m.openComparisonInput()
+	case key.Matches(msg, k.SwitchPlayer):
 		// Switch player
-		m.state = StatePlayerSwitch
-		m.playerSwitchInput = ""
-		return m, nil
+		return m.openPlayerSwitch()
+	case key.Matches(msg, k.MapStats):
+		// Per-map analytics
+		return m.openMapStats()
+	case key.Matches(msg, k.Sessions):
+		// Play sessions
+		return m.openSessions()
+	case key.Matches(msg, k.Teammates):
+		// Frequent teammates and opponents
+		return m.openTeammates()
+	case key.Matches(msg, k.Watchlist):
+		// Watchlist dashboard
+		return m.openWatchlist()
 	}
 	return m, nil
 }
 
+// openMatches loads the recent matches of the current player
+func (m AppModel) openMatches() (tea.Model, tea.Cmd) {
+	m.loading = true
+	m.state = StateLoading
+	m.progress = 0
+	m.progressMessage = "Loading recent matches..."
+	m.progressType = "matches"
+	return m, tea.Batch(m.loadMatchesWithProgress(), m.simulateProgress())
+}
+
+// openComparisonInput asks for the players to compare with
+func (m AppModel) openComparisonInput() (tea.Model, tea.Cmd) {
+	m.state = StateComparisonInput
+	m.comparisonInput = ""
+	return m, nil
+}
+
+// openPlayerSwitch asks for the nickname of the player to switch to
+func (m AppModel) openPlayerSwitch() (tea.Model, tea.Cmd) {
+	m.state = StatePlayerSwitch
+	m.playerSwitchInput = ""
+	return m, nil
+}
+
+// openMapStats loads the per-map analytics
+func (m AppModel) openMapStats() (tea.Model, tea.Cmd) {
+	m.loading = true
+	m.state = StateLoading
+	return m, m.loadMapStats()
+}
+
+// openSessions loads the play sessions
+func (m AppModel) openSessions() (tea.Model, tea.Cmd) {
+	m.loading = true
+	m.state = StateLoading
+	return m, m.loadSessions()
+}
+
+// openTeammates loads the frequent teammates and opponents
+func (m AppModel) openTeammates() (tea.Model, tea.Cmd) {
+	m.loading = true
+	m.state = StateLoading
+	return m, m.loadFrequencies()
+}
+
+// openWatchlist starts the watchlist dashboard
+func (m AppModel) openWatchlist() (tea.Model, tea.Cmd) {
+	m.startWatch(m.profileWatchlist())
+	cmd := m.pollWatch()
+	return m, cmd
+}
+
 // updatePlayerSwitch handles key events in the player switch state
 func (m AppModel) updatePlayerSwitch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+c", "q":
+	k := m.keyMap()
+	switch {
+	case key.Matches(msg, k.ForceQuit):
 		return m, tea.Quit
-	case "esc":
-		m.state = StateProfile
+	case key.Matches(msg, k.Back):
 		m.playerSwitchInput = ""
-		return m, nil
-	case "enter":
+		return m.back(StateProfile)
+	case key.Matches(msg, k.Select):
 		if m.playerSwitchInput != "" {
 			// Add current player to recent players if not already there
 			if m.player != nil {
@@ -101,12 +157,12 @@ func (m AppModel) updatePlayerSwitch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, m.loadPlayerProfile(m.playerSwitchInput)
 		}
 		return m, nil
-	case "backspace":
+	case key.Matches(msg, k.Delete):
 		if len(m.playerSwitchInput) > 0 {
 			m.playerSwitchInput = m.playerSwitchInput[:len(m.playerSwitchInput)-1]
 		}
 		return m, nil
-	case "ctrl+v", "cmd+v":
+	case key.Matches(msg, k.Paste):
 		// Handle paste from clipboard
 		if content, err := GetClipboardContent(); err == nil && content != "" {
 			m.playerSwitchInput += content
@@ -122,52 +178,68 @@ func (m AppModel) updatePlayerSwitch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // updateMatches handles key events in the matches state
 func (m AppModel) updateMatches(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+c", "q":
+	if m.matchFilterEditing {
+		return m.updateMatchFilterInput(msg)
+	}
+
+	visible := m.visibleMatches()
+	k := m.keyMap()
+	switch {
+	case key.Matches(msg, k.Quit):
 		return m, tea.Quit
-	case "esc":
-		m.state = StateProfile
+	case m.isBackKey(msg):
+		// Clear an active filter before leaving the screen
+		if m.matchFilter.active() {
+			m.setMatchFilter(matchFilter{})
+			return m, nil
+		}
+		return m.back(StateProfile)
+	case key.Matches(msg, k.Filter):
+		// Open the filter bar with the active filter for editing
+		m.matchFilterEditing = true
+		m.matchFilterInput = m.matchFilter.text
+		m.matchFilterError = ""
+		return m, nil
+	case key.Matches(msg, k.SortNext):
+		// Cycle the sort field, starting in its default direction
+		m.setMatchSort((m.matchSortField+1)%matchSortFieldCount, false)
+		return m, nil
+	case key.Matches(msg, k.SortPrev):
+		m.setMatchSort((m.matchSortField+matchSortFieldCount-1)%matchSortFieldCount, false)
+		return m, nil
+	case key.Matches(msg, k.Reverse):
+		// Reverse the sort direction
+		m.setMatchSort(m.matchSortField, !m.matchSortReversed)
+		return m, nil
+	case key.Matches(msg, k.Compact):
+		// Toggle one line per match
+		m.compactMatches = !m.compactMatches
 		return m, nil
-	case "up", "k":
+	case key.Matches(msg, k.Up):
 		// Navigate to previous match
 		if m.selectedMatchIndex > 0 {
 			m.selectedMatchIndex--
 		}
 		return m, nil
-	case "down", "j":
+	case key.Matches(msg, k.Down):
 		// Navigate to next match
-		if m.selectedMatchIndex < len(m.matches)-1 {
+		if m.selectedMatchIndex < len(visible)-1 {
 			m.selectedMatchIndex++
 		}
 		return m, nil
-	case "left", "h":
+	case key.Matches(msg, k.Left):
 		// Go to previous page
-		if m.currentPage > 1 {
-			m.currentPage--
-			// Always reset cursor to first position on the new page
-			m.selectedMatchIndex = (m.currentPage - 1) * m.matchesPerPage
-		}
-		return m, nil
-	case "right", "l":
+		return m.turnPage(-1), nil
+	case key.Matches(msg, k.Right):
 		// Go to next page
-		totalPages := (len(m.matches) + m.matchesPerPage - 1) / m.matchesPerPage
-		if m.currentPage < totalPages {
-			m.currentPage++
-			// Always reset cursor to first position on the new page
-			m.selectedMatchIndex = (m.currentPage - 1) * m.matchesPerPage
-		}
-		return m, nil
-	case "enter":
-		// Load detailed view of the selected match
-		if len(m.matches) > 0 && m.selectedMatchIndex < len(m.matches) {
-			m.loading = true
-			m.state = StateLoading
-			return m, m.loadMatchDetail(m.matches[m.selectedMatchIndex].MatchID)
-		}
-	case "d", "D":
+		return m.turnPage(1), nil
+	case key.Matches(msg, k.Select):
+		return m.openMatchDetail()
+	case key.Matches(msg, k.MatchStats):
 		// Load detailed match statistics
-		if len(m.matches) > 0 && m.selectedMatchIndex < len(m.matches) {
-			m.selectedPlayerMatch = &m.matches[m.selectedMatchIndex]
+		if len(visible) > 0 && m.selectedMatchIndex < len(visible) {
+			match := visible[m.selectedMatchIndex]
+			m.selectedPlayerMatch = &match
 			m.loading = true
 			m.state = StateLoading
 			return m, m.loadPlayerMatchStats()
@@ -176,13 +248,245 @@ func (m AppModel) updateMatches(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// turnPage moves the matches screen to the previous or next page
+func (m AppModel) turnPage(delta int) AppModel {
+	totalPages := (len(m.visibleMatches()) + m.matchesPerPage - 1) / m.matchesPerPage
+	if page := m.currentPage + delta; page >= 1 && page <= totalPages {
+		m.currentPage = page
+		// Always reset cursor to first position on the new page
+		m.selectedMatchIndex = (page - 1) * m.matchesPerPage
+	}
+	return m
+}
+
+// openMatchDetail loads the detailed view of the selected match
+func (m AppModel) openMatchDetail() (tea.Model, tea.Cmd) {
+	visible := m.visibleMatches()
+	if len(visible) == 0 || m.selectedMatchIndex >= len(visible) {
+		return m, nil
+	}
+	m.loading = true
+	m.state = StateLoading
+	return m, m.loadMatchDetail(visible[m.selectedMatchIndex].MatchID)
+}
+
 // updateStats handles key events in the stats state
 func (m AppModel) updateStats(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+c", "q":
+	if m.statsWindowEditing {
+		return m.updateStatsWindowInput(msg)
+	}
+
+	k := m.keyMap()
+	switch {
+	case key.Matches(msg, k.Quit):
+		return m, tea.Quit
+	case m.isBackKey(msg):
+		return m.back(StateProfile)
+	case key.Matches(msg, k.NextWindow):
+		// Cycle through the window presets
+		m.statsWindowPreset = (m.statsWindowPreset + 1) % len(statsWindowPresets)
+		m.statsWindow = statsWindowPresets[m.statsWindowPreset]
+		return m.refreshStats()
+	case key.Matches(msg, k.CustomWindow):
+		// Enter a custom window
+		m.statsWindowEditing = true
+		m.statsWindowInput = ""
+		m.statsWindowError = ""
+		return m, nil
+	case key.Matches(msg, k.NextMetric):
+		// Cycle the charted metric
+		m.statsChartMetric = (m.statsChartMetric + 1) % chartMetricCount
+		return m, nil
+	case key.Matches(msg, k.PrevMetric):
+		m.statsChartMetric = (m.statsChartMetric + chartMetricCount - 1) % chartMetricCount
+		return m, nil
+	case key.Matches(msg, k.ChartKind):
+		// Toggle between line and bar chart
+		if m.statsChartKind == chartKindLine {
+			m.statsChartKind = chartKindBar
+		} else {
+			m.statsChartKind = chartKindLine
+		}
+		return m, nil
+	}
+	return m, nil
+}
+
+// updateStatsWindowInput handles key events while a custom statistics
+// window is being typed
+func (m AppModel) updateStatsWindowInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	k := m.keyMap()
+	switch {
+	case key.Matches(msg, k.ForceQuit):
+		return m, tea.Quit
+	case key.Matches(msg, k.Back):
+		m.statsWindowEditing = false
+		m.statsWindowError = ""
+		return m, nil
+	case key.Matches(msg, k.Select):
+		window, err := parseStatsWindow(m.statsWindowInput)
+		if err != nil {
+			m.statsWindowError = err.Error()
+			return m, nil
+		}
+		m.statsWindowEditing = false
+		m.statsWindowError = ""
+		m.statsWindow = window
+		return m.refreshStats()
+	case key.Matches(msg, k.Delete):
+		if len(m.statsWindowInput) > 0 {
+			m.statsWindowInput = m.statsWindowInput[:len(m.statsWindowInput)-1]
+		}
+	default:
+		if len(msg.String()) == 1 {
+			m.statsWindowInput += msg.String()
+		}
+	}
+	return m, nil
+}
+
+// refreshStats recomputes statistics for the selected window from the
+// loaded matches, fetching from the API only when they do not cover it
+func (m AppModel) refreshStats() (tea.Model, tea.Cmd) {
+	now := time.Now()
+	if m.statsWindow.coveredBy(m.matches, now) || m.statsWindow.truncated(m.matches, m.config.MaxMatchesToLoad, now) {
+		stats := calculateStats(m.statsWindow.apply(m.matches, now))
+		m.stats = &stats
+		m.state = StateStats
+		return m, nil
+	}
+	m.loading = true
+	// A new window reloads the stats screen rather than opening another one
+	if m.state != StateStats {
+		m.state = StateLoading
+	}
+	return m, m.loadStatistics()
+}
+
+// updateMapStats handles key events in the map breakdown state
+func (m AppModel) updateMapStats(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	k := m.keyMap()
+	switch {
+	case key.Matches(msg, k.Quit):
+		return m, tea.Quit
+	case m.isBackKey(msg):
+		return m.back(StateProfile)
+	case key.Matches(msg, k.Left):
+		// Sort by previous column
+		m.mapSortColumn = (m.mapSortColumn + mapSortColumnCount - 1) % mapSortColumnCount
+		sortMapPerformance(m.mapStats, m.mapSortColumn, m.mapSortDesc)
+		return m, nil
+	case key.Matches(msg, k.Right):
+		// Sort by next column
+		m.mapSortColumn = (m.mapSortColumn + 1) % mapSortColumnCount
+		sortMapPerformance(m.mapStats, m.mapSortColumn, m.mapSortDesc)
+		return m, nil
+	case key.Matches(msg, k.Reverse):
+		// Reverse sort direction
+		m.mapSortDesc = !m.mapSortDesc
+		sortMapPerformance(m.mapStats, m.mapSortColumn, m.mapSortDesc)
+		return m, nil
+	}
+	return m, nil
+}
+
+// updateSessions handles key events in the sessions state
+func (m AppModel) updateSessions(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	k := m.keyMap()
+	switch {
+	case key.Matches(msg, k.Quit):
+		return m, tea.Quit
+	case m.isBackKey(msg):
+		return m.back(StateProfile)
+	case key.Matches(msg, k.Up):
+		if m.selectedSessionIndex > 0 {
+			m.selectedSessionIndex--
+		}
+		return m, nil
+	case key.Matches(msg, k.Down):
+		if m.selectedSessionIndex < len(m.sessions)-1 {
+			m.selectedSessionIndex++
+		}
+		return m, nil
+	}
+	return m, nil
+}
+
+// updateTeammates handles key events in the teammates state
+func (m AppModel) updateTeammates(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	list := m.focusedFrequencies()
+
+	k := m.keyMap()
+	switch {
+	case key.Matches(msg, k.Quit):
+		return m, tea.Quit
+	case m.isBackKey(msg):
+		return m.back(StateProfile)
+	case key.Matches(msg, k.SwitchList, k.Left, k.Right):
+		// Switch between teammates and opponents
+		m.frequencyOpponents = !m.frequencyOpponents
+		m.selectedFrequencyIndex = 0
+		return m, nil
+	case key.Matches(msg, k.Up):
+		if m.selectedFrequencyIndex > 0 {
+			m.selectedFrequencyIndex--
+		}
+		return m, nil
+	case key.Matches(msg, k.Down):
+		if m.selectedFrequencyIndex < len(list)-1 {
+			m.selectedFrequencyIndex++
+		}
+		return m, nil
+	case key.Matches(msg, k.Select):
+		// Open the selected player's profile
+		if m.selectedFrequencyIndex < len(list) {
+			if m.player != nil {
+				m.addToRecentPlayers(m.player.Nickname)
+			}
+			selected := list[m.selectedFrequencyIndex]
+			m.searchInput = selected.Nickname
+			m.loading = true
+			m.state = StateLoading
+			return m, m.loadKnownPlayer(selected.PlayerID, selected.Nickname)
+		}
+		return m, nil
+	}
+	return m, nil
+}
+
+// updateWatch handles key events in the watchlist dashboard
+func (m AppModel) updateWatch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	k := m.keyMap()
+	switch {
+	case key.Matches(msg, k.Quit):
 		return m, tea.Quit
-	case "esc":
-		m.state = StateProfile
+	case m.isBackKey(msg):
+		// Leaving the dashboard stops polling
+		m.watchPolling = false
+		return m.back(StateProfile)
+	case key.Matches(msg, k.Up):
+		if m.watchSelected > 0 {
+			m.watchSelected--
+		}
+		return m, nil
+	case key.Matches(msg, k.Down):
+		if m.watchSelected < len(m.watchStatuses)-1 {
+			m.watchSelected++
+		}
+		return m, nil
+	case key.Matches(msg, k.Refresh):
+		// Poll now, without waiting for the next interval
+		return m.pollWatchNow()
+	case key.Matches(msg, k.Select):
+		// Open the selected player's profile
+		if m.watchSelected < len(m.watchStatuses) {
+			m.watchPolling = false
+			status := m.watchStatuses[m.watchSelected]
+			m.searchInput = status.Nickname
+			m.loading = true
+			m.state = StateLoading
+			return m, m.loadKnownPlayer(status.PlayerID, status.Nickname)
+		}
 		return m, nil
 	}
 	return m, nil
@@ -190,25 +494,24 @@ func (m AppModel) updateStats(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // updateMatchDetail handles key events in the match detail state
 func (m AppModel) updateMatchDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+c", "q":
+	k := m.keyMap()
+	switch {
+	case key.Matches(msg, k.Quit):
 		return m, tea.Quit
-	case "esc":
-		m.state = StateMatches
-		return m, nil
+	case m.isBackKey(msg):
+		return m.back(StateMatches)
 	}
 	return m, nil
 }
 
 // updateError handles key events in the error state
 func (m AppModel) updateError(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+c", "q":
+	k := m.keyMap()
+	switch {
+	case key.Matches(msg, k.Quit):
 		return m, tea.Quit
-	case "esc", "enter":
-		m.state = StateSearch
-		m.error = ""
-		return m, nil
+	case m.isBackKey(msg), key.Matches(msg, k.Select):
+		return m.back(StateSearch)
 	}
 	return m, nil
 }
@@ -242,8 +545,41 @@ func (m AppModel) loadPlayerProfile(nickname string) tea.Cmd {
 
 
 
+// loadKnownPlayer loads a player's profile by ID when it is known, which
+// still works after a nickname change, and by nickname otherwise
+func (m AppModel) loadKnownPlayer(playerID, nickname string) tea.Cmd {
+	if playerID == "" {
+		return m.loadPlayerProfile(nickname)
+	}
+	return m.loadPlayerProfileByID(playerID)
+}
+
+// loadPlayerProfileByID loads the profile of a player whose ID is known,
+// e.g. from a match scoreboard
+func (m AppModel) loadPlayerProfileByID(playerID string) tea.Cmd {
+	return func() tea.Msg {
+		m.logger.Info("Loading player profile", map[string]interface{}{
+			"player_id": playerID,
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		profile, err := m.repo.GetPlayerByID(ctx, playerID)
+		if err != nil {
+			m.logger.Error("Failed to load player profile", map[string]interface{}{
+				"player_id": playerID,
+				"error":     err.Error(),
+			})
+			return errorMsg{err: err.Error()}
+		}
+		return profileLoadedMsg{profile: *profile}
+	}
+}
+
 // loadBackgroundMatches loads matches in the background for better UX
 func (m AppModel) loadBackgroundMatches() tea.Cmd {
+	playerID, gameID := m.player.ID, m.game()
 	return func() tea.Msg {
 		// Add a small delay to avoid overwhelming the API
 		time.Sleep(50 * time.Millisecond)
@@ -256,7 +592,7 @@ func (m AppModel) loadBackgroundMatches() tea.Cmd {
 		// Calculate how many more we need to load
 		remaining := m.config.MaxMatchesToLoad - len(m.matches)
 		if remaining <= 0 {
-			return backgroundMatchesLoadedMsg{matches: []entity.PlayerMatchSummary{}}
+			return backgroundMatchesLoadedMsg{playerID: playerID, gameID: gameID}
 		}
 
 		// Load in larger batches for better performance
@@ -272,18 +608,21 @@ func (m AppModel) loadBackgroundMatches() tea.Cmd {
 			batchSize = remaining
 		}
 
-		matches, err := m.repo.GetPlayerRecentMatches(ctx, m.player.ID, "cs2", len(m.matches) + batchSize)
+		start := time.Now()
+		matches, err := m.repo.GetPlayerRecentMatches(ctx, playerID, gameID, len(m.matches) + batchSize)
+		m.metrics.RecordBackgroundLoad(ctx, "matches_batch", time.Since(start), err)
 		if err != nil {
 			// Don't return error for background loading, just return empty matches
-			return backgroundMatchesLoadedMsg{matches: []entity.PlayerMatchSummary{}}
+			return backgroundMatchesLoadedMsg{playerID: playerID, gameID: gameID}
 		}
 		
-		return backgroundMatchesLoadedMsg{matches: matches}
+		return backgroundMatchesLoadedMsg{playerID: playerID, gameID: gameID, matches: matches}
 	}
 }
 
 // loadBackgroundMatchesParallel loads multiple batches of matches in parallel
 func (m AppModel) loadBackgroundMatchesParallel() tea.Cmd {
+	playerID, gameID := m.player.ID, m.game()
 	return func() tea.Msg {
 		// Use a longer timeout for background loading
 		ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
@@ -292,11 +631,15 @@ func (m AppModel) loadBackgroundMatchesParallel() tea.Cmd {
 		// Calculate how many more we need to load
 		remaining := m.config.MaxMatchesToLoad - len(m.matches)
 		if remaining <= 0 {
-			return backgroundMatchesLoadedMsg{matches: []entity.PlayerMatchSummary{}}
+			return backgroundMatchesLoadedMsg{playerID: playerID, gameID: gameID}
 		}
 
 		// Try to load all remaining matches at once for maximum speed
-		matches, err := m.repo.GetPlayerRecentMatches(ctx, m.player.ID, "cs2", m.config.MaxMatchesToLoad)
+		start := time.Now()
+		matches, err := m.repo.GetPlayerRecentMatches(ctx, playerID, gameID, m.config.MaxMatchesToLoad)
+		defer func() {
+			m.metrics.RecordBackgroundLoad(ctx, "matches", time.Since(start), err)
+		}()
 		if err != nil {
 			// If full load fails, try loading in smaller batches
 			// Load in batches of 100 for better performance
@@ -305,30 +648,105 @@ func (m AppModel) loadBackgroundMatchesParallel() tea.Cmd {
 				batchSize = remaining
 			}
 			
-			matches, err = m.repo.GetPlayerRecentMatches(ctx, m.player.ID, "cs2", len(m.matches) + batchSize)
+			matches, err = m.repo.GetPlayerRecentMatches(ctx, playerID, gameID, len(m.matches) + batchSize)
 			if err != nil {
 				// Don't return error for background loading, just return empty matches
-				return backgroundMatchesLoadedMsg{matches: []entity.PlayerMatchSummary{}}
+				return backgroundMatchesLoadedMsg{playerID: playerID, gameID: gameID}
 			}
 		}
 		
-		return backgroundMatchesLoadedMsg{matches: matches}
+		return backgroundMatchesLoadedMsg{playerID: playerID, gameID: gameID, matches: matches}
 	}
 }
 
-// loadStatistics loads and calculates statistics from recent matches
+// loadStatistics fetches enough recent matches to cover the selected
+// window and calculates statistics over it
 func (m AppModel) loadStatistics() tea.Cmd {
+	window := m.statsWindow
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		// Time based windows may need the full history, so allow as long
+		// as background loading does
+		timeout := 10 * time.Second
+		if window.kind != statsWindowLastN {
+			timeout = 120 * time.Second
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
 		defer cancel()
 
-		matches, err := m.repo.GetPlayerRecentMatches(ctx, m.player.ID, "cs2", 20)
+		matches, err := m.repo.GetPlayerRecentMatches(ctx, m.player.ID, m.game(), window.fetchLimit(m.config.MaxMatchesToLoad))
 		if err != nil {
 			return errorMsg{err: err.Error()}
 		}
 		
-		stats := calculateStats(matches)
-		return statsLoadedMsg{stats: stats}
+		stats := calculateStats(window.apply(matches, time.Now()))
+		return statsLoadedMsg{stats: stats, matches: matches}
+	}
+}
+
+// loadMapStats builds the per-map breakdown from the loaded matches and
+// the lifetime map segments of the player's stats
+func (m AppModel) loadMapStats() tea.Cmd {
+	matches := m.matches
+	lifetime := m.lifetimeStats
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if len(matches) == 0 {
+			loaded, err := m.repo.GetPlayerRecentMatches(ctx, m.player.ID, m.game(), 20)
+			if err != nil {
+				return errorMsg{err: err.Error()}
+			}
+			matches = loaded
+		}
+
+		// Lifetime numbers are only used for comparison, so a failure here
+		// is not fatal
+		if lifetime == nil {
+			if stats, err := m.repo.GetPlayerStats(ctx, m.player.ID, m.game()); err == nil {
+				lifetime = stats
+			}
+		}
+
+		maps := calculateMapPerformance(matches)
+		attachLifetimeMapStats(maps, extractLifetimeMapStats(lifetime))
+		return mapStatsLoadedMsg{maps: maps}
+	}
+}
+
+// loadSessions groups the player's recent matches into play sessions
+func (m AppModel) loadSessions() tea.Cmd {
+	matches := m.matches
+	return func() tea.Msg {
+		if len(matches) == 0 {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			loaded, err := m.repo.GetPlayerRecentMatches(ctx, m.player.ID, m.game(), sessionMatchesToLoad)
+			if err != nil {
+				return errorMsg{err: err.Error()}
+			}
+			matches = loaded
+		}
+		return sessionsLoadedMsg{sessions: groupSessions(matches), matches: matches}
+	}
+}
+
+// loadFrequencies counts the player's most frequent teammates and opponents
+func (m AppModel) loadFrequencies() tea.Cmd {
+	matches := m.matches
+	return func() tea.Msg {
+		if len(matches) == 0 {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			loaded, err := m.repo.GetPlayerRecentMatches(ctx, m.player.ID, m.game(), frequencyMatchesToLoad)
+			if err != nil {
+				return errorMsg{err: err.Error()}
+			}
+			matches = loaded
+		}
+		return frequenciesLoadedMsg{frequencies: analytics.CalculateFrequencies(matches), matches: matches}
 	}
 }
 
@@ -428,24 +846,31 @@ func (m AppModel) extractEnemyTeamScore(score string) int {
 
 // updateComparisonInput handles key events in the comparison input state
 func (m AppModel) updateComparisonInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+c", "q":
+	k := m.keyMap()
+	switch {
+	case key.Matches(msg, k.ForceQuit):
 		return m, tea.Quit
-	case "esc":
-		m.state = StateProfile
+	case key.Matches(msg, k.Back):
 		m.comparisonInput = ""
-		return m, nil
-	case "enter":
+		m.comparisonError = ""
+		return m.back(StateProfile)
+	case key.Matches(msg, k.Select):
 		if strings.TrimSpace(m.comparisonInput) != "" {
+			nicknames, err := parseComparisonNicknames(m.comparisonInput, m.player.Nickname)
+			if err != nil {
+				m.comparisonError = err.Error()
+				return m, nil
+			}
+			m.comparisonError = ""
 			m.loading = true
 			m.state = StateLoading
-			return m, m.loadPlayerComparison(m.comparisonInput)
+			return m, m.loadPlayerComparison(nicknames)
 		}
-	case "backspace":
+	case key.Matches(msg, k.Delete):
 		if len(m.comparisonInput) > 0 {
 			m.comparisonInput = m.comparisonInput[:len(m.comparisonInput)-1]
 		}
-	case "ctrl+v", "cmd+v":
+	case key.Matches(msg, k.Paste):
 		// Handle paste from clipboard
 		if content, err := GetClipboardContent(); err == nil && content != "" {
 			m.comparisonInput += content
@@ -461,55 +886,169 @@ func (m AppModel) updateComparisonInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // updateComparison handles key events in the comparison state
 func (m AppModel) updateComparison(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+c", "q":
+	k := m.keyMap()
+	switch {
+	case key.Matches(msg, k.Quit):
 		return m, tea.Quit
-	case "esc":
-		m.state = StateProfile
-		m.comparison = nil
+	case m.isBackKey(msg):
+		return m.back(StateProfile)
+	case key.Matches(msg, k.Left):
+		// Rank by previous metric
+		m.comparisonSort = (m.comparisonSort + comparisonMetricCount - 1) % comparisonMetricCount
+		return m, nil
+	case key.Matches(msg, k.Right):
+		// Rank by next metric
+		m.comparisonSort = (m.comparisonSort + 1) % comparisonMetricCount
 		return m, nil
+	case key.Matches(msg, k.Up):
+		if m.comparisonSelected > 0 {
+			m.comparisonSelected--
+		}
+		return m.scrollToSelection(), nil
+	case key.Matches(msg, k.Down):
+		if m.comparisonSelected < len(m.comparison.Players)-1 {
+			m.comparisonSelected++
+		}
+		return m.scrollToSelection(), nil
+	case key.Matches(msg, k.PageUp):
+		return m.scrollBy(-m.pageLines()), nil
+	case key.Matches(msg, k.PageDown):
+		return m.scrollBy(m.pageLines()), nil
+	case key.Matches(msg, k.Select):
+		// Head-to-head between the current player and the selected one
+		index := m.comparison.ComparisonData.Rankings[m.comparisonSort][m.comparisonSelected]
+		if index == 0 {
+			return m, nil
+		}
+		m.loading = true
+		m.state = StateLoading
+		return m, m.loadHeadToHead(m.comparison.Players[0], m.comparison.Players[index])
+	}
+	return m, nil
+}
+
+// updateHeadToHead handles key events in the head-to-head state
+func (m AppModel) updateHeadToHead(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	k := m.keyMap()
+	switch {
+	case key.Matches(msg, k.Quit):
+		return m, tea.Quit
+	case m.isBackKey(msg):
+		return m.back(StateComparison)
 	}
 	return m, nil
 }
 
-// loadPlayerComparison loads comparison data between current player and friend
-func (m AppModel) loadPlayerComparison(friendNickname string) tea.Cmd {
+// comparisonTarget is a player to compare the current player with. The
+// profile is looked up by nickname when the ID is not known.
+type comparisonTarget struct {
+	nickname string
+	playerID string
+}
+
+// loadPlayerComparison loads comparison data between the current player
+// and the players with the given nicknames
+func (m AppModel) loadPlayerComparison(nicknames []string) tea.Cmd {
+	targets := make([]comparisonTarget, len(nicknames))
+	for i, nickname := range nicknames {
+		targets[i] = comparisonTarget{nickname: nickname}
+	}
+	return m.loadComparison(targets)
+}
+
+// loadComparison loads comparison data between the current player and the
+// given players. Players are fetched concurrently; any failure aborts the
+// comparison.
+func (m AppModel) loadComparison(targets []comparisonTarget) tea.Cmd {
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
-		// Get friend's profile
-		friendProfile, err := m.repo.GetPlayerByNickname(ctx, friendNickname)
-		if err != nil {
-			return errorMsg{err: fmt.Sprintf("Failed to load friend's profile: %v", err)}
+		players := make([]ComparedPlayer, len(targets)+1)
+		errs := make([]error, len(targets)+1)
+		var wg sync.WaitGroup
+
+		// Get current player's recent matches for comparison (always load exactly the same number for fair comparison)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			matches, err := m.repo.GetPlayerRecentMatches(ctx, m.player.ID, m.game(), m.config.ComparisonMatches)
+			if err != nil {
+				errs[0] = fmt.Errorf("failed to load current player's matches: %w", err)
+				return
+			}
+			players[0] = ComparedPlayer{Nickname: m.player.Nickname, PlayerID: m.player.ID, Stats: calculateStats(matches)}
+		}()
+
+		for i, target := range targets {
+			wg.Add(1)
+			go func(i int, nickname, playerID string) {
+				defer wg.Done()
+				if playerID == "" {
+					profile, err := m.repo.GetPlayerByNickname(ctx, nickname)
+					if err != nil {
+						errs[i] = fmt.Errorf("failed to load %s's profile: %w", nickname, err)
+						return
+					}
+					nickname, playerID = profile.Nickname, profile.ID
+				}
+				matches, err := m.repo.GetPlayerRecentMatches(ctx, playerID, m.game(), m.config.ComparisonMatches)
+				if err != nil {
+					errs[i] = fmt.Errorf("failed to load %s's matches: %w", nickname, err)
+					return
+				}
+				players[i] = ComparedPlayer{Nickname: nickname, PlayerID: playerID, Stats: calculateStats(matches)}
+			}(i+1, target.nickname, target.playerID)
 		}
+		wg.Wait()
 
-		// Get friend's recent matches
-		friendMatches, err := m.repo.GetPlayerRecentMatches(ctx, friendProfile.ID, "cs2", m.config.ComparisonMatches)
-		if err != nil {
-			return errorMsg{err: fmt.Sprintf("Failed to load friend's matches: %v", err)}
+		for _, err := range errs {
+			if err != nil {
+				return errorMsg{err: err.Error()}
+			}
 		}
 
-		// Get current player's recent matches for comparison (always load exactly the same number for fair comparison)
-		currentMatches, err := m.repo.GetPlayerRecentMatches(ctx, m.player.ID, "cs2", m.config.ComparisonMatches)
-		if err != nil {
-			return errorMsg{err: fmt.Sprintf("Failed to load current player's matches: %v", err)}
+		comparison := PlayerComparison{
+			Players:        players,
+			ComparisonData: calculateComparisonData(players),
 		}
+		return comparisonLoadedMsg{comparison: comparison}
+	}
+}
 
-		// Calculate stats for both players
-		currentStats := calculateStats(currentMatches)
-		friendStats := calculateStats(friendMatches)
+// loadHeadToHead finds the matches two compared players shared. The
+// comparison only keeps a few matches of each player, so both histories
+// are loaded again up to max_matches_to_load.
+func (m AppModel) loadHeadToHead(player1, player2 ComparedPlayer) tea.Cmd {
+	return func() tea.Msg {
+		// Long histories take as long as background loading does
+		ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+		defer cancel()
 
-		// Create comparison data
-		comparison := PlayerComparison{
-			Player1Nickname: m.player.Nickname,
-			Player2Nickname: friendProfile.Nickname,
-			Player1Stats:    currentStats,
-			Player2Stats:    friendStats,
-			ComparisonData:  calculateComparisonData(currentStats, friendStats),
+		players := []ComparedPlayer{player1, player2}
+		histories := make([][]entity.PlayerMatchSummary, len(players))
+		errs := make([]error, len(players))
+		var wg sync.WaitGroup
+		for i, player := range players {
+			wg.Add(1)
+			go func(i int, player ComparedPlayer) {
+				defer wg.Done()
+				matches, err := m.repo.GetPlayerRecentMatches(ctx, player.PlayerID, m.game(), m.config.MaxMatchesToLoad)
+				if err != nil {
+					errs[i] = fmt.Errorf("failed to load %s's matches: %w", player.Nickname, err)
+					return
+				}
+				histories[i] = matches
+			}(i, player)
 		}
+		wg.Wait()
 
-		return comparisonLoadedMsg{comparison: comparison}
+		for _, err := range errs {
+			if err != nil {
+				return errorMsg{err: err.Error()}
+			}
+		}
+		return headToHeadLoadedMsg{headToHead: calculateHeadToHead(player1.Nickname, histories[0], player2.Nickname, histories[1])}
 	}
 }
 
@@ -524,7 +1063,7 @@ func (m AppModel) loadLifetimeStats() tea.Cmd {
 		var err error
 		
 		// Try CS2 first
-		stats, err = m.repo.GetPlayerStats(ctx, m.player.ID, "cs2")
+		stats, err = m.repo.GetPlayerStats(ctx, m.player.ID, m.game())
 		if err != nil || stats == nil || stats.Lifetime == nil || len(stats.Lifetime) == 0 {
 			// Try CS:GO as fallback
 			stats, err = m.repo.GetPlayerStats(ctx, m.player.ID, "csgo")
@@ -546,18 +1085,22 @@ func (m AppModel) loadLifetimeStats() tea.Cmd {
 
 // updateMatchSearch handles input for match search
 func (m AppModel) updateMatchSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+c", "q", "Q":
+	k := m.keyMap()
+	switch {
+	case key.Matches(msg, k.ForceQuit):
 		return m, tea.Quit
-	case "enter":
+	case key.Matches(msg, k.Back):
+		m.matchSearchInput = ""
+		return m.back(StateSearch)
+	case key.Matches(msg, k.Select):
 		if m.matchSearchInput != "" {
 			return m, m.loadMatchStats()
 		}
-	case "backspace":
+	case key.Matches(msg, k.Delete):
 		if len(m.matchSearchInput) > 0 {
 			m.matchSearchInput = m.matchSearchInput[:len(m.matchSearchInput)-1]
 		}
-	case "ctrl+v", "cmd+v", "ctrl+V", "cmd+V", "f2", "F2", "p", "P":
+	case key.Matches(msg, k.Paste):
 		// Handle paste from clipboard
 		if content, err := GetClipboardContent(); err == nil && content != "" {
 			m.matchSearchInput += content
@@ -574,13 +1117,15 @@ func (m AppModel) updateMatchSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // updateMatchStats handles input for match statistics view
 func (m AppModel) updateMatchStats(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+c", "q", "Q":
-		m.state = StateSearch
-		m.matchSearchInput = ""
-		m.matchStats = nil
+	k := m.keyMap()
+	switch {
+	case key.Matches(msg, k.Quit):
+		return m, tea.Quit
+	case m.isBackKey(msg):
+		return m.back(StateSearch)
+	default:
+		return m.updateScoreboard(msg)
 	}
-	return m, nil
 }
 
 // loadMatchStats loads match statistics
@@ -600,17 +1145,15 @@ func (m AppModel) loadMatchStats() tea.Cmd {
 
 // updatePlayerMatchDetail handles input for player match detail view
 func (m AppModel) updatePlayerMatchDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "ctrl+c", "q", "Q":
+	k := m.keyMap()
+	switch {
+	case key.Matches(msg, k.Quit):
 		return m, tea.Quit
-	case "esc", "backspace":
-		// Return to matches view
-		m.state = StateMatches
-		return m, nil
+	case m.isBackKey(msg):
+		return m.back(StateMatches)
 	default:
-		// No other keys needed for this view
+		return m.updateScoreboard(msg)
 	}
-	return m, nil
 }
 
 // loadPlayerMatchStats loads detailed match statistics for a player's match
@@ -695,7 +1238,7 @@ func (m AppModel) loadMatchesWithProgress() tea.Cmd {
 			initialLimit = m.config.MaxMatchesToLoad
 		}
 		
-		matches, err := m.repo.GetPlayerRecentMatches(ctx, m.player.ID, "cs2", initialLimit)
+		matches, err := m.repo.GetPlayerRecentMatches(ctx, m.player.ID, m.game(), initialLimit)
 		if err != nil {
 			return errorMsg{err: err.Error()}
 		}
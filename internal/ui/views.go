@@ -5,36 +5,41 @@ import (
 	"strings"
 	"time"
 
+	"github.com/armitageee/faceit-cli/internal/analytics"
+	"github.com/armitageee/faceit-cli/internal/entity"
+
 	"github.com/charmbracelet/lipgloss"
 )
 
 // viewSearch renders the search screen
 func (m AppModel) viewSearch() string {
-	asciiTitle := generateASCIILogo()
+	k := m.keyMap()
 	title := titleStyle.Render("🎮 FACEIT CLI")
 	
 	var content strings.Builder
 	content.WriteString("Choose search option:\n\n")
-	content.WriteString("1. Search player by nickname\n")
-	content.WriteString("2. Search match by ID\n\n")
+	content.WriteString(fmt.Sprintf("%s. %s\n", k.SearchPlayer.Help().Key, k.SearchPlayer.Help().Desc))
+	content.WriteString(fmt.Sprintf("%s. %s\n\n", k.SearchMatch.Help().Key, k.SearchMatch.Help().Desc))
 	content.WriteString(fmt.Sprintf("Selected: %s", m.searchInput))
 	
 	search := searchStyle.Render(content.String())
-	help := helpStyle.Render("Press 1 or 2 to select • Ctrl+C or Q to quit")
+	help := helpStyle.Render(helpLine(
+		fmt.Sprintf("Press %s or %s to select", k.SearchPlayer.Help().Key, k.SearchMatch.Help().Key),
+		helpEntry(k.Help), helpEntry(k.Palette), quitHelpEntry(k.Quit)))
 
 	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
-		lipgloss.JoinVertical(lipgloss.Center, asciiTitle, title, search, help))
+		m.withLogo(title, search, help))
 }
 
 // viewMatchSearch renders the match search screen
 func (m AppModel) viewMatchSearch() string {
-	asciiTitle := generateASCIILogo()
+	k := m.keyMap()
 	title := titleStyle.Render("🔍 Search Match")
 	search := searchStyle.Render(fmt.Sprintf("Enter match ID:\n\n%s", m.matchSearchInput))
-	help := helpStyle.Render("Press Enter to search • P or F2 to paste from clipboard • Ctrl+C or Q to quit")
+	help := helpStyle.Render(helpLine(helpEntry(k.Select, "Search"), helpEntry(k.Paste), helpEntry(k.Back), quitHelpEntry(k.ForceQuit)))
 
 	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
-		lipgloss.JoinVertical(lipgloss.Center, asciiTitle, title, search, help))
+		m.withLogo(title, search, help))
 }
 
 // viewMatchStats renders the match statistics screen
@@ -42,8 +47,12 @@ func (m AppModel) viewMatchStats() string {
 	if m.matchStats == nil {
 		return "No match data"
 	}
+	return m.viewScrollable()
+}
 
-	asciiTitle := generateASCIILogo()
+// scoreboardParts returns the title, scoreboard and help of the match
+// statistics screens
+func (m AppModel) scoreboardParts(stats *entity.MatchStats) (string, string, string) {
 	title := titleStyle.Render("📊 Match Statistics")
 	
 	var content strings.Builder
@@ -51,64 +60,82 @@ func (m AppModel) viewMatchStats() string {
 	// Match information with colors
 	content.WriteString(fmt.Sprintf("%s %s\n", 
 		matchInfoStyle.Render("🎮 Match ID:"), 
-		matchValueStyle.Render(m.matchStats.MatchID)))
+		matchValueStyle.Render(stats.MatchID)))
 	content.WriteString(fmt.Sprintf("%s %s\n", 
 		matchInfoStyle.Render("🗺️  Map:"), 
-		matchValueStyle.Render(m.matchStats.Map)))
+		matchValueStyle.Render(stats.Map)))
 	content.WriteString(fmt.Sprintf("%s %s\n", 
 		matchInfoStyle.Render("📊 Final Score:"), 
-		matchValueStyle.Render(m.matchStats.Score)))
+		matchValueStyle.Render(stats.Score)))
 	content.WriteString(fmt.Sprintf("%s %s\n", 
 		matchInfoStyle.Render("✅ Status:"), 
-		matchValueStyle.Render(m.matchStats.Result)))
+		matchValueStyle.Render(stats.Result)))
 	
 	// Determine winner with golden color
 	winner := "Draw"
-	if m.matchStats.Team1.Score > m.matchStats.Team2.Score {
-		winner = fmt.Sprintf("🏆 Winner: %s", m.matchStats.Team1.TeamName)
-	} else if m.matchStats.Team2.Score > m.matchStats.Team1.Score {
-		winner = fmt.Sprintf("🏆 Winner: %s", m.matchStats.Team2.TeamName)
+	if stats.Team1.Score > stats.Team2.Score {
+		winner = fmt.Sprintf("🏆 Winner: %s", stats.Team1.TeamName)
+	} else if stats.Team2.Score > stats.Team1.Score {
+		winner = fmt.Sprintf("🏆 Winner: %s", stats.Team2.TeamName)
 	}
 	content.WriteString(fmt.Sprintf("%s\n\n", winnerStyle.Render(winner)))
 	
+	// Rows are numbered across both teams for the selection
+	row := 0
+
 	// Team 1 header with blue color
-	team1Header := fmt.Sprintf("🔵 %s (Score: %d)", m.matchStats.Team1.TeamName, m.matchStats.Team1.Score)
+	team1Header := fmt.Sprintf("🔵 %s (Score: %d)", stats.Team1.TeamName, stats.Team1.Score)
 	content.WriteString(team1Style.Render(team1Header) + "\n")
-	content.WriteString(separatorStyle.Render("────────────────────────────────────────────────") + "\n")
-	content.WriteString(tableHeaderStyle.Render("Player          K   D   A   K/D   HS%   ADR") + "\n")
-	content.WriteString(separatorStyle.Render("────────────────────────────────────────────────") + "\n")
+	content.WriteString(separatorStyle.Render("──────────────────────────────────────────────────") + "\n")
+	content.WriteString(tableHeaderStyle.Render("  Player          K   D   A   K/D   HS%   ADR") + "\n")
+	content.WriteString(separatorStyle.Render("──────────────────────────────────────────────────") + "\n")
 	
-	for _, player := range m.matchStats.Team1.Players {
-		playerName := playerNameStyle.Render(fmt.Sprintf("%-15s", player.Nickname))
-		stats := statsValueStyle.Render(fmt.Sprintf(" %2d  %2d  %2d  %4.2f  %4.1f  %5.1f",
-			player.Kills, player.Deaths, player.Assists,
-			player.KDRatio, player.HeadshotsPercentage, player.ADR))
-		content.WriteString(playerName + stats + "\n")
+	for _, player := range stats.Team1.Players {
+		prefix := "  "
+		if row == m.scoreboardSelected {
+			prefix = "▶ "
+		}
+		row++
+		name, values := scoreboardRow(player)
+		content.WriteString(prefix + playerNameStyle.Render(name) + statsValueStyle.Render(values) + "\n")
 	}
 	
 	content.WriteString("\n")
 	
 	// Team 2 header with red color
-	team2Header := fmt.Sprintf("🔴 %s (Score: %d)", m.matchStats.Team2.TeamName, m.matchStats.Team2.Score)
+	team2Header := fmt.Sprintf("🔴 %s (Score: %d)", stats.Team2.TeamName, stats.Team2.Score)
 	content.WriteString(team2Style.Render(team2Header) + "\n")
-	content.WriteString(separatorStyle.Render("────────────────────────────────────────────────") + "\n")
-	content.WriteString(tableHeaderStyle.Render("Player          K   D   A   K/D   HS%   ADR") + "\n")
-	content.WriteString(separatorStyle.Render("────────────────────────────────────────────────") + "\n")
+	content.WriteString(separatorStyle.Render("──────────────────────────────────────────────────") + "\n")
+	content.WriteString(tableHeaderStyle.Render("  Player          K   D   A   K/D   HS%   ADR") + "\n")
+	content.WriteString(separatorStyle.Render("──────────────────────────────────────────────────") + "\n")
 	
-	for _, player := range m.matchStats.Team2.Players {
-		playerName := playerNameStyle.Render(fmt.Sprintf("%-15s", player.Nickname))
-		stats := statsValueStyle.Render(fmt.Sprintf(" %2d  %2d  %2d  %4.2f  %4.1f  %5.1f",
-			player.Kills, player.Deaths, player.Assists,
-			player.KDRatio, player.HeadshotsPercentage, player.ADR))
-		content.WriteString(playerName + stats + "\n")
+	for _, player := range stats.Team2.Players {
+		prefix := "  "
+		if row == m.scoreboardSelected {
+			prefix = "▶ "
+		}
+		row++
+		name, values := scoreboardRow(player)
+		content.WriteString(prefix + playerNameStyle.Render(name) + statsValueStyle.Render(values) + "\n")
 	}
 	
-	content.WriteString("\n" + helpTextStyle.Render("📝 Press 'q' to go back to search"))
-	
-	help := helpStyle.Render(content.String())
+	k := m.keyMap()
+	entries := []string{pairHelpEntry(k.Up, k.Down, "Select player"), k.PageUp.Help().Key + "/" + k.PageDown.Help().Key + " - Scroll",
+		helpEntry(k.Select, "Open profile")}
+	if m.player != nil {
+		entries = append(entries, helpEntry(k.Compare, "Compare with "+m.player.Nickname))
+	}
+	entries = append(entries, backHelpEntry(k), quitHelpEntry(k.Quit))
+	help := helpStyle.Render("📝 " + helpLine(entries...))
 
-	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
-		lipgloss.JoinVertical(lipgloss.Center, asciiTitle, title, help))
+	return title, helpStyle.Render(strings.TrimSuffix(content.String(), "\n")), help
+}
+
+// scoreboardRow formats a player's name and values for a scoreboard row
+func scoreboardRow(player entity.PlayerMatchStats) (string, string) {
+	return fmt.Sprintf("%-15s", player.Nickname), fmt.Sprintf(" %2d  %2d  %2d  %4.2f  %4.1f  %5.1f",
+		player.Kills, player.Deaths, player.Assists,
+		player.KDRatio, player.HeadshotsPercentage, player.ADR)
 }
 
 // viewProfile renders the profile screen
@@ -117,18 +144,17 @@ func (m AppModel) viewProfile() string {
 		return "No profile data"
 	}
 
-	asciiTitle := generateASCIILogo()
 	title := titleStyle.Render("👤 " + m.player.Nickname)
 	
 	var content strings.Builder
 	content.WriteString(fmt.Sprintf("Country: %s\n", m.player.Country))
 	content.WriteString(fmt.Sprintf("ID: %s\n", m.player.ID))
 	
-	if cs2, ok := m.player.Games["cs2"]; ok {
-		content.WriteString("\n🎯 CS2 Stats:\n")
-		content.WriteString(fmt.Sprintf("  ELO: %d\n", cs2.Elo))
-		content.WriteString(fmt.Sprintf("  Skill Level: %d\n", cs2.SkillLevel))
-		content.WriteString(fmt.Sprintf("  Region: %s\n", cs2.Region))
+	if game, ok := m.player.Games[m.game()]; ok {
+		content.WriteString(fmt.Sprintf("\n🎯 %s Stats:\n", strings.ToUpper(m.game())))
+		content.WriteString(fmt.Sprintf("  ELO: %d\n", game.Elo))
+		content.WriteString(fmt.Sprintf("  Skill Level: %d\n", game.SkillLevel))
+		content.WriteString(fmt.Sprintf("  Region: %s\n", game.Region))
 		
 		// Add lifetime statistics if available
 		if m.lifetimeStats != nil {
@@ -143,10 +169,13 @@ func (m AppModel) viewProfile() string {
 	// Create beautiful ASCII frame
 	framedContent := generateProfileFrame(content.String())
 	profile := profileStyle.Render(framedContent)
-	help := helpStyle.Render("M - Recent matches • S - Statistics (20 matches) • C - Compare with friend • P - Switch player • Esc - Back to search • Ctrl+C or Q to quit")
+	k := m.keyMap()
+	help := helpStyle.Render(helpLine(helpEntry(k.Matches), helpEntry(k.Stats), helpEntry(k.MapStats), helpEntry(k.Sessions),
+		helpEntry(k.Teammates), helpEntry(k.Watchlist), helpEntry(k.Compare), helpEntry(k.SwitchPlayer),
+		backHelpEntry(k), helpEntry(k.Help), helpEntry(k.Palette), quitHelpEntry(k.Quit)))
 
 	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
-		lipgloss.JoinVertical(lipgloss.Center, asciiTitle, title, profile, help))
+		m.withLogo(title, profile, help))
 }
 
 // viewMatches renders the matches screen
@@ -155,53 +184,45 @@ func (m AppModel) viewMatches() string {
 		return "No matches found"
 	}
 
-	asciiTitle := generateASCIILogo()
 	title := titleStyle.Render("🏆 Recent Matches - " + m.player.Nickname)
+	visible := m.visibleMatches()
 	
 	// Calculate pagination info
 	startIndex := (m.currentPage - 1) * m.matchesPerPage
 	endIndex := startIndex + m.matchesPerPage
-	if endIndex > len(m.matches) {
-		endIndex = len(m.matches)
+	if endIndex > len(visible) {
+		endIndex = len(visible)
+	}
+	if startIndex > endIndex {
+		startIndex = endIndex
 	}
 	
 	// Show only matches for current page
-	pageMatches := m.matches[startIndex:endIndex]
+	pageMatches := visible[startIndex:endIndex]
 	
 	var content strings.Builder
-	for i, match := range pageMatches {
-		// Calculate global index for selection
-		globalIndex := startIndex + i
-		
-		// Highlight selected match
-		prefix := "  "
-		if globalIndex == m.selectedMatchIndex {
-			prefix = "▶ "
-		}
-		
-		resultStyle := lossStyle
-		if match.Result == "Win" {
-			resultStyle = winStyle
-		}
-		
-		finishedAt := time.Unix(match.FinishedAt, 0).Format("2006-01-02 15:04")
-		
-		content.WriteString(fmt.Sprintf("%s%s %s | %s | %s\n", 
-			prefix,
-			resultStyle.Render(match.Result),
-			match.Map,
-			match.Score,
-			finishedAt))
-		content.WriteString(fmt.Sprintf("    K/D/A: %d/%d/%d (%.2f) | HS: %.1f%%\n\n",
-			match.Kills, match.Deaths, match.Assists, match.KDRatio, match.HeadshotsPercentage))
+	if len(visible) == 0 {
+		content.WriteString("No matches match the filter")
+	} else {
+		content.WriteString(m.renderMatchTable(pageMatches, startIndex))
 	}
 
 	// Add pagination info
-	totalPages := (len(m.matches) + m.matchesPerPage - 1) / m.matchesPerPage
+	totalPages := (len(visible) + m.matchesPerPage - 1) / m.matchesPerPage
+	if totalPages == 0 {
+		totalPages = 1
+	}
 	startMatch := startIndex + 1
 	endMatch := endIndex
 	paginationInfo := fmt.Sprintf("Page %d/%d | Matches %d-%d of %d", 
-		m.currentPage, totalPages, startMatch, endMatch, len(m.matches))
+		m.currentPage, totalPages, startMatch, endMatch, len(visible))
+	if len(visible) == 0 {
+		paginationInfo = fmt.Sprintf("Page 1/1 | No matches of %d", len(m.matches))
+	}
+	paginationInfo += fmt.Sprintf(" | Sort: %s %s", m.matchSortField, sortIndicator(m.matchSortDescending()))
+	if m.matchFilter.active() {
+		paginationInfo += fmt.Sprintf(" | 🔍 %s (%d of %d)", m.matchFilter.text, len(visible), len(m.matches))
+	}
 	
 	// Add background loading indicator
 	if m.backgroundLoading {
@@ -211,25 +232,37 @@ func (m AppModel) viewMatches() string {
 		paginationInfo += fmt.Sprintf(" | 🔄 Loading more... (%d/%d, %.0f%%)", loaded, total, percentage)
 	}
 	
+	k := m.keyMap()
+	previousLink, nextLink := k.pageLinks()
 	if m.currentPage < totalPages {
-		paginationInfo += " | Next (→)"
+		paginationInfo += " | " + nextLink
 	}
 	if m.currentPage > 1 {
-		paginationInfo += " | Previous (←)"
+		paginationInfo += " | " + previousLink
 	}
 	
-	paginationStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#626262")).
-		Italic(true).
-		Align(lipgloss.Center)
-	
 	pagination := paginationStyle.Render(paginationInfo)
 
 	matches := matchesStyle.Render(content.String())
-	help := helpStyle.Render("↑↓/KJ - Navigate • ←→/HL - Change page • Enter - Match details • D - Match stats • Esc - Back to profile • Ctrl+C or Q to quit")
+	filterHelp, backHelp := helpEntry(k.Filter), backHelpEntry(k)
+	if m.matchFilter.active() {
+		filterHelp, backHelp = helpEntry(k.Filter, "Edit filter"), helpEntry(k.Back, "Clear filter")
+	}
+	help := helpStyle.Render(helpLine(pairHelpEntry(k.Up, k.Down, "Navigate"), pairHelpEntry(k.Left, k.Right, "Change page"),
+		helpEntry(k.SortNext), helpEntry(k.Reverse), helpEntry(k.Compact), filterHelp,
+		helpEntry(k.Select, "Match details"), helpEntry(k.MatchStats), backHelp, quitHelpEntry(k.Quit)))
+	if m.matchFilterEditing {
+		// Show the filter bar in place of the regular help line
+		input := fmt.Sprintf("Filter: / %s_  (e.g. ancient loss, kd > 1.3 and map = de_nuke, finished > 2026-01-01) • %s", m.matchFilterInput,
+			helpLine(helpEntry(k.Select, "Apply"), helpEntry(k.Back, "Cancel")))
+		if m.matchFilterError != "" {
+			input += "\n" + errorStyle.Render(m.matchFilterError)
+		}
+		help = helpStyle.Render(input)
+	}
 
 	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
-		lipgloss.JoinVertical(lipgloss.Center, asciiTitle, title, matches, pagination, help))
+		m.withLogo(title, matches, pagination, help))
 }
 
 // viewStats renders the statistics screen
@@ -238,11 +271,18 @@ func (m AppModel) viewStats() string {
 		return "No statistics data"
 	}
 
-	asciiTitle := generateASCIILogo()
-	title := titleStyle.Render("📊 Statistics (20 matches) - " + m.player.Nickname)
+	window := m.statsWindow.String()
+	if m.statsWindow.truncated(m.matches, m.config.MaxMatchesToLoad, time.Now()) {
+		window += fmt.Sprintf(", limited to the last %d matches", m.config.MaxMatchesToLoad)
+	}
+	title := titleStyle.Render(fmt.Sprintf("📊 Statistics (%s) - %s", window, m.player.Nickname))
+
 	
 	// Left side - Statistics
 	var statsContent strings.Builder
+	if m.stats.TotalMatches == 0 {
+		statsContent.WriteString(errorStyle.Render("No matches in this window") + "\n\n")
+	}
 	statsContent.WriteString("📈 Overall Performance:\n")
 	statsContent.WriteString(fmt.Sprintf("  Matches: %d | Wins: %d | Losses: %d\n", 
 		m.stats.TotalMatches, m.stats.Wins, m.stats.Losses))
@@ -283,20 +323,273 @@ func (m AppModel) viewStats() string {
 		statsContent.WriteString(fmt.Sprintf("    %s: %d matches\n", mapStat.name, mapStat.count))
 	}
 
-	// Right side - Streak information
-	streakInfo := generateStreakInfo(m.stats)
+	// Right side - Streak information and trend sparklines
+	var streakContent strings.Builder
+	streakContent.WriteString(generateStreakInfo(m.stats))
+	streakContent.WriteString("\n\n📉 Trends (oldest → newest):\n")
+	sparkWidth := 20
+	streakContent.WriteString(fmt.Sprintf("  K/D  %s\n", chartSeriesStyle.Render(sparkline(reverseValues(m.stats.KDChartData), sparkWidth))))
+	streakContent.WriteString(fmt.Sprintf("  ADR  %s\n", chartSeriesStyle.Render(sparkline(reverseValues(m.stats.ADRChartData), sparkWidth))))
+	streakContent.WriteString(fmt.Sprintf("  HS%%  %s", chartSeriesStyle.Render(sparkline(reverseValues(m.stats.HSChartData), sparkWidth))))
 	
 	// Create styled boxes
 	statsBox := statsStyle.Render(statsContent.String())
-	streakBox := statsStyle.Render(streakInfo)
+	streakBox := statsStyle.Render(streakContent.String())
 	
 	// Combine stats and streak info side by side
 	combinedContent := lipgloss.JoinHorizontal(lipgloss.Top, statsBox, "  ", streakBox)
+
+	chartBox := statsStyle.Render(m.renderStatsChart())
 	
-	help := helpStyle.Render("Esc - Back to profile • Ctrl+C or Q to quit")
+	k := m.keyMap()
+	help := helpStyle.Render(helpLine(helpEntry(k.NextWindow), helpEntry(k.CustomWindow), helpEntry(k.NextMetric), helpEntry(k.ChartKind),
+		backHelpEntry(k), quitHelpEntry(k.Quit)))
+	if m.statsWindowEditing {
+		// Show the window input in place of the regular help line
+		input := fmt.Sprintf("Window: > %s_  (e.g. 50, 7d, 2026-01-01, session) • %s", m.statsWindowInput,
+			helpLine(helpEntry(k.Select, "Apply"), helpEntry(k.Back, "Cancel")))
+		if m.statsWindowError != "" {
+			input += "\n" + errorStyle.Render(m.statsWindowError)
+		}
+		help = helpStyle.Render(input)
+	}
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
+		m.withLogo(title, combinedContent, chartBox, help))
+}
+
+// renderStatsChart renders the trend chart for the selected metric,
+// sized to fit the current terminal
+func (m AppModel) renderStatsChart() string {
+	var values []float64
+	switch m.statsChartMetric {
+	case chartMetricADR:
+		values = m.stats.ADRChartData
+	case chartMetricHS:
+		values = m.stats.HSChartData
+	default:
+		values = m.stats.KDChartData
+	}
+	values = reverseValues(values)
+
+	// Leave room for the box border and padding
+	width := m.width - 10
+	if width < 30 {
+		width = 30
+	}
+	if width > 100 {
+		width = 100
+	}
+	height := m.height / 4
+	if height < 4 {
+		height = 4
+	}
+	if height > 10 {
+		height = 10
+	}
+
+	title := fmt.Sprintf("📈 %s per match %s %s",
+		m.statsChartMetric,
+		chartSeriesStyle.Render("• value"),
+		chartOverlayStyle.Render(fmt.Sprintf("· %d-match average", chartRollingWindow)))
+
+	c := newChart(title, values, width, height)
+	c.kind = m.statsChartKind
+	c.format = m.statsChartMetric.valueFormat()
+	c.overlay = rollingAverage(values, chartRollingWindow)
+	return c.render()
+}
+
+// viewMapStats renders the per-map performance breakdown
+func (m AppModel) viewMapStats() string {
+	if len(m.mapStats) == 0 {
+		return "No map data"
+	}
+
+	title := titleStyle.Render("🗺️  Map Performance - " + m.player.Nickname)
+
+	// Header, with the active sort column marked
+	headers := []struct {
+		column mapSortColumn
+		format string
+	}{
+		{mapSortMap, "%-12s"},
+		{mapSortMatches, "%4s"},
+		{mapSortWinRate, "%7s"},
+		{mapSortKD, "%6s"},
+		{mapSortADR, "%6s"},
+		{mapSortHS, "%6s"},
+		{mapSortRounds, "%10s"},
+	}
+	var header strings.Builder
+	for _, h := range headers {
+		label := h.column.String()
+		if h.column == m.mapSortColumn {
+			label += sortIndicator(m.mapSortDesc)
+		}
+		header.WriteString(fmt.Sprintf(h.format, label))
+	}
+	header.WriteString(fmt.Sprintf("  %-6s", "Form"))
+	lifetimeLabel := mapSortLifetimeWinRate.String()
+	if m.mapSortColumn == mapSortLifetimeWinRate {
+		lifetimeLabel += sortIndicator(m.mapSortDesc)
+	}
+	header.WriteString(fmt.Sprintf("%12s%11s", lifetimeLabel, "Life K/D"))
+
+	var content strings.Builder
+	content.WriteString(tableHeaderStyle.Render(header.String()) + "\n")
+	content.WriteString(separatorStyle.Render(strings.Repeat("─", lipgloss.Width(header.String()))) + "\n")
+
+	for _, perf := range m.mapStats {
+		mapName := perf.Map
+		if len(mapName) > 11 {
+			mapName = mapName[:11]
+		}
+		content.WriteString(fmt.Sprintf("%-12s%4d", mapName, perf.Matches))
+		winRate := fmt.Sprintf("%6.0f%%", perf.WinRate)
+		if perf.WinRate >= 50 {
+			content.WriteString(winStyle.Render(winRate))
+		} else {
+			content.WriteString(lossStyle.Render(winRate))
+		}
+		content.WriteString(fmt.Sprintf("%6.2f%6.0f%5.0f%%", perf.AverageKD, perf.AverageADR, perf.AverageHS))
+		content.WriteString(fmt.Sprintf("%10s", fmt.Sprintf("%d-%d", perf.RoundsWon, perf.RoundsLost)))
+
+		// Recent form, newest first
+		content.WriteString("  ")
+		for _, r := range perf.RecentForm {
+			if r == 'W' {
+				content.WriteString(winStyle.Render("W"))
+			} else {
+				content.WriteString(lossStyle.Render("L"))
+			}
+		}
+		content.WriteString(strings.Repeat(" ", 6-len(perf.RecentForm)))
+
+		// Lifetime comparison: recent minus lifetime
+		if perf.Lifetime != nil {
+			content.WriteString(fmt.Sprintf("%6.0f%% ", perf.Lifetime.WinRate))
+			content.WriteString(formatMapDelta(perf.WinRate-perf.Lifetime.WinRate, "%+4.0f"))
+			content.WriteString(fmt.Sprintf("%5.2f ", perf.Lifetime.AverageKD))
+			content.WriteString(formatMapDelta(perf.AverageKD-perf.Lifetime.AverageKD, "%+5.2f"))
+		} else {
+			content.WriteString(helpStyle.Render(fmt.Sprintf("%12s%11s", "n/a", "n/a")))
+		}
+		content.WriteString("\n")
+	}
+
+	content.WriteString("\n" + helpTextStyle.Render(fmt.Sprintf("Form: last %d results per map, newest first • Deltas: recent vs lifetime", mapFormLength)))
+
+	table := statsStyle.Render(content.String())
+	k := m.keyMap()
+	help := helpStyle.Render(helpLine(pairHelpEntry(k.Left, k.Right, "Sort column"), helpEntry(k.Reverse, "Reverse order"),
+		backHelpEntry(k), quitHelpEntry(k.Quit)))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
+		m.withLogo(title, table, help))
+}
+
+// viewSessions renders the play sessions screen: a summary and the
+// matches of the selected session followed by the list of sessions
+func (m AppModel) viewSessions() string {
+	if len(m.sessions) == 0 {
+		return "No sessions found"
+	}
+
+	title := titleStyle.Render("🌙 Play Sessions - " + m.player.Nickname)
+
+	session := m.sessions[m.selectedSessionIndex]
+	heading := "Latest session"
+	if m.selectedSessionIndex > 0 {
+		heading = "Session of " + session.Start.Format("2006-01-02")
+	}
+
+	var summary strings.Builder
+	summary.WriteString(tableHeaderStyle.Render(heading) + "\n\n")
+	summary.WriteString(fmt.Sprintf("%s %s – %s (%s)\n",
+		matchInfoStyle.Render("Played:"),
+		session.Start.Format("2006-01-02 15:04"),
+		session.End.Format("15:04"),
+		formatSessionDuration(session.Duration())))
+	summary.WriteString(fmt.Sprintf("%s %d (%s / %s)\n",
+		matchInfoStyle.Render("Matches:"),
+		len(session.Matches),
+		winStyle.Render(fmt.Sprintf("%dW", session.Wins)),
+		lossStyle.Render(fmt.Sprintf("%dL", session.Losses))))
+	summary.WriteString(fmt.Sprintf("%s %s\n", matchInfoStyle.Render("ELO change:"), formatEloChange(session.EloChange, session.EloKnown)))
+	summary.WriteString(fmt.Sprintf("%s %.2f\n\n", matchInfoStyle.Render("Average K/D:"), session.AverageKD))
+
+	for _, match := range session.Matches {
+		resultStyle := lossStyle
+		if match.Result == "Win" {
+			resultStyle = winStyle
+		}
+		summary.WriteString(fmt.Sprintf("%s %s %-12s %-7s K/D %.2f\n",
+			time.Unix(match.FinishedAt, 0).Format("15:04"),
+			resultStyle.Render(fmt.Sprintf("%-4s", match.Result)),
+			match.Map,
+			match.Score,
+			match.KDRatio))
+	}
+
+	// Session list, newest first
+	var list strings.Builder
+	list.WriteString(tableHeaderStyle.Render(fmt.Sprintf("  %-18s%4s%8s%7s%8s", "Started", "M", "W-L", "K/D", "ELO")) + "\n")
+	for i, s := range m.sessions {
+		prefix := "  "
+		if i == m.selectedSessionIndex {
+			prefix = "▶ "
+		}
+		list.WriteString(fmt.Sprintf("%s%-18s%4d%8s%7.2f%8s\n",
+			prefix,
+			s.Start.Format("2006-01-02 15:04"),
+			len(s.Matches),
+			fmt.Sprintf("%d-%d", s.Wins, s.Losses),
+			s.AverageKD,
+			formatEloChange(s.EloChange, s.EloKnown)))
+	}
+	list.WriteString("\n" + helpTextStyle.Render(fmt.Sprintf("Sessions are split by breaks longer than %s", formatSessionDuration(sessionGap))))
+
+	boxes := lipgloss.JoinHorizontal(lipgloss.Top, statsStyle.Render(summary.String()), statsStyle.Render(list.String()))
+	k := m.keyMap()
+	help := helpStyle.Render(helpLine(pairHelpEntry(k.Up, k.Down, "Select session"), backHelpEntry(k), quitHelpEntry(k.Quit)))
 
 	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
-		lipgloss.JoinVertical(lipgloss.Center, asciiTitle, title, combinedContent, help))
+		m.withLogo(title, boxes, help))
+}
+
+// formatSessionDuration formats a duration as hours and minutes
+func formatSessionDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// formatEloChange formats an ELO change, or "n/a" when it is unknown
+func formatEloChange(change int, known bool) string {
+	if !known {
+		return "n/a"
+	}
+	return fmt.Sprintf("%+d", change)
+}
+
+// sortIndicator returns the arrow shown next to the active sort column
+func sortIndicator(descending bool) string {
+	if descending {
+		return "▼"
+	}
+	return "▲"
+}
+
+// formatMapDelta colours a recent-vs-lifetime difference
+func formatMapDelta(delta float64, format string) string {
+	text := fmt.Sprintf(format, delta)
+	if delta >= 0 {
+		return betterStyle.Render(text)
+	}
+	return worseStyle.Render(text)
 }
 
 // viewMatchDetail renders the detailed match statistics screen
@@ -305,7 +598,6 @@ func (m AppModel) viewMatchDetail() string {
 		return "No match detail data"
 	}
 
-	asciiTitle := generateASCIILogo()
 	title := titleStyle.Render("🔍 Match Details - " + m.matchDetail.Map)
 	
 	var content strings.Builder
@@ -366,16 +658,16 @@ func (m AppModel) viewMatchDetail() string {
 		m.matchDetail.TeamStats.EnemyTeamScore))
 
 	matchDetail := matchDetailStyle.Render(content.String())
-	help := helpStyle.Render("Esc - Back to matches • Ctrl+C or Q to quit")
+	k := m.keyMap()
+	help := helpStyle.Render(helpLine(backHelpEntry(k), quitHelpEntry(k.Quit)))
 
 	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
-		lipgloss.JoinVertical(lipgloss.Center, asciiTitle, title, matchDetail, help))
+		m.withLogo(title, matchDetail, help))
 }
 
 
 // viewPlayerSwitch renders the player switch screen
 func (m AppModel) viewPlayerSwitch() string {
-	asciiTitle := generateASCIILogo()
 	title := titleStyle.Render("🔄 Switch Player")
 	
 	var content strings.Builder
@@ -391,177 +683,322 @@ func (m AppModel) viewPlayerSwitch() string {
 	}
 	
 	playerSwitch := profileStyle.Render(content.String())
-	help := helpStyle.Render("Enter - Switch player • Esc - Back to profile • Ctrl+C or Q to quit")
+	k := m.keyMap()
+	help := helpStyle.Render(helpLine(helpEntry(k.Select, "Switch player"), helpEntry(k.Paste), helpEntry(k.Back), quitHelpEntry(k.ForceQuit)))
 	
 	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
-		lipgloss.JoinVertical(lipgloss.Center, asciiTitle, title, playerSwitch, help))
+		m.withLogo(title, playerSwitch, help))
 }
 
-// viewComparison renders the player comparison screen
+// viewComparison renders the comparison screen as a ranking table with
+// the best value of each column highlighted
 func (m AppModel) viewComparison() string {
-	if m.comparison == nil {
+	if m.comparison == nil || len(m.comparison.Players) == 0 {
 		return "No comparison data"
 	}
+	return m.viewScrollable()
+}
+
+// comparisonParts returns the title, ranking table and help of the
+// comparison screen
+func (m AppModel) comparisonParts() (string, string, string) {
+	title := titleStyle.Render(fmt.Sprintf("⚔️ Player Comparison (%d players)", len(m.comparison.Players)))
+
+	players := m.comparison.Players
+	data := m.comparison.ComparisonData
+
+	// Header, with the ranking column marked
+	var header strings.Builder
+	header.WriteString(fmt.Sprintf("  %-3s%-16s", "#", "Player"))
+	for metric := comparisonMetric(0); metric < comparisonMetricCount; metric++ {
+		label := metric.String()
+		if metric == m.comparisonSort {
+			label += sortIndicator(!metric.lowerIsBetter())
+		}
+		header.WriteString(fmt.Sprintf("%9s", label))
+	}
 
-	asciiTitle := generateASCIILogo()
-	title := titleStyle.Render("⚔️ Player Comparison")
-	
 	var content strings.Builder
-	
-	// Player names
-	content.WriteString(fmt.Sprintf("%s vs %s\n\n", 
-		player1Style.Render(m.comparison.Player1Nickname),
-		player2Style.Render(m.comparison.Player2Nickname)))
-	
-	// Basic stats comparison
-	content.WriteString("📊 Basic Statistics:\n")
-	content.WriteString(fmt.Sprintf("  K/D Ratio: %.2f vs %.2f (%s)\n", 
-		m.comparison.Player1Stats.AverageKDRatio,
-		m.comparison.Player2Stats.AverageKDRatio,
-		formatComparisonValue(m.comparison.ComparisonData.KDRatioDiff, m.comparison.ComparisonData.KDRatioDiff > 0)))
-	
-	content.WriteString(fmt.Sprintf("  Total K/D: %.2f vs %.2f (%s)\n", 
-		m.comparison.Player1Stats.TotalKDA,
-		m.comparison.Player2Stats.TotalKDA,
-		formatComparisonValue(m.comparison.ComparisonData.TotalKDADiff, m.comparison.ComparisonData.TotalKDADiff > 0)))
-	
-	content.WriteString(fmt.Sprintf("  Win Rate: %.1f%% vs %.1f%% (%s%%)\n", 
-		m.comparison.Player1Stats.WinRate,
-		m.comparison.Player2Stats.WinRate,
-		formatComparisonValue(m.comparison.ComparisonData.WinRateDiff, m.comparison.ComparisonData.WinRateDiff > 0)))
-	
-	content.WriteString(fmt.Sprintf("  Headshots: %.1f%% vs %.1f%% (%s%%)\n", 
-		m.comparison.Player1Stats.AverageHS,
-		m.comparison.Player2Stats.AverageHS,
-		formatComparisonValue(m.comparison.ComparisonData.AverageHSDiff, m.comparison.ComparisonData.AverageHSDiff > 0)))
-	
-	content.WriteString("\n🎯 Kills & Deaths:\n")
-	content.WriteString(fmt.Sprintf("  Total Kills: %d vs %d (%s)\n", 
-		m.comparison.Player1Stats.TotalKills,
-		m.comparison.Player2Stats.TotalKills,
-		formatComparisonInt(m.comparison.ComparisonData.TotalKillsDiff, m.comparison.ComparisonData.TotalKillsDiff > 0)))
-	
-	content.WriteString(fmt.Sprintf("  Total Deaths: %d vs %d (%s)\n", 
-		m.comparison.Player1Stats.TotalDeaths,
-		m.comparison.Player2Stats.TotalDeaths,
-		formatComparisonInt(m.comparison.ComparisonData.TotalDeathsDiff, m.comparison.ComparisonData.TotalDeathsDiff < 0)))
-	
-	content.WriteString(fmt.Sprintf("  Total Assists: %d vs %d (%s)\n", 
-		m.comparison.Player1Stats.TotalAssists,
-		m.comparison.Player2Stats.TotalAssists,
-		formatComparisonInt(m.comparison.ComparisonData.TotalAssistsDiff, m.comparison.ComparisonData.TotalAssistsDiff > 0)))
-	
-	content.WriteString("\n🏆 Performance:\n")
-	content.WriteString(fmt.Sprintf("  Best K/D: %.2f vs %.2f (%s)\n", 
-		m.comparison.Player1Stats.BestKDRatio,
-		m.comparison.Player2Stats.BestKDRatio,
-		formatComparisonValue(m.comparison.ComparisonData.BestKDDiff, m.comparison.ComparisonData.BestKDDiff > 0)))
-	
-	content.WriteString(fmt.Sprintf("  Worst K/D: %.2f vs %.2f (%s)\n", 
-		m.comparison.Player1Stats.WorstKDRatio,
-		m.comparison.Player2Stats.WorstKDRatio,
-		formatComparisonValue(m.comparison.ComparisonData.WorstKDDiff, m.comparison.ComparisonData.WorstKDDiff > 0)))
-	
+	content.WriteString(tableHeaderStyle.Render(header.String()) + "\n")
+	content.WriteString(separatorStyle.Render(strings.Repeat("─", lipgloss.Width(header.String()))) + "\n")
+
+	for rank, index := range data.Rankings[m.comparisonSort] {
+		player := players[index]
+		name := player.Nickname
+		if len(name) > 15 {
+			name = name[:15]
+		}
+		name = fmt.Sprintf("%-16s", name)
+		if index == 0 {
+			name = player1Style.Render(name)
+		}
+		prefix := "  "
+		if rank == m.comparisonSelected {
+			prefix = "▶ "
+		}
+		content.WriteString(fmt.Sprintf("%s%-3d%s", prefix, rank+1, name))
+
+		for metric := comparisonMetric(0); metric < comparisonMetricCount; metric++ {
+			cell := fmt.Sprintf("%9s", metric.format(player.Stats))
+			if data.isBestValue(players, metric, index) {
+				cell = betterStyle.Render(cell)
+			}
+			content.WriteString(cell)
+		}
+		content.WriteString("\n")
+	}
+
 	content.WriteString("\n🗺️ Maps:\n")
-	content.WriteString(fmt.Sprintf("  Most Played Together: %s\n", m.comparison.ComparisonData.MostPlayedMap))
-	content.WriteString(fmt.Sprintf("  Common Maps: %d\n", len(m.comparison.ComparisonData.CommonMaps)))
-	
-	comparison := comparisonStyle.Render(content.String())
-	help := helpStyle.Render("Esc - Back to profile • Ctrl+C or Q to quit")
-	
-	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
-		lipgloss.JoinVertical(lipgloss.Center, asciiTitle, title, comparison, help))
-}
+	content.WriteString(fmt.Sprintf("  Most Played Together: %s\n", data.MostPlayedMap))
+	if len(data.CommonMaps) > 0 {
+		content.WriteString(fmt.Sprintf("  Common Maps (%d): %s\n", len(data.CommonMaps), strings.Join(data.CommonMaps, ", ")))
+	} else {
+		content.WriteString("  Common Maps: 0\n")
+	}
+	content.WriteString("\n" + helpTextStyle.Render(fmt.Sprintf("Over the last %d matches of each player • Best value per column highlighted", m.config.ComparisonMatches)))
 
-// viewComparisonInput renders the comparison input screen
-func (m AppModel) viewComparisonInput() string {
-	asciiTitle := generateASCIILogo()
-	title := titleStyle.Render("⚔️ Compare with Friend")
-	search := searchStyle.Render(fmt.Sprintf("Enter friend's nickname:\n\n%s", m.comparisonInput))
-	help := helpStyle.Render("Press Enter to compare • Esc - Back to profile • Ctrl+C or Q to quit")
+	comparison := comparisonStyle.Render(content.String())
+	k := m.keyMap()
+	help := helpStyle.Render(helpLine(pairHelpEntry(k.Left, k.Right, "Rank by column"), pairHelpEntry(k.Up, k.Down, "Select player"),
+		k.PageUp.Help().Key+"/"+k.PageDown.Help().Key+" - Scroll", helpEntry(k.Select, "Head-to-head with selected"), backHelpEntry(k), quitHelpEntry(k.Quit)))
 
-	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
-		lipgloss.JoinVertical(lipgloss.Center, asciiTitle, title, search, help))
+	return title, comparison, help
 }
 
-// viewPlayerMatchDetail renders the detailed match statistics from player profile
-func (m AppModel) viewPlayerMatchDetail() string {
-	if m.playerMatchStats == nil {
-		return "No match data"
+// viewHeadToHead renders the shared-match analysis of two players
+func (m AppModel) viewHeadToHead() string {
+	if m.headToHead == nil {
+		return "No head-to-head data"
 	}
+	h2h := m.headToHead
 
-	asciiTitle := generateASCIILogo()
-	title := titleStyle.Render("📊 Match Statistics")
+	title := titleStyle.Render("🤝 Head-to-Head")
 
 	var content strings.Builder
-	
-	// Match information with colors
-	content.WriteString(fmt.Sprintf("%s %s\n", 
-		matchInfoStyle.Render("🎮 Match ID:"), 
-		matchValueStyle.Render(m.playerMatchStats.MatchID)))
-	content.WriteString(fmt.Sprintf("%s %s\n", 
-		matchInfoStyle.Render("🗺️  Map:"), 
-		matchValueStyle.Render(m.playerMatchStats.Map)))
-	content.WriteString(fmt.Sprintf("%s %s\n", 
-		matchInfoStyle.Render("📊 Final Score:"), 
-		matchValueStyle.Render(m.playerMatchStats.Score)))
-	content.WriteString(fmt.Sprintf("%s %s\n", 
-		matchInfoStyle.Render("✅ Status:"), 
-		matchValueStyle.Render(m.playerMatchStats.Result)))
+	content.WriteString(fmt.Sprintf("%s vs %s\n\n",
+		player1Style.Render(h2h.Player1),
+		player2Style.Render(h2h.Player2)))
 
-	// Determine winner with golden color
-	winner := "Draw"
-	if m.playerMatchStats.Team1.Score > m.playerMatchStats.Team2.Score {
-		winner = fmt.Sprintf("🏆 Winner: %s", m.playerMatchStats.Team1.TeamName)
-	} else if m.playerMatchStats.Team2.Score > m.playerMatchStats.Team1.Score {
-		winner = fmt.Sprintf("🏆 Winner: %s", m.playerMatchStats.Team2.TeamName)
+	if len(h2h.Matches) == 0 {
+		content.WriteString(helpTextStyle.Render("No shared matches in the loaded histories"))
+	} else {
+		writeRecord := func(heading string, record SharedRecord) {
+			content.WriteString(heading + "\n")
+			if record.Matches == 0 {
+				content.WriteString("  No matches\n\n")
+				return
+			}
+			content.WriteString(fmt.Sprintf("  Record (%s): %s / %s (%.0f%%)\n",
+				h2h.Player1,
+				winStyle.Render(fmt.Sprintf("%dW", record.Wins)),
+				lossStyle.Render(fmt.Sprintf("%dL", record.Losses)),
+				record.WinRate))
+			content.WriteString(fmt.Sprintf("  %-16s%8s%8s%12s\n", "", "K/D", "HS%", "K/D/A"))
+			for _, row := range []struct {
+				name  string
+				style lipgloss.Style
+				stats PlayerStatsSummary
+			}{
+				{h2h.Player1, player1Style, record.Player1Stats},
+				{h2h.Player2, player2Style, record.Player2Stats},
+			} {
+				name := row.name
+				if len(name) > 15 {
+					name = name[:15]
+				}
+				content.WriteString(fmt.Sprintf("  %s%8.2f%7.1f%%%12s\n",
+					row.style.Render(fmt.Sprintf("%-16s", name)),
+					row.stats.TotalKDA,
+					row.stats.AverageHS,
+					fmt.Sprintf("%d/%d/%d", row.stats.TotalKills, row.stats.TotalDeaths, row.stats.TotalAssists)))
+			}
+			content.WriteString("\n")
+		}
+		writeRecord("🤝 As teammates:", h2h.Together)
+		writeRecord("⚔️ As opponents:", h2h.Against)
+
+		content.WriteString("📋 Shared matches:\n")
+		for _, shared := range h2h.Matches {
+			side := "with"
+			if !shared.Teammates {
+				side = "vs  "
+			}
+			resultStyle := lossStyle
+			if shared.Player1.Result == "Win" {
+				resultStyle = winStyle
+			}
+			content.WriteString(fmt.Sprintf("  %s %s %s %-12s K/D %.2f vs %.2f\n",
+				time.Unix(shared.Player1.FinishedAt, 0).Format("2006-01-02"),
+				side,
+				resultStyle.Render(fmt.Sprintf("%-4s", shared.Player1.Result)),
+				shared.Player1.Map,
+				shared.Player1.KDRatio,
+				shared.Player2.KDRatio))
+		}
 	}
-	content.WriteString(fmt.Sprintf("%s\n\n", winnerStyle.Render(winner)))
 
-	// Team 1 header with blue color
-	team1Header := fmt.Sprintf("🔵 %s (Score: %d)", m.playerMatchStats.Team1.TeamName, m.playerMatchStats.Team1.Score)
-	content.WriteString(team1Style.Render(team1Header) + "\n")
-	content.WriteString(separatorStyle.Render("────────────────────────────────────────────────") + "\n")
-	content.WriteString(tableHeaderStyle.Render("Player          K   D   A   K/D   HS%   ADR") + "\n")
-	content.WriteString(separatorStyle.Render("────────────────────────────────────────────────") + "\n")
+	content.WriteString("\n" + helpTextStyle.Render(fmt.Sprintf("Searched the last %d matches of %s and %d of %s", h2h.Sample1, h2h.Player1, h2h.Sample2, h2h.Player2)))
+
+	box := comparisonStyle.Render(content.String())
+	k := m.keyMap()
+	help := helpStyle.Render(helpLine(backHelpEntry(k), quitHelpEntry(k.Quit)))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
+		m.withLogo(title, box, help))
+}
 
-	for _, player := range m.playerMatchStats.Team1.Players {
-		playerName := playerNameStyle.Render(fmt.Sprintf("%-15s", player.Nickname))
-		stats := statsValueStyle.Render(fmt.Sprintf(" %2d  %2d  %2d  %4.2f  %4.1f  %5.1f",
-			player.Kills, player.Deaths, player.Assists,
-			player.KDRatio, player.HeadshotsPercentage, player.ADR))
-		content.WriteString(playerName + stats + "\n")
+// viewTeammates renders the most frequent teammates and opponents side
+// by side
+func (m AppModel) viewTeammates() string {
+	if m.frequencies == nil {
+		return "No teammate data"
 	}
 
-	content.WriteString("\n")
+	title := titleStyle.Render(fmt.Sprintf("👥 Teammates & Opponents - %s (%d matches)", m.player.Nickname, m.frequencies.Matches))
 
-	// Team 2 header with red color
-	team2Header := fmt.Sprintf("🔴 %s (Score: %d)", m.playerMatchStats.Team2.TeamName, m.playerMatchStats.Team2.Score)
-	content.WriteString(team2Style.Render(team2Header) + "\n")
-	content.WriteString(separatorStyle.Render("────────────────────────────────────────────────") + "\n")
-	content.WriteString(tableHeaderStyle.Render("Player          K   D   A   K/D   HS%   ADR") + "\n")
-	content.WriteString(separatorStyle.Render("────────────────────────────────────────────────") + "\n")
+	renderList := func(heading string, list []analytics.PlayerFrequency, focused bool) string {
+		var content strings.Builder
+		content.WriteString(tableHeaderStyle.Render(heading) + "\n\n")
+		content.WriteString(tableHeaderStyle.Render(fmt.Sprintf("  %-16s%4s%8s%7s", "Player", "M", "W-L", "Win%")) + "\n")
+		if len(list) == 0 {
+			content.WriteString(helpTextStyle.Render("  No players found") + "\n")
+		}
+		for i, p := range analytics.Top(list, frequencyListLength) {
+			prefix := "  "
+			if focused && i == m.selectedFrequencyIndex {
+				prefix = "▶ "
+			}
+			name := p.Nickname
+			if len(name) > 15 {
+				name = name[:15]
+			}
+			winRate := fmt.Sprintf("%6.0f%%", p.WinRate)
+			if p.WinRate >= 50 {
+				winRate = winStyle.Render(winRate)
+			} else {
+				winRate = lossStyle.Render(winRate)
+			}
+			content.WriteString(fmt.Sprintf("%s%-16s%4d%8s%s\n", prefix, name, p.Matches, fmt.Sprintf("%d-%d", p.Wins, p.Losses), winRate))
+		}
+		style := statsStyle
+		if focused {
+			style = style.BorderForeground(currentTheme.Focus)
+		}
+		return style.Render(content.String())
+	}
+
+	lists := lipgloss.JoinHorizontal(lipgloss.Top,
+		renderList("🤝 Teammates (your win rate with them)", m.frequencies.Teammates, !m.frequencyOpponents),
+		renderList("⚔️ Opponents (your win rate against them)", m.frequencies.Opponents, m.frequencyOpponents))
+	k := m.keyMap()
+	help := helpStyle.Render(helpLine(pairHelpEntry(k.Up, k.Down, "Select player"), helpEntry(k.SwitchList),
+		helpEntry(k.Select, "Open profile"), backHelpEntry(k), quitHelpEntry(k.Quit)))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
+		m.withLogo(title, lists, help))
+}
+
+// viewWatch renders the watchlist dashboard
+func (m AppModel) viewWatch() string {
+	title := titleStyle.Render(fmt.Sprintf("👀 Watchlist (every %s)", m.watcher.Interval()))
+	now := time.Now()
+
+	var content strings.Builder
+	content.WriteString(tableHeaderStyle.Render(fmt.Sprintf("  %-16s%6s%7s  %-28s%10s", "Player", "ELO", "Δ", "Last match", "Checked")) + "\n")
+	for i, status := range m.watchStatuses {
+		prefix := "  "
+		if i == m.watchSelected {
+			prefix = "▶ "
+		}
+		name := status.Nickname
+		if len(name) > 15 {
+			name = name[:15]
+		}
+		name = fmt.Sprintf("%-16s", name)
+		if m.isRecentWatchEvent(status, now) {
+			name = betterStyle.Render(name)
+		}
+
+		elo := "-"
+		if status.Elo > 0 {
+			elo = fmt.Sprintf("%d", status.Elo)
+		}
+		delta := fmt.Sprintf("%7s", "")
+		if status.EloChange > 0 {
+			delta = winStyle.Render(fmt.Sprintf("%+7d", status.EloChange))
+		} else if status.EloChange < 0 {
+			delta = lossStyle.Render(fmt.Sprintf("%+7d", status.EloChange))
+		}
+
+		lastMatch := "-"
+		if status.LastMatch != nil {
+			lastMatch = fmt.Sprintf("%s %s %s", status.LastMatch.Result, status.LastMatch.Map, status.LastMatch.Score)
+		}
+		if len(lastMatch) > 27 {
+			lastMatch = lastMatch[:27]
+		}
+
+		checked := fmt.Sprintf("%10s", "pending")
+		if status.Err != nil {
+			checked = errorStyle.Render(fmt.Sprintf("%10s", "error"))
+		} else if !status.LastChecked.IsZero() {
+			checked = fmt.Sprintf("%10s", status.LastChecked.Format("15:04:05"))
+		}
 
-	for _, player := range m.playerMatchStats.Team2.Players {
-		playerName := playerNameStyle.Render(fmt.Sprintf("%-15s", player.Nickname))
-		stats := statsValueStyle.Render(fmt.Sprintf(" %2d  %2d  %2d  %4.2f  %4.1f  %5.1f",
-			player.Kills, player.Deaths, player.Assists,
-			player.KDRatio, player.HeadshotsPercentage, player.ADR))
-		content.WriteString(playerName + stats + "\n")
+		content.WriteString(fmt.Sprintf("%s%s%6s%s  %-28s%s\n", prefix, name, elo, delta, lastMatch, checked))
+	}
+
+	content.WriteString("\n" + tableHeaderStyle.Render("Activity") + "\n")
+	if len(m.watchEvents) == 0 {
+		content.WriteString(helpTextStyle.Render("No new matches or ELO changes yet") + "\n")
+	}
+	for _, entry := range m.watchEvents {
+		line := fmt.Sprintf("%s  %s", entry.at.Format("15:04"), entry.event)
+		if entry.event.Match != nil && entry.event.Match.Result == "Win" {
+			line = winStyle.Render(line)
+		} else if entry.event.Match != nil {
+			line = lossStyle.Render(line)
+		}
+		content.WriteString(line + "\n")
 	}
 
-	content.WriteString("\n" + helpTextStyle.Render("📝 Press 'Esc' to go back to matches"))
+	dashboard := statsStyle.Render(content.String())
+	k := m.keyMap()
+	help := helpStyle.Render(helpLine(pairHelpEntry(k.Up, k.Down, "Select player"), helpEntry(k.Select, "Open profile"),
+		helpEntry(k.Refresh), backHelpEntry(k), quitHelpEntry(k.Quit)))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
+		m.withLogo(title, dashboard, help))
+}
 
-	help := helpStyle.Render(content.String())
+// viewComparisonInput renders the comparison input screen
+func (m AppModel) viewComparisonInput() string {
+	title := titleStyle.Render("⚔️ Compare Players")
+	prompt := fmt.Sprintf("Enter up to %d nicknames, separated by commas or spaces:\n\n%s", maxComparisonPlayers-1, m.comparisonInput)
+	if m.comparisonError != "" {
+		prompt += "\n\n" + errorStyle.Render(m.comparisonError)
+	}
+	search := searchStyle.Render(prompt)
+	k := m.keyMap()
+	help := helpStyle.Render(helpLine(helpEntry(k.Select, "Compare"), helpEntry(k.Paste), helpEntry(k.Back), quitHelpEntry(k.ForceQuit)))
 
 	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
-		lipgloss.JoinVertical(lipgloss.Center, asciiTitle, title, help))
+		m.withLogo(title, search, help))
+}
+
+// viewPlayerMatchDetail renders the detailed match statistics from player profile
+func (m AppModel) viewPlayerMatchDetail() string {
+	if m.playerMatchStats == nil {
+		return "No match data"
+	}
+	return m.viewScrollable()
 }
 
 // viewError renders the error screen
 func (m AppModel) viewError() string {
 	error := errorStyle.Render(fmt.Sprintf("❌ Error: %s", m.error))
-	help := helpStyle.Render("Esc or Enter - Back to search • Ctrl+C or Q to quit")
+	k := m.keyMap()
+	help := helpStyle.Render(helpLine(backHelpEntry(k), helpEntry(k.Select, "Back"), quitHelpEntry(k.Quit)))
 	
 	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
 		lipgloss.JoinVertical(lipgloss.Center, error, help))
@@ -652,7 +1089,6 @@ func (m AppModel) renderProgressBar() string {
 
 // renderLoadingScreen renders a loading screen with progress bar
 func (m AppModel) renderLoadingScreen() string {
-	asciiTitle := generateASCIILogo()
 	title := titleStyle.Render("⏳ Loading...")
 	
 	progressContent := m.renderProgressBar()
@@ -660,5 +1096,5 @@ func (m AppModel) renderLoadingScreen() string {
 	help := helpStyle.Render("Please wait while we load your data...")
 
 	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
-		lipgloss.JoinVertical(lipgloss.Center, asciiTitle, title, progressContent, help))
+		m.withLogo(title, progressContent, help))
 }
@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/armitageee/faceit-cli/internal/notify"
+	"github.com/armitageee/faceit-cli/internal/watch"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// watchEventLogLength is the number of recent events kept on the dashboard
+const watchEventLogLength = 8
+
+// watchHighlightPolls is the number of polls for which a player with a new
+// match or ELO change stays highlighted
+const watchHighlightPolls = 3
+
+// notifyTimeout bounds the delivery of the notifications of one poll
+const notifyTimeout = 30 * time.Second
+
+// watchLogEntry is an event shown in the dashboard's activity log
+type watchLogEntry struct {
+	at    time.Time
+	event watch.Event
+}
+
+// WithWatchlist returns a model that opens on the watchlist dashboard for
+// the given players
+func (m AppModel) WithWatchlist(nicknames []string) AppModel {
+	m.loading = false
+	m.searchInput = ""
+	m.startWatch(nicknames)
+	return m
+}
+
+// WithNotifier returns a model that sends a notification whenever a
+// watched player finishes a match
+func (m AppModel) WithNotifier(notifier notify.Notifier) AppModel {
+	m.notifier = notifier
+	return m
+}
+
+// WithEloRecorder returns a model whose watchers pass the ELO change of
+// every match they see to record, so loaded matches can show it
+func (m AppModel) WithEloRecorder(record watch.Recorder) AppModel {
+	m.recordElo = record
+	return m
+}
+
+// startWatch replaces the current watcher and switches to the dashboard.
+// The caller is responsible for issuing the first poll, which is marked
+// as running here.
+func (m *AppModel) startWatch(nicknames []string) {
+	interval := time.Duration(m.config.WatchInterval) * time.Second
+	m.watcher = watch.New(m.repo, nicknames, interval).WithRecorder(m.recordElo)
+	m.watchStatuses = m.watcher.Statuses()
+	m.watchEvents = nil
+	m.watchSelected = 0
+	m.watchGeneration++
+	m.watchPolling = true
+	m.watchPollInFlight = true
+	m.state = StateWatch
+}
+
+// profileWatchlist returns the configured watchlist with the current
+// player added to the front when they are not on it already
+func (m AppModel) profileWatchlist() []string {
+	var nicknames []string
+	if m.player != nil {
+		nicknames = append(nicknames, m.player.Nickname)
+	}
+	for _, nickname := range m.config.WatchPlayers {
+		duplicate := false
+		for _, existing := range nicknames {
+			if strings.EqualFold(existing, nickname) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			nicknames = append(nicknames, nickname)
+		}
+	}
+	return nicknames
+}
+
+// pollWatch runs one poll of the watcher in the background. Callers check
+// that no poll is running: the watcher saves each player's new baseline
+// during a poll, so the events of every poll must be applied.
+func (m *AppModel) pollWatch() tea.Cmd {
+	m.watchPollInFlight = true
+	watcher := m.watcher
+	generation := m.watchGeneration
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), watcher.Interval())
+		defer cancel()
+
+		events := watcher.Poll(ctx)
+		return watchPolledMsg{generation: generation, events: events, statuses: watcher.Statuses()}
+	}
+}
+
+// scheduleWatchPoll waits one interval before asking for the next poll
+func (m AppModel) scheduleWatchPoll() tea.Cmd {
+	timer := m.watchTimer
+	return tea.Tick(m.watcher.Interval(), func(time.Time) tea.Msg {
+		return watchTickMsg{timer: timer}
+	})
+}
+
+// pollWatchNow polls without waiting for the pending timer, which is
+// cancelled so only one polling loop runs. A running poll is not
+// repeated; it schedules the next one when it returns.
+func (m AppModel) pollWatchNow() (tea.Model, tea.Cmd) {
+	if m.watchPollInFlight {
+		return m, nil
+	}
+	m.watchTimer++
+	cmd := m.pollWatch()
+	return m, cmd
+}
+
+// notifyWatchEvents delivers notifications for the finished matches among
+// the events in the background
+func (m AppModel) notifyWatchEvents(events []watch.Event) tea.Cmd {
+	if m.notifier == nil {
+		return nil
+	}
+	var notifications []notify.Notification
+	for _, event := range events {
+		if n, ok := notify.FromEvent(event); ok {
+			notifications = append(notifications, n)
+		}
+	}
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	notifier := m.notifier
+	appLogger := m.logger
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+		defer cancel()
+
+		for _, n := range notifications {
+			if err := notifier.Notify(ctx, n); err != nil && appLogger != nil {
+				appLogger.Warn("Failed to send notification", map[string]interface{}{
+					"player": n.Player,
+					"error":  err.Error(),
+				})
+			}
+		}
+		return nil
+	}
+}
+
+// isRecentWatchEvent reports whether a player's last change happened
+// recently enough to be highlighted
+func (m AppModel) isRecentWatchEvent(status watch.PlayerStatus, now time.Time) bool {
+	if status.LastEvent.IsZero() || m.watcher == nil {
+		return false
+	}
+	return now.Sub(status.LastEvent) < watchHighlightPolls*m.watcher.Interval()
+}
@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/armitageee/faceit-cli/internal/config"
+	"github.com/armitageee/faceit-cli/internal/entity"
+	"github.com/armitageee/faceit-cli/internal/notify"
+	"github.com/armitageee/faceit-cli/internal/watch"
+)
+
+func TestProfileWatchlist(t *testing.T) {
+	model := AppModel{
+		config: &config.Config{WatchPlayers: []string{"friend", "Me", "rival"}},
+		player: &entity.PlayerProfile{Nickname: "me"},
+	}
+
+	expected := []string{"me", "friend", "rival"}
+	if got := model.profileWatchlist(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("profileWatchlist() = %v, want %v", got, expected)
+	}
+}
+
+func TestWatchPolledMsg(t *testing.T) {
+	model := AppModel{config: &config.Config{WatchInterval: 60}}.WithWatchlist([]string{"alice"})
+	if model.state != StateWatch || !model.watchPolling {
+		t.Fatalf("Expected the dashboard to be polling, got state %v", model.state)
+	}
+
+	event := watch.Event{Nickname: "alice", Match: &entity.PlayerMatchSummary{MatchID: "m1", Result: "Win"}}
+	statuses := []watch.PlayerStatus{{Nickname: "alice", Elo: 2000, LastEvent: time.Now()}}
+
+	// Results of an earlier watcher are ignored
+	updated, cmd := model.Update(watchPolledMsg{generation: model.watchGeneration - 1, events: []watch.Event{event}})
+	if len(updated.(AppModel).watchEvents) != 0 || cmd != nil {
+		t.Error("Expected a stale poll to be ignored")
+	}
+
+	updated, cmd = model.Update(watchPolledMsg{generation: model.watchGeneration, events: []watch.Event{event}, statuses: statuses})
+	result := updated.(AppModel)
+	if len(result.watchEvents) != 1 || result.watchStatuses[0].Elo != 2000 {
+		t.Errorf("Expected the poll to be recorded, got %+v", result.watchEvents)
+	}
+	if cmd == nil {
+		t.Error("Expected the next poll to be scheduled")
+	}
+	if !result.isRecentWatchEvent(result.watchStatuses[0], time.Now()) {
+		t.Error("Expected a fresh change to be highlighted")
+	}
+
+	// Once polling stops no further poll is scheduled
+	result.watchPolling = false
+	if _, cmd := result.Update(watchPolledMsg{generation: result.watchGeneration}); cmd != nil {
+		t.Error("Expected no poll to be scheduled after leaving the dashboard")
+	}
+}
+
+func TestWatchRefreshWhilePolling(t *testing.T) {
+	model := AppModel{config: &config.Config{WatchInterval: 60}}.WithWatchlist([]string{"alice"})
+	model.watcher = watch.New(nil, []string{"alice"}, time.Minute)
+
+	// Refreshing or coming back while the first poll runs starts no second poll
+	if _, cmd := model.Update(keyMsg("r")); cmd != nil {
+		t.Error("Expected no second poll while one is running")
+	}
+	model.watchPolling = false
+	updated, cmd := model.back(StateWatch)
+	if model = updated.(AppModel); model.state != StateWatch || cmd != nil || !model.watchPolling {
+		t.Fatalf("Expected the dashboard to resume without a second poll, got state %v", model.state)
+	}
+
+	// The running poll is applied and schedules the next one
+	event := watch.Event{Nickname: "alice", Match: &entity.PlayerMatchSummary{MatchID: "m1", Result: "Win"}}
+	model = sendMsg(model, watchPolledMsg{generation: model.watchGeneration, events: []watch.Event{event}})
+	if len(model.watchEvents) != 1 || model.watchPollInFlight {
+		t.Fatalf("Expected the poll to be applied, got %+v", model.watchEvents)
+	}
+
+	// Refreshing cancels the pending timer
+	timer := model.watchTimer
+	model = typeKeys(model, "r")
+	if !model.watchPollInFlight || model.watchTimer == timer {
+		t.Error("Expected a refresh to poll now")
+	}
+	if _, cmd := model.Update(watchTickMsg{timer: timer}); cmd != nil {
+		t.Error("Expected the cancelled timer to be ignored")
+	}
+}
+
+func TestWatchNotifications(t *testing.T) {
+	recorder := &notify.Recorder{}
+	model := AppModel{config: &config.Config{WatchInterval: 60}}.WithNotifier(recorder).WithWatchlist([]string{"alice"})
+
+	events := []watch.Event{
+		{Nickname: "alice", Elo: 2010, EloChange: 10},
+		{Nickname: "alice", Match: &entity.PlayerMatchSummary{MatchID: "m1", Result: "Win"}, Elo: 2025, EloChange: 15},
+	}
+	if cmd := model.notifyWatchEvents(events); cmd == nil {
+		t.Fatal("Expected a notification command")
+	} else {
+		cmd()
+	}
+
+	got := recorder.Notifications()
+	if len(got) != 1 || got[0].Match.MatchID != "m1" || got[0].EloChange != 15 {
+		t.Errorf("Expected one notification for the finished match, got %+v", got)
+	}
+
+	if cmd := model.notifyWatchEvents(events[:1]); cmd != nil {
+		t.Error("Expected no notification without a finished match")
+	}
+	if cmd := model.WithNotifier(nil).notifyWatchEvents(events); cmd != nil {
+		t.Error("Expected no notification when notifications are disabled")
+	}
+}
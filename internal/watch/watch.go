@@ -0,0 +1,253 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/armitageee/faceit-cli/internal/cache"
+	"github.com/armitageee/faceit-cli/internal/entity"
+	"github.com/armitageee/faceit-cli/internal/repository"
+)
+
+// DefaultInterval is the polling interval used when none is configured
+const DefaultInterval = time.Minute
+
+// MinInterval is the shortest allowed polling interval. Every poll costs
+// a few API requests per player, so polling faster would only burn the
+// rate limit.
+const MinInterval = 15 * time.Second
+
+// gameID is the game whose ELO and matches are watched
+const gameID = "cs2"
+
+// PlayerStatus is the last known state of a watched player
+type PlayerStatus struct {
+	Nickname    string
+	PlayerID    string
+	Elo         int
+	SkillLevel  int
+	LastMatch   *entity.PlayerMatchSummary
+	EloChange   int       // change seen with the most recent event, zero if none
+	LastChecked time.Time // zero until the first successful poll
+	LastEvent   time.Time // when a new match or ELO change was last seen
+	Err         error     // error of the most recent poll, if any
+
+	pendingMatch *entity.PlayerMatchSummary // new match held until its ELO moves
+}
+
+// Event reports a change detected for a watched player
+type Event struct {
+	Nickname string
+	// Match is the newly finished match, or nil when only the ELO changed.
+	// Its EloChange is set when the ELO moved since the previous poll or,
+	// for a match found before its ELO, by the poll after.
+	Match     *entity.PlayerMatchSummary
+	Elo       int
+	EloChange int
+}
+
+// String returns a one-line description of the event
+func (e Event) String() string {
+	if e.Match == nil {
+		return fmt.Sprintf("%s: ELO %d (%+d)", e.Nickname, e.Elo, e.EloChange)
+	}
+	text := fmt.Sprintf("%s: %s on %s %s, K/D %.2f",
+		e.Nickname, e.Match.Result, e.Match.Map, e.Match.Score, e.Match.KDRatio)
+	if e.EloChange != 0 {
+		text += fmt.Sprintf(", ELO %d (%+d)", e.Elo, e.EloChange)
+	}
+	return text
+}
+
+// Recorder saves a finished match together with the ELO change the
+// watcher worked out for it, which FACEIT's match history does not report
+type Recorder func(ctx context.Context, playerID, gameID string, match entity.PlayerMatchSummary)
+
+// Watcher polls the latest match and ELO of a list of players
+type Watcher struct {
+	repo     repository.FaceitRepository
+	interval time.Duration
+	now      func() time.Time
+	record   Recorder // nil when ELO changes are not recorded
+
+	mu      sync.Mutex
+	players []*PlayerStatus
+}
+
+// New creates a watcher for the given nicknames. Intervals below
+// MinInterval are raised to it. The repository should be the cached one
+// shared with the rest of the application: each poll only asks for data
+// that is at most one interval old, so concurrent readers reuse it.
+func New(repo repository.FaceitRepository, nicknames []string, interval time.Duration) *Watcher {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	if interval < MinInterval {
+		interval = MinInterval
+	}
+
+	players := make([]*PlayerStatus, 0, len(nicknames))
+	for _, nickname := range nicknames {
+		players = append(players, &PlayerStatus{Nickname: nickname})
+	}
+
+	return &Watcher{
+		repo:     repo,
+		interval: interval,
+		now:      time.Now,
+		players:  players,
+	}
+}
+
+// WithRecorder passes every new match whose ELO change is known to
+// record. A nil recorder disables recording.
+func (w *Watcher) WithRecorder(record Recorder) *Watcher {
+	w.record = record
+	return w
+}
+
+// Interval returns the polling interval
+func (w *Watcher) Interval() time.Duration {
+	return w.interval
+}
+
+// Statuses returns a snapshot of every watched player in watchlist order
+func (w *Watcher) Statuses() []PlayerStatus {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	statuses := make([]PlayerStatus, len(w.players))
+	for i, p := range w.players {
+		statuses[i] = *p
+	}
+	return statuses
+}
+
+// Poll checks every player once and returns the changes found. The first
+// successful poll of a player only records a baseline. A new match found
+// before the player's ELO moved is held until the next poll so it can be
+// reported with its ELO change. Players are polled one after another to
+// spread the requests out.
+func (w *Watcher) Poll(ctx context.Context) []Event {
+	ctx = cache.WithMaxAge(ctx, w.interval)
+
+	var events []Event
+	for i := range w.players {
+		w.mu.Lock()
+		previous := *w.players[i]
+		w.mu.Unlock()
+
+		next, found := w.pollPlayer(ctx, previous)
+		for _, event := range found {
+			if event.Match != nil && event.EloChange != 0 && w.record != nil {
+				w.record(ctx, next.PlayerID, gameID, *event.Match)
+			}
+		}
+
+		w.mu.Lock()
+		*w.players[i] = next
+		w.mu.Unlock()
+
+		events = append(events, found...)
+	}
+	return events
+}
+
+// pollPlayer fetches one player's profile and latest match and compares
+// them with the previous status
+func (w *Watcher) pollPlayer(ctx context.Context, previous PlayerStatus) (PlayerStatus, []Event) {
+	next := previous
+
+	profile, err := w.repo.GetPlayerByNickname(ctx, previous.Nickname)
+	if err != nil {
+		next.Err = fmt.Errorf("load profile: %w", err)
+		return next, nil
+	}
+	matches, err := w.repo.GetPlayerRecentMatches(ctx, profile.ID, gameID, 1)
+	if err != nil {
+		next.Err = fmt.Errorf("load matches: %w", err)
+		return next, nil
+	}
+
+	game := profile.Games[gameID]
+	next.PlayerID = profile.ID
+	next.Nickname = profile.Nickname
+	next.Elo = game.Elo
+	next.SkillLevel = game.SkillLevel
+	next.LastChecked = w.now()
+	next.Err = nil
+	if len(matches) > 0 {
+		latest := matches[0]
+		next.LastMatch = &latest
+	}
+
+	// The first poll only establishes the baseline
+	if previous.LastChecked.IsZero() {
+		return next, nil
+	}
+
+	tracked := previous.Elo > 0 && game.Elo > 0
+	eloChange := 0
+	if tracked {
+		eloChange = game.Elo - previous.Elo
+	}
+	newMatch := next.LastMatch != nil &&
+		(previous.LastMatch == nil || previous.LastMatch.MatchID != next.LastMatch.MatchID)
+
+	var events []Event
+	next.pendingMatch = nil
+	if previous.pendingMatch != nil {
+		// The held match takes the ELO change seen since, unless a newer
+		// match may have caused it
+		match := *previous.pendingMatch
+		if !newMatch {
+			match.EloChange = eloChange
+			if next.LastMatch != nil && next.LastMatch.MatchID == match.MatchID {
+				next.LastMatch.EloChange = eloChange
+			}
+			next.EloChange = eloChange
+			eloChange = 0
+		}
+		events = append(events, Event{Nickname: next.Nickname, Match: &match, Elo: next.Elo, EloChange: match.EloChange})
+	}
+
+	switch {
+	case newMatch && eloChange == 0 && tracked:
+		// Matches usually show up before the ELO they earned, so hold the
+		// match for one poll to report it with its ELO change
+		held := *next.LastMatch
+		next.pendingMatch = &held
+	case newMatch || eloChange != 0:
+		event := Event{Nickname: next.Nickname, Elo: next.Elo, EloChange: eloChange}
+		if newMatch {
+			next.LastMatch.EloChange = eloChange
+			match := *next.LastMatch
+			event.Match = &match
+		}
+		next.EloChange = eloChange
+		events = append(events, event)
+	}
+
+	if len(events) > 0 {
+		next.LastEvent = next.LastChecked
+	}
+	return next, events
+}
+
+// Run polls immediately and then once per interval until the context is
+// cancelled, passing the events of every poll to handle
+func (w *Watcher) Run(ctx context.Context, handle func([]Event)) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		handle(w.Poll(ctx))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
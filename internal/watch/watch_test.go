@@ -0,0 +1,214 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/armitageee/faceit-cli/internal/entity"
+)
+
+// fakeRepository serves profiles and latest matches that tests can change
+// between polls
+type fakeRepository struct {
+	elo     map[string]int
+	matches map[string][]entity.PlayerMatchSummary
+	fail    map[string]bool
+}
+
+func newFakeRepository() *fakeRepository {
+	return &fakeRepository{
+		elo:     make(map[string]int),
+		matches: make(map[string][]entity.PlayerMatchSummary),
+		fail:    make(map[string]bool),
+	}
+}
+
+func (f *fakeRepository) GetPlayerByNickname(ctx context.Context, nickname string) (*entity.PlayerProfile, error) {
+	if f.fail[nickname] {
+		return nil, errors.New("player not found")
+	}
+	return &entity.PlayerProfile{
+		ID:       "id-" + nickname,
+		Nickname: nickname,
+		Games:    map[string]entity.GameDetail{"cs2": {Elo: f.elo[nickname], SkillLevel: 8}},
+	}, nil
+}
+
+func (f *fakeRepository) GetPlayerByID(ctx context.Context, playerID string) (*entity.PlayerProfile, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeRepository) GetPlayerStats(ctx context.Context, playerID, gameID string) (*entity.PlayerStats, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeRepository) GetPlayerRecentMatches(ctx context.Context, playerID string, gameID string, limit int) ([]entity.PlayerMatchSummary, error) {
+	matches := f.matches[strings.TrimPrefix(playerID, "id-")]
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches, nil
+}
+
+func (f *fakeRepository) GetMatchStats(ctx context.Context, matchID string) (*entity.MatchStats, error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestNewClampsInterval(t *testing.T) {
+	repo := newFakeRepository()
+	if got := New(repo, nil, 0).Interval(); got != DefaultInterval {
+		t.Errorf("Expected default interval %s, got %s", DefaultInterval, got)
+	}
+	if got := New(repo, nil, time.Second).Interval(); got != MinInterval {
+		t.Errorf("Expected minimum interval %s, got %s", MinInterval, got)
+	}
+}
+
+func TestWatcherPoll(t *testing.T) {
+	repo := newFakeRepository()
+	repo.elo["alice"] = 2000
+	repo.elo["bob"] = 1500
+	repo.matches["alice"] = []entity.PlayerMatchSummary{{MatchID: "a1", Result: "Win", Map: "de_mirage"}}
+	repo.matches["bob"] = []entity.PlayerMatchSummary{{MatchID: "b1", Result: "Loss"}}
+
+	var recorded []entity.PlayerMatchSummary
+	w := New(repo, []string{"alice", "bob"}, time.Minute).
+		WithRecorder(func(ctx context.Context, playerID, gameID string, match entity.PlayerMatchSummary) {
+			recorded = append(recorded, match)
+		})
+	ctx := context.Background()
+
+	// The first poll only records a baseline
+	if events := w.Poll(ctx); len(events) != 0 {
+		t.Fatalf("Expected no events on the first poll, got %v", events)
+	}
+	statuses := w.Statuses()
+	if len(statuses) != 2 || statuses[0].Elo != 2000 || statuses[0].LastMatch.MatchID != "a1" {
+		t.Fatalf("Unexpected baseline: %+v", statuses)
+	}
+
+	// Nothing changed
+	if events := w.Poll(ctx); len(events) != 0 {
+		t.Fatalf("Expected no events without changes, got %v", events)
+	}
+
+	// Alice finished a match and gained ELO; Bob's ELO was corrected
+	repo.elo["alice"] = 2025
+	repo.matches["alice"] = []entity.PlayerMatchSummary{{MatchID: "a2", Result: "Win", Map: "de_nuke", Score: "13-9", KDRatio: 1.4}}
+	repo.elo["bob"] = 1490
+
+	events := w.Poll(ctx)
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+
+	alice := events[0]
+	if alice.Match == nil || alice.Match.MatchID != "a2" {
+		t.Fatalf("Expected a new match event for alice, got %+v", alice)
+	}
+	if alice.EloChange != 25 || alice.Match.EloChange != 25 {
+		t.Errorf("Expected ELO change +25 on the event and match, got %d / %d", alice.EloChange, alice.Match.EloChange)
+	}
+	if !strings.Contains(alice.String(), "Win on de_nuke 13-9") || !strings.Contains(alice.String(), "(+25)") {
+		t.Errorf("Unexpected event description: %s", alice.String())
+	}
+
+	bob := events[1]
+	if bob.Match != nil || bob.EloChange != -10 {
+		t.Errorf("Expected an ELO-only event of -10 for bob, got %+v", bob)
+	}
+
+	statuses = w.Statuses()
+	if statuses[0].EloChange != 25 || statuses[0].LastEvent.IsZero() {
+		t.Errorf("Expected alice's status to record the change, got %+v", statuses[0])
+	}
+
+	// Only the new match with a known ELO change is recorded
+	if len(recorded) != 1 || recorded[0].MatchID != "a2" || recorded[0].EloChange != 25 {
+		t.Errorf("Expected alice's match to be recorded with +25, got %+v", recorded)
+	}
+}
+
+func TestWatcherHoldsMatchUntilEloMoves(t *testing.T) {
+	repo := newFakeRepository()
+	repo.elo["alice"] = 2000
+	repo.matches["alice"] = []entity.PlayerMatchSummary{{MatchID: "a1", Result: "Win"}}
+
+	var recorded []entity.PlayerMatchSummary
+	w := New(repo, []string{"alice"}, time.Minute).
+		WithRecorder(func(ctx context.Context, playerID, gameID string, match entity.PlayerMatchSummary) {
+			recorded = append(recorded, match)
+		})
+	ctx := context.Background()
+	w.Poll(ctx)
+
+	// The match shows up before the ELO it earned
+	repo.matches["alice"] = []entity.PlayerMatchSummary{{MatchID: "a2", Result: "Win", Map: "de_nuke"}}
+	if events := w.Poll(ctx); len(events) != 0 {
+		t.Fatalf("Expected the match to be held until the ELO moves, got %v", events)
+	}
+
+	// The ELO follows and is reported with the match
+	repo.elo["alice"] = 2020
+	events := w.Poll(ctx)
+	if len(events) != 1 || events[0].Match == nil || events[0].Match.MatchID != "a2" {
+		t.Fatalf("Expected one match event for a2, got %+v", events)
+	}
+	if events[0].EloChange != 20 || events[0].Match.EloChange != 20 {
+		t.Errorf("Expected ELO change +20 on the event and match, got %d / %d", events[0].EloChange, events[0].Match.EloChange)
+	}
+	if status := w.Statuses()[0]; status.EloChange != 20 || status.LastMatch.EloChange != 20 {
+		t.Errorf("Expected alice's status to show +20, got %+v", status)
+	}
+	if len(recorded) != 1 || recorded[0].MatchID != "a2" || recorded[0].EloChange != 20 {
+		t.Errorf("Expected a2 to be recorded with +20, got %+v", recorded)
+	}
+
+	// A match that never moves the ELO is only held for one poll
+	repo.matches["alice"] = []entity.PlayerMatchSummary{{MatchID: "a3", Result: "Loss"}}
+	if events := w.Poll(ctx); len(events) != 0 {
+		t.Fatalf("Expected a3 to be held, got %v", events)
+	}
+	events = w.Poll(ctx)
+	if len(events) != 1 || events[0].Match == nil || events[0].Match.MatchID != "a3" || events[0].EloChange != 0 {
+		t.Errorf("Expected a3 without an ELO change, got %+v", events)
+	}
+	if len(recorded) != 1 {
+		t.Errorf("Expected a3 not to be recorded without an ELO change, got %+v", recorded)
+	}
+}
+
+func TestWatcherPollError(t *testing.T) {
+	repo := newFakeRepository()
+	repo.fail["ghost"] = true
+
+	w := New(repo, []string{"ghost"}, time.Minute)
+	if events := w.Poll(context.Background()); len(events) != 0 {
+		t.Errorf("Expected no events, got %v", events)
+	}
+	status := w.Statuses()[0]
+	if status.Err == nil || !status.LastChecked.IsZero() {
+		t.Errorf("Expected an error and no successful check, got %+v", status)
+	}
+}
+
+func TestWatcherRunStopsOnCancel(t *testing.T) {
+	repo := newFakeRepository()
+	w := New(repo, []string{"alice"}, time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	polls := 0
+	err := w.Run(ctx, func(events []Event) {
+		polls++
+		cancel()
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if polls != 1 {
+		t.Errorf("Expected one immediate poll, got %d", polls)
+	}
+}
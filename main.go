@@ -40,6 +40,16 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Headless commands print their results to stdout instead of
+	// starting the TUI
+	command := ""
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "teammates", "watch", "serve", "exporter", "sync", "query":
+			command = os.Args[1]
+		}
+	}
+
 	// Load environment variables from .env file if it exists
 	_ = godotenv.Load() // .env file is optional, so we ignore errors
 
@@ -56,7 +66,7 @@ func main() {
 		KafkaTopic:     cfg.KafkaTopic,
 		ServiceName:    "faceit-cli",
 		ProductionMode: cfg.ProductionMode,
-		LogToStdout:    cfg.LogToStdout,
+		LogToStdout:    cfg.LogToStdout && command == "",
 	}
 
 	appLogger, err := logger.New(loggerConfig)
@@ -80,6 +90,8 @@ func main() {
 		Environment:    cfg.Environment,
 		OTLPEndpoint:   cfg.OTLPEndpoint,
 		Enabled:        cfg.TelemetryEnabled,
+		MetricsEnabled:      cfg.MetricsEnabled,
+		OTLPMetricsEndpoint: cfg.OTLPMetricsEndpoint,
 	}
 	
 	telemetryInstance, err := telemetry.New(ctx, telemetryConfig)
@@ -99,12 +111,30 @@ func main() {
 	}()
 	
 	application := app.NewApp(cfg, appLogger, telemetryInstance)
-	
-	if err := application.Run(ctx); err != nil {
+	defer application.Close()
+
+	var runErr error
+	switch command {
+	case "teammates":
+		runErr = application.ExportTeammates(ctx, os.Args[2:], os.Stdout)
+	case "watch":
+		runErr = application.Watch(ctx, os.Args[2:], os.Stdout)
+	case "serve":
+		runErr = application.Serve(ctx, os.Args[2:], os.Stdout)
+	case "exporter":
+		runErr = application.Exporter(ctx, os.Args[2:], os.Stdout)
+	case "sync":
+		runErr = application.Sync(ctx, os.Args[2:], os.Stdout)
+	case "query":
+		runErr = application.Query(ctx, os.Args[2:], os.Stdout)
+	default:
+		runErr = application.Run(ctx)
+	}
+	if runErr != nil {
 		appLogger.Error("Application failed", map[string]interface{}{
-			"error": err.Error(),
+			"error": runErr.Error(),
 		})
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", runErr)
 		os.Exit(1)
 	}
 